@@ -0,0 +1,62 @@
+package await
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+)
+
+// Admission refuses to start tasks while the process is overloaded, so a
+// fan-out during an incident fails fast instead of piling more goroutines
+// onto a struggling service. Set it in Options.Admission; it may be shared by
+// any number of calls.
+type Admission struct {
+	// MaxGoroutines refuses tasks while runtime.NumGoroutine exceeds it
+	// (0 = no limit).
+	MaxGoroutines int
+
+	// Load, if set, reports the current load, such as CPU utilisation or the
+	// depth of a downstream queue. Tasks are refused while it exceeds MaxLoad.
+	// It is called once per call, so it should be cheap, for example by
+	// returning a value sampled in the background.
+	Load    func() float64
+	MaxLoad float64
+}
+
+// Admit returns an error wrapping ErrOverloaded if a task should not be
+// started now, or nil if it may run.
+func (a *Admission) Admit() error {
+	if a.MaxGoroutines > 0 {
+		if n := runtime.NumGoroutine(); n > a.MaxGoroutines {
+			return fmt.Errorf("%w: %d goroutines, limit %d", ErrOverloaded, n, a.MaxGoroutines)
+		}
+	}
+	if a.Load != nil {
+		if load := a.Load(); load > a.MaxLoad {
+			return fmt.Errorf("%w: load %g, limit %g", ErrOverloaded, load, a.MaxLoad)
+		}
+	}
+	return nil
+}
+
+// admit consults opts.Admission once for a whole call, before any of its
+// goroutines are started, so the call's own fan-out never counts against
+// MaxGoroutines. When the call is refused every task is replaced by one
+// failing with the refusal, so callers still get a result per task.
+func admit[T any](opts Options, tasks []Task[T]) []Task[T] {
+	if opts.Admission == nil {
+		return tasks
+	}
+	err := opts.Admission.Admit()
+	if err == nil {
+		return tasks
+	}
+	refused := make([]Task[T], len(tasks))
+	for i := range refused {
+		refused[i] = func(context.Context) (T, error) {
+			var zero T
+			return zero, err
+		}
+	}
+	return refused
+}
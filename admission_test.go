@@ -0,0 +1,90 @@
+package await
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAdmission(t *testing.T) {
+	ctx := context.Background()
+	var ran atomic.Int32
+	task := func(ctx context.Context) (int, error) {
+		ran.Add(1)
+		return 1, nil
+	}
+
+	t.Run("load probe", func(t *testing.T) {
+		var load atomic.Value
+		load.Store(0.9)
+		admission := &Admission{Load: func() float64 { return load.Load().(float64) }, MaxLoad: 0.8}
+		ran.Store(0)
+
+		results, _ := AllWithOptions(ctx, Options{Admission: admission}, task, task)
+		for i, r := range results {
+			if !errors.Is(r.Err, ErrOverloaded) {
+				t.Fatalf("task %d: expected ErrOverloaded, got %v", i, r.Err)
+			}
+		}
+		_, err := AnyWithOptions(ctx, Options{Admission: admission}, task, task)
+		if !errors.Is(err, ErrOverloaded) {
+			t.Fatalf("expected Any to fail with ErrOverloaded, got %v", err)
+		}
+		if ran.Load() != 0 {
+			t.Fatalf("expected no task to run while overloaded, ran %d", ran.Load())
+		}
+
+		load.Store(0.5)
+		if val, err := AnyWithOptions(ctx, Options{Admission: admission}, task, task); err != nil || val != 1 {
+			t.Fatalf("expected tasks admitted once load drops, got %d, %v", val, err)
+		}
+	})
+
+	t.Run("goroutine limit", func(t *testing.T) {
+		ran.Store(0)
+		admission := &Admission{MaxGoroutines: 1}
+		_, err := AnyWithOptions(ctx, Options{Admission: admission, TaskErrors: true}, task)
+		var te *TaskError
+		if !errors.Is(err, ErrOverloaded) || !errors.As(err, &te) {
+			t.Fatalf("expected TaskError wrapping ErrOverloaded, got %v", err)
+		}
+
+		admission.MaxGoroutines = runtime.NumGoroutine() + 100
+		if _, err := AnyWithOptions(ctx, Options{Admission: admission}, task); err != nil {
+			t.Fatalf("expected task admitted under the limit, got %v", err)
+		}
+		if ran.Load() != 1 {
+			t.Fatalf("expected one task to run, ran %d", ran.Load())
+		}
+	})
+
+	t.Run("own fan-out is not counted", func(t *testing.T) {
+		ran.Store(0)
+		admission := &Admission{MaxGoroutines: runtime.NumGoroutine() + 5}
+		slow := func(ctx context.Context) (int, error) {
+			ran.Add(1)
+			time.Sleep(10 * time.Millisecond)
+			return 1, nil
+		}
+		tasks := make([]Task[int], 50)
+		for i := range tasks {
+			tasks[i] = slow
+		}
+
+		results, err := AllWithOptions(ctx, Options{Admission: admission}, tasks...)
+		if err != nil {
+			t.Fatalf("expected no function error, got %v", err)
+		}
+		for i, r := range results {
+			if r.Err != nil {
+				t.Fatalf("task %d: expected to be admitted on an idle process, got %v", i, r.Err)
+			}
+		}
+		if ran.Load() != 50 {
+			t.Fatalf("expected every task to run, ran %d", ran.Load())
+		}
+	})
+}
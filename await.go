@@ -5,14 +5,20 @@ package await
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"reflect"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // Result holds either a value or an error from an async operation.
 // Used by All to return both successful and failed results for each task.
 type Result[T any] struct {
-	Value T     // The successful result value (or zero value if failed)
-	Err   error // The error if the operation failed (nil if succeeded)
+	Value  T     // The successful result value (or zero value if failed)
+	Err    error // The error if the operation failed (nil if succeeded)
+	Timing       // When the task ran; only filled in with Options.Timing
 }
 
 // Task represents an async operation that returns a value of type T or an error.
@@ -28,6 +34,13 @@ type Task[T any] func(ctx context.Context) (T, error)
 // like empty task list or context cancellation before execution.
 // Task-level errors are captured in each Result[T].Err field.
 func All[T any](ctx context.Context, tasks ...Task[T]) ([]Result[T], error) {
+	return AllWithOptions(ctx, Options{}, tasks...)
+}
+
+// AllWithOptions is like All but applies the given execution options. When
+// Options.AbortAfterFailures stops the run, it returns the results together
+// with an error wrapping ErrTooManyFailures.
+func AllWithOptions[T any](ctx context.Context, opts Options, tasks ...Task[T]) ([]Result[T], error) {
 	// Validate inputs - function-level errors
 	if len(tasks) == 0 {
 		return nil, ErrNoTasks
@@ -37,109 +50,417 @@ func All[T any](ctx context.Context, tasks ...Task[T]) ([]Result[T], error) {
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
 	}
+	tasks = admit(opts, tasks)
 
-	results := make([]Result[T], len(tasks))
-	var wg sync.WaitGroup
+	var order []int
+	if opts.Deadlines != nil {
+		var err error
+		if tasks, order, err = withDeadlines(opts.Deadlines, tasks); err != nil {
+			return nil, err
+		}
+	}
 
-	for i, t := range tasks {
-		wg.Add(1)
-		go func(idx int, task Task[T]) {
-			defer wg.Done()
-			select {
-			case <-ctx.Done():
-				results[idx] = Result[T]{Err: ctx.Err()}
-				return
-			default:
-				val, err := task(ctx)
-				results[idx] = Result[T]{Value: val, Err: err}
+	parent := ctx
+	var abort context.CancelCauseFunc
+	if opts.AbortAfterFailures > 0 {
+		ctx, abort = context.WithCancelCause(ctx)
+		defer abort(nil)
+	}
+
+	// A single task gains nothing from a goroutine, so it runs inline like Sequential.
+	if opts.Sequential || len(tasks) == 1 {
+		results := allSequential(ctx, opts, tasks, abort)
+		if abort != nil {
+			return results, abortError(parent, ctx)
+		}
+		return results, nil
+	}
+
+	st := &allState[T]{
+		ctx:     ctx,
+		opts:    opts,
+		abort:   abort,
+		results: make([]Result[T], len(tasks)),
+		order:   order,
+		done:    make(chan struct{}),
+	}
+	st.remaining.Store(int32(len(tasks)))
+	if trackingEnabled(opts) {
+		st.infos = make([]*taskInfo, len(tasks))
+		for i := range tasks {
+			st.infos[i] = &taskInfo{index: i}
+		}
+	}
+
+	if limit := opts.MaxConcurrency; limit > 0 && limit < len(tasks) {
+		goroutines.Add(int64(limit))
+		for i := 0; i < limit; i++ {
+			go st.work(tasks)
+		}
+	} else {
+		goroutines.Add(int64(len(tasks)))
+		for i, t := range tasks {
+			go st.run(i, t)
+		}
+	}
+
+	<-st.done
+	if st.infos != nil {
+		logAllFailures(ctx, st.results, st.infos)
+	}
+	if abort != nil {
+		return st.results, abortError(parent, ctx)
+	}
+	return st.results, nil
+}
+
+// abortError returns the cause of ctx if this All call aborted it through
+// Options.AbortAfterFailures, and nil otherwise. A cause ctx inherited from
+// parent, such as an enclosing All giving up, belongs to the caller.
+func abortError(parent, ctx context.Context) error {
+	cause := context.Cause(ctx)
+	if cause == nil || cause == context.Cause(parent) || !errors.Is(cause, ErrTooManyFailures) {
+		return nil
+	}
+	return cause
+}
+
+// abortCause is the cancellation cause once failures tasks have failed.
+func abortCause(failures int) error {
+	return fmt.Errorf("%w: %d tasks failed", ErrTooManyFailures, failures)
+}
+
+// allState is shared by the goroutines of a single All call. Each goroutine
+// writes only its own result slot; the last one to finish closes done.
+type allState[T any] struct {
+	ctx       context.Context
+	opts      Options
+	abort     context.CancelCauseFunc // Cancels ctx; only set with AbortAfterFailures
+	failures  atomic.Int32            // Failed tasks, counted with AbortAfterFailures
+	results   []Result[T]
+	infos     []*taskInfo
+	remaining atomic.Int32
+	next      atomic.Int32 // Position in order of the next task to start, with MaxConcurrency
+	order     []int        // Start order with MaxConcurrency; nil for argument order
+	done      chan struct{}
+}
+
+func (st *allState[T]) run(idx int, task Task[T]) {
+	defer goroutines.Add(-1)
+	st.runOne(idx, task)
+}
+
+// work is one of MaxConcurrency workers: it runs tasks in start order until
+// none are left, skipping the ones it reaches after ctx is done.
+func (st *allState[T]) work(tasks []Task[T]) {
+	defer goroutines.Add(-1)
+	for {
+		idx := int(st.next.Add(1)) - 1
+		if idx >= len(tasks) {
+			return
+		}
+		if st.order != nil {
+			idx = st.order[idx]
+		}
+		if st.ctx.Err() != nil {
+			st.skip(idx)
+			continue
+		}
+		st.runOne(idx, tasks[idx])
+	}
+}
+
+// skip records that task idx was never started.
+func (st *allState[T]) skip(idx int) {
+	st.results[idx] = Result[T]{Err: skippedError(st.ctx, st.opts, idx)}
+	st.finish()
+}
+
+// skippedError is the error recorded for task idx of All when it was not
+// started because ctx was done.
+func skippedError(ctx context.Context, opts Options, idx int) error {
+	err := fmt.Errorf("%w: %w", ErrSkipped, context.Cause(ctx))
+	if opts.TaskErrors {
+		err = &TaskError{Index: idx, Err: err}
+	}
+	return err
+}
+
+func (st *allState[T]) runOne(idx int, task Task[T]) {
+	if st.ctx.Err() != nil {
+		st.results[idx] = Result[T]{Err: skippedError(st.ctx, st.opts, idx)}
+	} else {
+		var info *taskInfo
+		if st.infos != nil {
+			info = st.infos[idx]
+		}
+		val, err := runTask(st.ctx, st.opts, info, task)
+		st.results[idx] = Result[T]{Value: val, Err: err, Timing: info.timing()}
+		if err != nil && st.abort != nil {
+			if n := int(st.failures.Add(1)); n == st.opts.AbortAfterFailures {
+				st.abort(abortCause(n))
 			}
-		}(i, t)
+		}
 	}
+	st.finish()
+}
 
-	wg.Wait()
-	return results, nil
+// finish counts a task as done and closes done after the last one.
+func (st *allState[T]) finish() {
+	if st.remaining.Add(-1) == 0 {
+		close(st.done)
+	}
 }
 
 // Any executes all tasks concurrently and returns when the first task succeeds.
 // Returns the value from the first successful task, or an AggregateError
 // if all tasks fail. Similar to Promise.any in JavaScript.
 func Any[T any](ctx context.Context, tasks ...Task[T]) (T, error) {
+	return AnyWithOptions(ctx, Options{}, tasks...)
+}
+
+// AnyWithOptions is like Any but applies the given execution options.
+func AnyWithOptions[T any](ctx context.Context, opts Options, tasks ...Task[T]) (T, error) {
+	return runAny(ctx, opts, tasks, nil)
+}
+
+// runAny implements AnyWithOptions, filling report when it is non-nil.
+func runAny[T any](ctx context.Context, opts Options, tasks []Task[T], report *AnyReport) (T, error) {
 	var zero T
 	if len(tasks) == 0 {
 		return zero, ErrNoTasks
 	}
 
-	ctx, cancel := context.WithCancel(ctx)
-	defer cancel()
+	order, err := launchOrder(opts, len(tasks))
+	if err != nil {
+		return zero, err
+	}
+	tasks = admit(opts, tasks)
 
-	type result struct {
-		val T
-		err error
+	// A single task gains nothing from a goroutine, so it runs inline like Sequential.
+	if opts.Sequential || len(tasks) == 1 {
+		return anySequential(ctx, opts, tasks, order, report)
 	}
 
-	ch := make(chan result, len(tasks))
+	ctx, cancel := context.WithCancelCause(ctx)
 
-	for _, t := range tasks {
-		go func(task Task[T]) {
-			select {
-			case <-ctx.Done():
-				ch <- result{err: ctx.Err()}
-				return
-			default:
-				val, err := task(ctx)
-				ch <- result{val, err}
-			}
-		}(t)
+	st := newFirstState[T](ctx, opts, len(tasks), false)
+	st.report = report
+	if opts.LoserGrace > 0 {
+		st.finished = make(chan struct{})
 	}
+	st.launch(tasks, order)
 
-	errors := make([]error, 0, len(tasks))
-	for i := 0; i < len(tasks); i++ {
-		res := <-ch
-		if res.err == nil {
-			cancel() // Cancel remaining
-			return res.val, nil
+	<-st.done
+	st.watchLeaks("Any")
+	if st.won {
+		if st.finished != nil {
+			goroutines.Add(1)
+			go cancelAfterGrace(cancel, st.finished, opts.LoserGrace)
+		} else {
+			cancel(ErrSiblingSucceeded) // Cancel remaining
 		}
-		errors = append(errors, res.err)
+		return st.val, nil
 	}
+	defer cancel(nil)
 
-	return zero, &AggregateError{Errors: errors}
+	aggErr := newAggregateError(opts, st.errs, st.indices, st.failed)
+	logAggregateFailure(ctx, len(tasks), aggErr, st.failed)
+	return zero, aggErr
 }
 
 // Race executes all tasks concurrently and returns the first to complete,
 // whether it succeeds or fails. Similar to Promise.race in JavaScript.
 func Race[T any](ctx context.Context, tasks ...Task[T]) (T, error) {
+	return RaceWithOptions(ctx, Options{}, tasks...)
+}
+
+// RaceWithOptions is like Race but applies the given execution options.
+func RaceWithOptions[T any](ctx context.Context, opts Options, tasks ...Task[T]) (T, error) {
 	var zero T
 	if len(tasks) == 0 {
 		return zero, ErrNoTasks
 	}
+	tasks = admit(opts, tasks)
+
+	// Run sequentially, the first task is always the first to complete.
+	if opts.Sequential || len(tasks) == 1 {
+		return runSingle(ctx, opts, singleTaskInfo(opts), tasks[0])
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	st := newFirstState[T](ctx, opts, len(tasks), true)
+	st.launch(tasks, nil)
+
+	<-st.done
+	cancel(ErrSiblingCompleted) // Cancel remaining
+	st.watchLeaks("Race")
+	if st.won {
+		return st.val, nil
+	}
+	return zero, st.errs[0]
+}
+
+// firstState is shared by the goroutines of a single Any or Race call.
+// It settles once: on the first success (Any), the first completion (Race),
+// or when every task has failed. Goroutines finishing after that only
+// decrement the outstanding count.
+type firstState[T any] struct {
+	ctx             context.Context
+	opts            Options
+	firstCompletion bool // Race semantics: settle on the first completion, success or not
+	tracking        bool
+	report          *AnyReport    // Optional, filled for AnyWithReport
+	finished        chan struct{} // Closed when every task has returned; only set with LoserGrace
+	leaks           *leakDetector // Set when leak detection was enabled at the start of the call
+	failure         chan struct{} // Signals the staggered launcher that a task failed (Any only)
+
+	mu        sync.Mutex
+	remaining int
+	settled   bool
+	won       bool
+	val       T
+	errs      []error
+	indices   []int // Task index of each entry in errs (Any only)
+	failed    []*taskInfo
+	running   []bool // Per-task running flags, only tracked for leak detection
+	winner    int    // Index of the winning task once won is set
+	done      chan struct{}
+}
+
+func newFirstState[T any](ctx context.Context, opts Options, n int, firstCompletion bool) *firstState[T] {
+	st := &firstState[T]{
+		ctx:             ctx,
+		opts:            opts,
+		firstCompletion: firstCompletion,
+		tracking:        trackingEnabled(opts),
+		remaining:       n,
+		done:            make(chan struct{}),
+	}
+	if !firstCompletion {
+		st.errs = make([]error, 0, n)
+		st.indices = make([]int, 0, n)
+	}
+	if d := leaks.Load(); d != nil {
+		st.leaks = d
+		st.running = make([]bool, n)
+		for i := range st.running {
+			st.running[i] = true
+		}
+	}
+	return st
+}
 
-	ctx, cancel := context.WithCancel(ctx)
-	defer cancel()
+func (st *firstState[T]) run(idx int, task Task[T]) {
+	defer goroutines.Add(-1)
+	var info *taskInfo
+	if st.tracking {
+		info = &taskInfo{index: idx}
+	}
 
-	type result struct {
-		val T
-		err error
+	var val T
+	err := st.ctx.Err()
+	if err == nil {
+		val, err = runTask(st.ctx, st.opts, info, task)
 	}
 
-	ch := make(chan result, len(tasks))
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.remaining--
+	if st.running != nil {
+		st.running[idx] = false
+	}
+	if st.remaining == 0 && st.finished != nil {
+		close(st.finished)
+	}
+	if st.settled {
+		if st.won && !st.firstCompletion && err == nil && st.opts.OnMismatch != nil {
+			st.crossCheck(idx, val)
+		}
+		if st.won && st.opts.OnLoser != nil {
+			st.opts.OnLoser(idx, err)
+		}
+		return
+	}
 
-	for _, t := range tasks {
-		go func(task Task[T]) {
+	switch {
+	case err == nil:
+		st.val = val
+		st.won = true
+		st.winner = idx
+		if st.report != nil {
+			st.report.Winner = idx
+			st.report.Timing = info.timing()
+		}
+		st.settled = true
+		close(st.done)
+	case st.firstCompletion:
+		st.errs = append(st.errs, err)
+		st.settled = true
+		close(st.done)
+	default:
+		if st.failure != nil {
 			select {
-			case <-ctx.Done():
-				ch <- result{err: ctx.Err()}
-				return
+			case st.failure <- struct{}{}:
 			default:
-				val, err := task(ctx)
-				select {
-				case ch <- result{val, err}:
-				case <-ctx.Done():
-				}
 			}
-		}(t)
+		}
+		st.errs = append(st.errs, err)
+		st.indices = append(st.indices, idx)
+		if info != nil {
+			st.failed = append(st.failed, info)
+		}
+		if st.report != nil {
+			st.report.Failures = append(st.report.Failures, IndexedError{Index: idx, Err: err})
+		}
+		if st.remaining == 0 {
+			st.settled = true
+			close(st.done)
+		}
 	}
+}
+
+// crossCheck reports the value of task idx, which succeeded after the winner,
+// to OnMismatch if it differs from the winner's. Called with st.mu held.
+func (st *firstState[T]) crossCheck(idx int, val T) {
+	want, got := any(st.val), any(val)
+	same := st.opts.SameValue
+	if same == nil {
+		same = reflect.DeepEqual
+	}
+	if !same(want, got) {
+		st.opts.OnMismatch(st.winner, idx, want, got)
+	}
+}
+
+// cancelAfterGrace lets the losers of a settled Any call keep running until
+// they have all returned or grace has elapsed, then cancels them.
+func cancelAfterGrace(cancel context.CancelCauseFunc, finished <-chan struct{}, grace time.Duration) {
+	defer goroutines.Add(-1)
+	timer := time.NewTimer(grace)
+	defer timer.Stop()
+	select {
+	case <-finished:
+	case <-timer.C:
+	}
+	cancel(ErrSiblingSucceeded)
+}
+
+// singleTaskInfo returns identity tracking for a lone task, or nil when not needed.
+func singleTaskInfo(opts Options) *taskInfo {
+	if !trackingEnabled(opts) {
+		return nil
+	}
+	return &taskInfo{index: 0}
+}
 
-	res := <-ch
-	cancel() // Cancel remaining
-	return res.val, res.err
+// runSingle runs one task inline, failing with the context error if ctx is already done.
+func runSingle[T any](ctx context.Context, opts Options, info *taskInfo, task Task[T]) (T, error) {
+	if err := ctx.Err(); err != nil {
+		var zero T
+		return zero, err
+	}
+	return runTask(ctx, opts, info, task)
 }
@@ -1,8 +1,15 @@
 package await
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"log/slog"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -163,6 +170,229 @@ func TestAll(t *testing.T) {
 	})
 }
 
+func TestAllMaxConcurrency(t *testing.T) {
+	t.Run("limits running tasks", func(t *testing.T) {
+		var running, peak atomic.Int32
+		task := func(ctx context.Context) (int, error) {
+			n := running.Add(1)
+			defer running.Add(-1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(2 * time.Millisecond)
+			return int(n), nil
+		}
+		tasks := []Task[int]{task, task, task, task, task, task}
+
+		results, err := AllWithOptions(context.Background(), Options{MaxConcurrency: 2}, tasks...)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(results) != len(tasks) || peak.Load() > 2 {
+			t.Fatalf("expected %d results with at most 2 running, got %d results, peak %d", len(tasks), len(results), peak.Load())
+		}
+	})
+
+	t.Run("queued tasks are skipped on cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		errDown := errors.New("down")
+		var started atomic.Int32
+		first := func(ctx context.Context) (int, error) {
+			started.Add(1)
+			cancel()
+			return 0, errDown
+		}
+		queued := func(ctx context.Context) (int, error) {
+			started.Add(1)
+			return 1, nil
+		}
+
+		results, err := AllWithOptions(ctx, Options{MaxConcurrency: 1, TaskErrors: true}, first, queued, queued)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if started.Load() != 1 {
+			t.Fatalf("expected only the first task to start, got %d", started.Load())
+		}
+		if !errors.Is(results[0].Err, errDown) || errors.Is(results[0].Err, ErrSkipped) {
+			t.Errorf("expected first task's own failure, got %v", results[0].Err)
+		}
+		for _, r := range results[1:] {
+			var taskErr *TaskError
+			if !errors.Is(r.Err, ErrSkipped) || !errors.Is(r.Err, context.Canceled) || !errors.As(r.Err, &taskErr) {
+				t.Errorf("expected skipped task error wrapping context.Canceled, got %v", r.Err)
+			}
+		}
+	})
+}
+
+func TestAllAbortAfterFailures(t *testing.T) {
+	errDown := errors.New("down")
+	fail := func(ctx context.Context) (int, error) { return 0, errDown }
+	ok := func(ctx context.Context) (int, error) { return 1, nil }
+
+	t.Run("cancels running tasks", func(t *testing.T) {
+		causes := make(chan error, 1)
+		started := make(chan struct{})
+		slow := func(ctx context.Context) (int, error) {
+			close(started)
+			<-ctx.Done()
+			causes <- context.Cause(ctx)
+			return 0, ctx.Err()
+		}
+		failAfterStart := func(ctx context.Context) (int, error) {
+			<-started
+			return fail(ctx)
+		}
+
+		results, err := AllWithOptions(context.Background(), Options{AbortAfterFailures: 2}, ok, failAfterStart, slow, failAfterStart)
+		if !errors.Is(err, ErrTooManyFailures) {
+			t.Fatalf("expected ErrTooManyFailures, got %v", err)
+		}
+		if len(results) != 4 || results[0].Value != 1 || !errors.Is(results[1].Err, errDown) || !errors.Is(results[3].Err, errDown) {
+			t.Fatalf("expected every result returned, got %+v", results)
+		}
+		if cause := <-causes; !errors.Is(cause, ErrTooManyFailures) {
+			t.Fatalf("expected running task cancelled with ErrTooManyFailures, got %v", cause)
+		}
+	})
+
+	t.Run("skips queued tasks", func(t *testing.T) {
+		for _, opts := range []Options{
+			{AbortAfterFailures: 1, MaxConcurrency: 1},
+			{AbortAfterFailures: 1, Sequential: true},
+		} {
+			var started atomic.Int32
+			counted := func(ctx context.Context) (int, error) {
+				started.Add(1)
+				return 1, nil
+			}
+
+			results, err := AllWithOptions(context.Background(), opts, counted, fail, counted, counted)
+			if !errors.Is(err, ErrTooManyFailures) {
+				t.Fatalf("%+v: expected ErrTooManyFailures, got %v", opts, err)
+			}
+			if started.Load() != 1 || results[0].Err != nil {
+				t.Fatalf("%+v: expected only the first task to run, got %d", opts, started.Load())
+			}
+			for _, r := range results[2:] {
+				if !errors.Is(r.Err, ErrSkipped) || !errors.Is(r.Err, ErrTooManyFailures) {
+					t.Errorf("%+v: expected skipped task wrapping ErrTooManyFailures, got %v", opts, r.Err)
+				}
+			}
+		}
+	})
+
+	t.Run("skips tasks started after cancellation", func(t *testing.T) {
+		// The context passes All's initial check and is cancelled before
+		// any task goroutine starts.
+		ctx := &cancelledAfterCheck{Context: context.Background()}
+		results, err := AllWithOptions[int](ctx, Options{TaskErrors: true}, ok, ok, ok)
+		if err != nil {
+			t.Fatalf("expected no function error, got %v", err)
+		}
+		for i, r := range results {
+			var taskErr *TaskError
+			if !errors.Is(r.Err, ErrSkipped) || !errors.Is(r.Err, context.Canceled) || !errors.As(r.Err, &taskErr) || taskErr.Index != i {
+				t.Errorf("expected task %d skipped with context.Canceled, got %v", i, r.Err)
+			}
+		}
+	})
+
+	t.Run("ignores an enclosing abort", func(t *testing.T) {
+		started := make(chan struct{})
+		inner := make(chan error, 1)
+		nested := func(ctx context.Context) (int, error) {
+			_, err := AllWithOptions(ctx, Options{AbortAfterFailures: 1}, func(ctx context.Context) (int, error) {
+				close(started)
+				<-ctx.Done()
+				return 0, ctx.Err()
+			})
+			inner <- err
+			return 0, ctx.Err()
+		}
+		failAfterStart := func(ctx context.Context) (int, error) {
+			<-started
+			return fail(ctx)
+		}
+
+		_, err := AllWithOptions(context.Background(), Options{AbortAfterFailures: 1}, nested, failAfterStart)
+		if !errors.Is(err, ErrTooManyFailures) {
+			t.Fatalf("expected ErrTooManyFailures, got %v", err)
+		}
+		if err := <-inner; err != nil {
+			t.Fatalf("expected nested All not to report the enclosing abort, got %v", err)
+		}
+	})
+
+	t.Run("fewer failures than the limit", func(t *testing.T) {
+		results, err := AllWithOptions(context.Background(), Options{AbortAfterFailures: 3}, ok, fail, ok, fail)
+		if err != nil || results[2].Err != nil {
+			t.Fatalf("expected run to complete, got %v, %+v", err, results)
+		}
+	})
+}
+
+func TestAllDeadlines(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	t.Run("earliest deadline first", func(t *testing.T) {
+		var mu sync.Mutex
+		var started []int
+		task := func(i int) Task[int] {
+			return func(ctx context.Context) (int, error) {
+				mu.Lock()
+				started = append(started, i)
+				mu.Unlock()
+				return i, nil
+			}
+		}
+		deadlines := []time.Time{{}, now.Add(time.Hour), {}, now.Add(time.Minute)}
+
+		_, err := AllWithOptions(ctx, Options{MaxConcurrency: 1, Deadlines: deadlines}, task(0), task(1), task(2), task(3))
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if want := []int{3, 1, 0, 2}; !slices.Equal(started, want) {
+			t.Fatalf("expected start order %v, got %v", want, started)
+		}
+	})
+
+	t.Run("deadline applied to task context", func(t *testing.T) {
+		deadline := now.Add(time.Minute)
+		results, _ := AllWithOptions(ctx, Options{Deadlines: []time.Time{deadline, {}}},
+			func(ctx context.Context) (int, error) {
+				if d, ok := ctx.Deadline(); !ok || !d.Equal(deadline) {
+					return 0, errors.New("missing deadline")
+				}
+				return 1, nil
+			},
+			func(ctx context.Context) (int, error) {
+				if _, ok := ctx.Deadline(); ok {
+					return 0, errors.New("unexpected deadline")
+				}
+				return 2, nil
+			},
+		)
+		for _, r := range results {
+			if r.Err != nil {
+				t.Error(r.Err)
+			}
+		}
+	})
+
+	t.Run("one deadline per task", func(t *testing.T) {
+		ok := func(ctx context.Context) (int, error) { return 1, nil }
+		if _, err := AllWithOptions(ctx, Options{Deadlines: []time.Time{now}}, ok, ok); !errors.Is(err, ErrInvalidDeadlines) {
+			t.Fatalf("expected ErrInvalidDeadlines, got %v", err)
+		}
+	})
+}
+
 func TestAny(t *testing.T) {
 	ctx := context.Background()
 
@@ -214,6 +444,213 @@ func TestAny(t *testing.T) {
 	})
 }
 
+func TestLoserGrace(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("losers finish within grace", func(t *testing.T) {
+		var mu sync.Mutex
+		var losers []error
+		finished := make(chan struct{})
+
+		opts := Options{
+			LoserGrace: time.Second,
+			OnLoser: func(index int, err error) {
+				mu.Lock()
+				losers = append(losers, err)
+				mu.Unlock()
+				close(finished)
+			},
+		}
+		val, err := AnyWithOptions(ctx, opts,
+			func(ctx context.Context) (int, error) { return 1, nil },
+			func(ctx context.Context) (int, error) {
+				select {
+				case <-time.After(30 * time.Millisecond):
+					return 2, nil
+				case <-ctx.Done():
+					return 0, ctx.Err()
+				}
+			},
+		)
+		if err != nil || val != 1 {
+			t.Fatalf("expected 1, got %d, %v", val, err)
+		}
+
+		<-finished
+		mu.Lock()
+		defer mu.Unlock()
+		if len(losers) != 1 || losers[0] != nil {
+			t.Fatalf("expected loser to complete without cancellation, got %v", losers)
+		}
+	})
+
+	t.Run("losers cancelled after grace", func(t *testing.T) {
+		loserErr := make(chan error, 1)
+		opts := Options{
+			LoserGrace: 20 * time.Millisecond,
+			OnLoser:    func(index int, err error) { loserErr <- err },
+		}
+		_, err := AnyWithOptions(ctx, opts,
+			func(ctx context.Context) (int, error) { return 1, nil },
+			func(ctx context.Context) (int, error) {
+				<-ctx.Done()
+				return 0, ctx.Err()
+			},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		select {
+		case err := <-loserErr:
+			if !errors.Is(err, context.Canceled) {
+				t.Fatalf("expected context.Canceled, got %v", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("loser was not cancelled after grace period")
+		}
+	})
+}
+
+func TestOnMismatch(t *testing.T) {
+	type mismatch struct {
+		winner, index int
+		want, got     any
+	}
+	mismatches := make(chan mismatch, 2)
+	checked := make(chan struct{}, 2)
+	opts := Options{
+		LoserGrace: time.Second,
+		OnLoser:    func(index int, err error) { checked <- struct{}{} },
+		OnMismatch: func(winner, index int, want, got any) {
+			mismatches <- mismatch{winner, index, want, got}
+		},
+	}
+	after := func(d time.Duration, v string) Task[string] {
+		return func(ctx context.Context) (string, error) {
+			select {
+			case <-time.After(d):
+				return v, nil
+			case <-ctx.Done():
+				return "", ctx.Err()
+			}
+		}
+	}
+
+	val, err := AnyWithOptions(context.Background(), opts,
+		after(20*time.Millisecond, "VERIFIED"),
+		after(0, "VERIFIED"),
+		after(40*time.Millisecond, "REJECTED"),
+	)
+	if err != nil || val != "VERIFIED" {
+		t.Fatalf("expected VERIFIED, got %q, %v", val, err)
+	}
+
+	<-checked
+	<-checked
+	close(mismatches)
+	var got []mismatch
+	for m := range mismatches {
+		got = append(got, m)
+	}
+	if len(got) != 1 || got[0] != (mismatch{1, 2, "VERIFIED", "REJECTED"}) {
+		t.Fatalf("expected task 2 to mismatch winner 1, got %+v", got)
+	}
+
+	t.Run("ignored by Race", func(t *testing.T) {
+		mismatched := make(chan struct{}, 1)
+		opts := Options{
+			OnLoser:    func(index int, err error) { checked <- struct{}{} },
+			OnMismatch: func(winner, index int, want, got any) { mismatched <- struct{}{} },
+		}
+		// The slower task ignores cancellation, so it succeeds after the winner.
+		started := make(chan struct{})
+		fast := func(ctx context.Context) (string, error) {
+			<-started
+			return "VERIFIED", nil
+		}
+		slow := func(ctx context.Context) (string, error) {
+			close(started)
+			time.Sleep(20 * time.Millisecond)
+			return "REJECTED", nil
+		}
+
+		val, err := RaceWithOptions(context.Background(), opts, fast, slow)
+		if err != nil || val != "VERIFIED" {
+			t.Fatalf("expected VERIFIED, got %q, %v", val, err)
+		}
+		<-checked
+		select {
+		case <-mismatched:
+			t.Fatal("expected OnMismatch not to be called for Race")
+		default:
+		}
+	})
+}
+
+func TestCancellationCause(t *testing.T) {
+	loser := func(started chan<- struct{}, causes chan<- error) Task[int] {
+		return func(ctx context.Context) (int, error) {
+			close(started)
+			<-ctx.Done()
+			causes <- context.Cause(ctx)
+			if !CancelledBySibling(ctx) {
+				return 0, errors.New("expected sibling cancellation")
+			}
+			return 0, ctx.Err()
+		}
+	}
+
+	t.Run("Any", func(t *testing.T) {
+		started := make(chan struct{})
+		causes := make(chan error, 1)
+		winner := func(ctx context.Context) (int, error) {
+			<-started
+			return 1, nil
+		}
+		if _, err := Any(context.Background(), winner, loser(started, causes)); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if cause := <-causes; cause != ErrSiblingSucceeded {
+			t.Fatalf("expected ErrSiblingSucceeded, got %v", cause)
+		}
+	})
+
+	t.Run("Race", func(t *testing.T) {
+		started := make(chan struct{})
+		causes := make(chan error, 1)
+		winner := func(ctx context.Context) (int, error) {
+			<-started
+			return 0, errors.New("fast failure")
+		}
+		Race(context.Background(), winner, loser(started, causes))
+		if cause := <-causes; cause != ErrSiblingCompleted {
+			t.Fatalf("expected ErrSiblingCompleted, got %v", cause)
+		}
+	})
+
+	t.Run("parent cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		started := make(chan struct{}, 2)
+		results := make(chan bool, 2)
+		task := func(ctx context.Context) (int, error) {
+			started <- struct{}{}
+			<-ctx.Done()
+			results <- CancelledBySibling(ctx)
+			return 0, ctx.Err()
+		}
+		go func() {
+			<-started
+			<-started
+			cancel()
+		}()
+		Any(ctx, task, task)
+		if <-results || <-results {
+			t.Fatal("expected parent cancellation not to be reported as sibling cancellation")
+		}
+	})
+}
+
 func TestRace(t *testing.T) {
 	ctx := context.Background()
 
@@ -250,4 +687,143 @@ func TestRace(t *testing.T) {
 			t.Fatalf("expected quick error, got %v", err)
 		}
 	})
+
+	t.Run("parent context expires while tasks run", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		// The tasks ignore ctx, so their results arrive after it has expired.
+		var started sync.WaitGroup
+		started.Add(2)
+		release := make(chan struct{})
+		slow := Task[int](func(ctx context.Context) (int, error) {
+			started.Done()
+			<-release
+			return 1, nil
+		})
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			if val, err := Race(ctx, slow, slow); err != nil || val != 1 {
+				t.Errorf("expected the first result to be kept, got %d, %v", val, err)
+			}
+		}()
+		started.Wait()
+		cancel()
+		close(release)
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("expected Race to return once a task finished")
+		}
+	})
+}
+
+func TestSetLogger(t *testing.T) {
+	var buf bytes.Buffer
+	SetLogger(slog.New(slog.NewJSONHandler(&buf, nil)))
+	defer SetLogger(nil)
+
+	t1 := Task[int](func(ctx context.Context) (int, error) {
+		return 0, errors.New("error 1")
+	})
+	t2 := Task[int](func(ctx context.Context) (int, error) {
+		return 0, errors.New("error 2")
+	})
+
+	if _, err := Any(context.Background(), t1, t2); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, `"msg":"all tasks failed"`) || !strings.Contains(out, `"failures":2`) {
+		t.Fatalf("expected aggregate failure event, got %s", out)
+	}
+}
+
+func TestSingleTaskFastPath(t *testing.T) {
+	ctx := context.Background()
+
+	// onCallerStack reports whether the task runs on the test's goroutine.
+	onCallerStack := func() bool {
+		buf := make([]byte, 8192)
+		return strings.Contains(string(buf[:runtime.Stack(buf, false)]), "TestSingleTaskFastPath")
+	}
+
+	t.Run("All runs inline", func(t *testing.T) {
+		var inline bool
+		results, err := All(ctx, Task[int](func(ctx context.Context) (int, error) {
+			inline = onCallerStack()
+			return 1, nil
+		}))
+		if err != nil || len(results) != 1 || results[0].Value != 1 {
+			t.Fatalf("expected [{1, nil}], got %v, %v", results, err)
+		}
+		if !inline {
+			t.Fatal("expected single task to run on the caller's goroutine")
+		}
+	})
+
+	t.Run("Any wraps failure in AggregateError", func(t *testing.T) {
+		var inline bool
+		_, err := Any(ctx, Task[int](func(ctx context.Context) (int, error) {
+			inline = onCallerStack()
+			return 0, errors.New("failed")
+		}))
+		aggErr, ok := err.(*AggregateError)
+		if !ok || len(aggErr.Errors) != 1 {
+			t.Fatalf("expected AggregateError with 1 error, got %v", err)
+		}
+		if !inline {
+			t.Fatal("expected single task to run on the caller's goroutine")
+		}
+	})
+
+	t.Run("Race returns task result", func(t *testing.T) {
+		var inline bool
+		_, err := Race(ctx, Task[int](func(ctx context.Context) (int, error) {
+			inline = onCallerStack()
+			return 0, errors.New("failed")
+		}))
+		if err == nil || err.Error() != "failed" {
+			t.Fatalf("expected 'failed', got %v", err)
+		}
+		if !inline {
+			t.Fatal("expected single task to run on the caller's goroutine")
+		}
+	})
+
+	t.Run("respects cancelled context", func(t *testing.T) {
+		cctx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		called := false
+		task := Task[int](func(ctx context.Context) (int, error) {
+			called = true
+			return 1, nil
+		})
+		if _, err := Race(cctx, task); err != context.Canceled {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+		if _, err := Any(cctx, task); !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+		if called {
+			t.Fatal("expected task not to run with a cancelled context")
+		}
+	})
+}
+
+// cancelledAfterCheck is a context that reports cancellation from its second
+// Err call on, so it passes the check All makes before starting any task.
+type cancelledAfterCheck struct {
+	context.Context
+	checked atomic.Bool
+}
+
+func (c *cancelledAfterCheck) Err() error {
+	if c.checked.Swap(true) {
+		return context.Canceled
+	}
+	return nil
 }
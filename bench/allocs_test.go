@@ -0,0 +1,68 @@
+package bench
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/remiges-tech/await"
+	"github.com/remiges-tech/await/retry"
+)
+
+// Concurrent calls allocate one goroutine closure per task plus a small fixed
+// amount of shared state; fixedAllocs bounds the latter.
+const fixedAllocs = 8
+
+// perTaskAllocs is the per-task budget of each call. Any and Race return
+// before their losers have been scheduled, and AllocsPerRun runs with
+// GOMAXPROCS=1, so the runtime cannot reuse the losers' goroutine
+// descriptors and allocates fresh ones on the next run.
+var perTaskAllocs = map[string]int{"All": 1, "Any": 2, "Race": 2}
+
+func TestAllocsPerCall(t *testing.T) {
+	if testing.Short() {
+		t.Skip("allocation budgets are not checked in short mode")
+	}
+	ctx := context.Background()
+
+	calls := map[string]func(ts []await.Task[int]){
+		"All":  func(ts []await.Task[int]) { await.All(ctx, ts...) },
+		"Any":  func(ts []await.Task[int]) { await.Any(ctx, ts...) },
+		"Race": func(ts []await.Task[int]) { await.Race(ctx, ts...) },
+	}
+
+	for name, call := range calls {
+		t.Run(name+"/inline", func(t *testing.T) {
+			ts := tasks(1, false)
+			if allocs := testing.AllocsPerRun(100, func() { call(ts) }); allocs > 1 {
+				t.Errorf("expected at most 1 alloc for a single task, got %.0f", allocs)
+			}
+		})
+
+		for _, n := range []int{10, 100} {
+			t.Run(fmt.Sprintf("%s/tasks=%d", name, n), func(t *testing.T) {
+				ts := tasks(n, false)
+				budget := float64(perTaskAllocs[name]*n + fixedAllocs)
+				if allocs := testing.AllocsPerRun(100, func() { call(ts) }); allocs > budget {
+					t.Errorf("expected at most %.0f allocs, got %.0f", budget, allocs)
+				}
+			})
+		}
+	}
+}
+
+func TestAllocsRetryFirstAttempt(t *testing.T) {
+	if testing.Short() {
+		t.Skip("allocation budgets are not checked in short mode")
+	}
+	ctx := context.Background()
+	succeed := func(ctx context.Context) (int, error) { return 1, nil }
+	r, err := retry.New(retry.Options{Strategy: &retry.NoDelay{}, MaxAttempts: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if allocs := testing.AllocsPerRun(100, func() { retry.Run(ctx, r, succeed) }); allocs > 2 {
+		t.Errorf("expected at most 2 allocs for a successful first attempt, got %.0f", allocs)
+	}
+}
@@ -0,0 +1,87 @@
+package bench
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/remiges-tech/await"
+)
+
+func BenchmarkAll(b *testing.B) {
+	ctx := context.Background()
+	for _, n := range taskCounts {
+		ts := tasks(n, false)
+		b.Run(fmt.Sprintf("tasks=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := await.All(ctx, ts...); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkAny(b *testing.B) {
+	ctx := context.Background()
+	for _, n := range taskCounts {
+		ts := tasks(n, false)
+		b.Run(fmt.Sprintf("tasks=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := await.Any(ctx, ts...); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkAnyAllFail(b *testing.B) {
+	ctx := context.Background()
+	for _, n := range taskCounts {
+		ts := tasks(n, true)
+		b.Run(fmt.Sprintf("tasks=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := await.Any(ctx, ts...); err == nil {
+					b.Fatal("expected error")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkRace(b *testing.B) {
+	ctx := context.Background()
+	for _, n := range taskCounts {
+		ts := tasks(n, false)
+		b.Run(fmt.Sprintf("tasks=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := await.Race(ctx, ts...); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkGenerate(b *testing.B) {
+	ctx := context.Background()
+	gen := func(i int) await.Task[int] {
+		return func(ctx context.Context) (int, error) { return i, nil }
+	}
+	for _, n := range taskCounts {
+		b.Run(fmt.Sprintf("tasks=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				sum := 0
+				if err := await.Generate(ctx, n, 0, gen, func(_ int, r await.Result[int]) { sum += r.Value }); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,10 @@
+// Package bench holds the performance regression suite for await and its
+// subpackages. It contains no library code: run the benchmarks with
+//
+//	go test -bench=. -benchmem ./bench
+//
+// and compare runs with benchstat. The Test functions assert allocation
+// budgets for the hot paths and run as part of the normal test suite, so
+// an accidental extra allocation per task fails CI rather than waiting for
+// someone to read benchmark output.
+package bench
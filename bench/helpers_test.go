@@ -0,0 +1,28 @@
+package bench
+
+import (
+	"context"
+	"errors"
+
+	"github.com/remiges-tech/await"
+)
+
+// taskCounts covers the inline single-task path, typical fan-outs and
+// goroutine-heavy batches.
+var taskCounts = []int{1, 10, 1_000, 100_000}
+
+var errBench = errors.New("bench failure")
+
+func tasks(n int, fail bool) []await.Task[int] {
+	ts := make([]await.Task[int], n)
+	for i := range ts {
+		v := i
+		ts[i] = func(ctx context.Context) (int, error) {
+			if fail {
+				return 0, errBench
+			}
+			return v, nil
+		}
+	}
+	return ts
+}
@@ -0,0 +1,33 @@
+package bench
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/remiges-tech/await"
+)
+
+func BenchmarkPoolThroughput(b *testing.B) {
+	ctx := context.Background()
+	task := func(ctx context.Context) (int, error) { return 1, nil }
+
+	for _, workers := range []int{1, 4, 16} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			pool := await.NewPool[int](ctx, workers)
+			defer pool.Close()
+
+			futures := make([]*await.Future[int], b.N)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := range futures {
+				futures[i] = pool.Submit(task)
+			}
+			for _, f := range futures {
+				if _, err := f.Await(ctx); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package bench
+
+import (
+	"context"
+	"testing"
+
+	"github.com/remiges-tech/await/retry"
+)
+
+func BenchmarkRetryDo(b *testing.B) {
+	ctx := context.Background()
+	succeed := func(ctx context.Context) (int, error) { return 1, nil }
+
+	b.Run("first attempt", func(b *testing.B) {
+		opts := retry.Options{Strategy: &retry.NoDelay{}, MaxAttempts: 3}
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := retry.Do(ctx, succeed, opts); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("exhausted", func(b *testing.B) {
+		opts := retry.Options{Strategy: &retry.NoDelay{}, MaxAttempts: 3}
+		fail := func(ctx context.Context) (int, error) { return 0, errBench }
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := retry.Do(ctx, fail, opts); err == nil {
+				b.Fatal("expected error")
+			}
+		}
+	})
+
+	b.Run("retryer", func(b *testing.B) {
+		r, err := retry.New(retry.Options{Strategy: &retry.NoDelay{}, MaxAttempts: 3})
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := retry.Run(ctx, r, succeed); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,86 @@
+package await
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+var benchSizes = []int{1, 2, 10, 100}
+
+func benchTasks(n int, fail bool) []Task[int] {
+	tasks := make([]Task[int], n)
+	for i := range tasks {
+		v := i
+		tasks[i] = func(ctx context.Context) (int, error) {
+			if fail {
+				return 0, errBench
+			}
+			return v, nil
+		}
+	}
+	return tasks
+}
+
+var errBench = errors.New("bench failure")
+
+func BenchmarkAll(b *testing.B) {
+	ctx := context.Background()
+	for _, n := range benchSizes {
+		tasks := benchTasks(n, false)
+		b.Run(fmt.Sprintf("tasks=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := All(ctx, tasks...); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkAny(b *testing.B) {
+	ctx := context.Background()
+	for _, n := range benchSizes {
+		tasks := benchTasks(n, false)
+		b.Run(fmt.Sprintf("tasks=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := Any(ctx, tasks...); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkAnyAllFail(b *testing.B) {
+	ctx := context.Background()
+	for _, n := range benchSizes {
+		tasks := benchTasks(n, true)
+		b.Run(fmt.Sprintf("tasks=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := Any(ctx, tasks...); err == nil {
+					b.Fatal("expected error")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkRace(b *testing.B) {
+	ctx := context.Background()
+	for _, n := range benchSizes {
+		tasks := benchTasks(n, false)
+		b.Run(fmt.Sprintf("tasks=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := Race(ctx, tasks...); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
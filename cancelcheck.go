@@ -0,0 +1,77 @@
+package await
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// IgnoredCancellation describes a task wrapped with WithCancelCheck that was
+// still running well after its context was done.
+type IgnoredCancellation struct {
+	Name    string        // Task name from Named, or "" if it has none
+	Elapsed time.Duration // Time since the context was done
+	Cause   error         // context.Cause of the task's context
+}
+
+var cancelWarning atomic.Pointer[func(IgnoredCancellation)]
+
+// SetCancelWarning sets the function called for each task wrapped with
+// WithCancelCheck that is still running its grace period after its context
+// was done. It is called once per run, from a background goroutine, while the
+// task is still running. Pass nil to disable the warning again.
+func SetCancelWarning(warn func(IgnoredCancellation)) {
+	if warn == nil {
+		cancelWarning.Store(nil)
+		return
+	}
+	cancelWarning.Store(&warn)
+}
+
+// WithCancelCheck wraps task to catch it ignoring cancellation, a common cause
+// of calls that hang past their deadline. If the task is still running grace
+// after its context is done, the function set with SetCancelWarning is called.
+// If it then returns an error, the error is annotated with
+// ErrIgnoredCancellation and how late the task returned; the original error
+// stays available through errors.Is and errors.As.
+//
+// A task that returns a value after ignoring cancellation keeps its value, and
+// only triggers the warning.
+func WithCancelCheck[T any](task Task[T], grace time.Duration) Task[T] {
+	return func(ctx context.Context) (T, error) {
+		var cancelledAt atomic.Int64
+		finished := make(chan struct{})
+		stop := context.AfterFunc(ctx, func() {
+			at := time.Now()
+			cancelledAt.Store(at.UnixNano())
+
+			timer := time.NewTimer(grace)
+			defer timer.Stop()
+			select {
+			case <-finished:
+			case <-timer.C:
+				if warn := cancelWarning.Load(); warn != nil {
+					(*warn)(IgnoredCancellation{
+						Name:    TaskName(ctx),
+						Elapsed: time.Since(at),
+						Cause:   context.Cause(ctx),
+					})
+				}
+			}
+		})
+
+		val, err := task(ctx)
+		close(finished)
+		stop()
+
+		if err != nil {
+			if at := cancelledAt.Load(); at != 0 {
+				if late := time.Since(time.Unix(0, at)); late > grace {
+					err = fmt.Errorf("%w: returned %v after cancellation: %w", ErrIgnoredCancellation, late.Round(time.Millisecond), err)
+				}
+			}
+		}
+		return val, err
+	}
+}
@@ -0,0 +1,75 @@
+package await
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWithCancelCheck(t *testing.T) {
+	t.Run("annotates error of task ignoring cancellation", func(t *testing.T) {
+		warnings := make(chan IgnoredCancellation, 1)
+		SetCancelWarning(func(w IgnoredCancellation) { warnings <- w })
+		t.Cleanup(func() { SetCancelWarning(nil) })
+
+		errSlow := errors.New("slow query failed")
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		results, err := All(ctx, WithCancelCheck(Named("report", func(ctx context.Context) (int, error) {
+			time.Sleep(80 * time.Millisecond) // ignores ctx
+			return 0, errSlow
+		}), 20*time.Millisecond))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		taskErr := results[0].Err
+		if !errors.Is(taskErr, ErrIgnoredCancellation) || !errors.Is(taskErr, errSlow) {
+			t.Fatalf("expected annotated errSlow, got %v", taskErr)
+		}
+
+		select {
+		case w := <-warnings:
+			if w.Elapsed < 20*time.Millisecond || !errors.Is(w.Cause, context.DeadlineExceeded) {
+				t.Fatalf("unexpected warning %+v", w)
+			}
+		default:
+			t.Fatal("expected a warning while the task was running")
+		}
+	})
+
+	t.Run("prompt task is not flagged", func(t *testing.T) {
+		warnings := make(chan IgnoredCancellation, 1)
+		SetCancelWarning(func(w IgnoredCancellation) { warnings <- w })
+		t.Cleanup(func() { SetCancelWarning(nil) })
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := WithCancelCheck(func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		}, 20*time.Millisecond)(ctx)
+		if errors.Is(err, ErrIgnoredCancellation) || !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected plain context.Canceled, got %v", err)
+		}
+
+		select {
+		case w := <-warnings:
+			t.Fatalf("unexpected warning %+v", w)
+		case <-time.After(40 * time.Millisecond):
+		}
+	})
+
+	t.Run("late success keeps value", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		v, err := WithCancelCheck(func(ctx context.Context) (int, error) {
+			time.Sleep(10 * time.Millisecond)
+			return 5, nil
+		}, time.Millisecond)(ctx)
+		if err != nil || v != 5 {
+			t.Fatalf("expected {5, nil}, got {%d, %v}", v, err)
+		}
+	})
+}
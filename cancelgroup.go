@@ -0,0 +1,81 @@
+package await
+
+import (
+	"context"
+	"sync"
+)
+
+// CancelGroup cancels individual named tasks of a long-running group, such as
+// an All call or a Pool, without cancelling the rest. Create one with
+// NewCancelGroup.
+type CancelGroup struct {
+	mu      sync.Mutex
+	running map[string]map[*cancelEntry]struct{}
+}
+
+type cancelEntry struct {
+	cancel context.CancelCauseFunc
+}
+
+type cancelGroupKey struct{}
+
+// NewCancelGroup returns a context for the group's tasks and the group
+// handle. Tasks given a name with Named and run with the returned context, or
+// a context derived from it, can be cancelled by name with CancelTask.
+func NewCancelGroup(ctx context.Context) (context.Context, *CancelGroup) {
+	g := &CancelGroup{running: make(map[string]map[*cancelEntry]struct{})}
+	return context.WithValue(ctx, cancelGroupKey{}, g), g
+}
+
+// CancelTask cancels the context of every running task with the given name,
+// with ErrTaskCancelled as its context.Cause, and returns how many were
+// cancelled. Tasks that start later under the same name are not affected.
+func (g *CancelGroup) CancelTask(name string) int {
+	g.mu.Lock()
+	entries := g.running[name]
+	delete(g.running, name)
+	g.mu.Unlock()
+
+	for e := range entries {
+		e.cancel(ErrTaskCancelled)
+	}
+	return len(entries)
+}
+
+// Running returns the number of running tasks with the given name.
+func (g *CancelGroup) Running(name string) int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return len(g.running[name])
+}
+
+// register derives a cancellable context for a run of the named task. done
+// must be called when the run returns.
+func (g *CancelGroup) register(ctx context.Context, name string) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	e := &cancelEntry{cancel: cancel}
+
+	g.mu.Lock()
+	if g.running[name] == nil {
+		g.running[name] = make(map[*cancelEntry]struct{})
+	}
+	g.running[name][e] = struct{}{}
+	g.mu.Unlock()
+
+	return ctx, func() {
+		g.mu.Lock()
+		if entries := g.running[name]; entries != nil {
+			delete(entries, e)
+			if len(entries) == 0 {
+				delete(g.running, name)
+			}
+		}
+		g.mu.Unlock()
+		cancel(nil)
+	}
+}
+
+func cancelGroupFrom(ctx context.Context) *CancelGroup {
+	g, _ := ctx.Value(cancelGroupKey{}).(*CancelGroup)
+	return g
+}
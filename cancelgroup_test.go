@@ -0,0 +1,76 @@
+package await
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCancelGroup(t *testing.T) {
+	t.Run("cancels only the named task", func(t *testing.T) {
+		ctx, group := NewCancelGroup(context.Background())
+
+		started := make(chan struct{}, 2)
+		release := make(chan struct{})
+		worker := func(ctx context.Context) (string, error) {
+			started <- struct{}{}
+			select {
+			case <-ctx.Done():
+				return "", context.Cause(ctx)
+			case <-release:
+				return "done", nil
+			}
+		}
+
+		type out struct {
+			results []Result[string]
+			err     error
+		}
+		ch := make(chan out, 1)
+		go func() {
+			results, err := All(ctx, Named("export", worker), Named("sync", worker))
+			ch <- out{results, err}
+		}()
+		<-started
+		<-started
+
+		if n := group.CancelTask("export"); n != 1 {
+			t.Fatalf("expected 1 task cancelled, got %d", n)
+		}
+		if n := group.CancelTask("missing"); n != 0 {
+			t.Fatalf("expected no task cancelled for unknown name, got %d", n)
+		}
+		time.Sleep(10 * time.Millisecond)
+		if group.Running("sync") != 1 {
+			t.Fatal("expected sync to keep running")
+		}
+		close(release)
+
+		o := <-ch
+		if o.err != nil {
+			t.Fatalf("unexpected error: %v", o.err)
+		}
+		if !errors.Is(o.results[0].Err, ErrTaskCancelled) {
+			t.Fatalf("expected export cancelled with ErrTaskCancelled, got %v", o.results[0].Err)
+		}
+		if o.results[1].Err != nil || o.results[1].Value != "done" {
+			t.Fatalf("expected sync to finish, got %+v", o.results[1])
+		}
+		if group.Running("sync") != 0 {
+			t.Fatal("expected finished tasks to be unregistered")
+		}
+	})
+
+	t.Run("later runs are unaffected", func(t *testing.T) {
+		ctx, group := NewCancelGroup(context.Background())
+		group.CancelTask("job")
+
+		val, err := Named("job", func(ctx context.Context) (int, error) {
+			return 1, ctx.Err()
+		})(ctx)
+		if err != nil || val != 1 {
+			t.Fatalf("expected {1, nil}, got {%d, %v}", val, err)
+		}
+	})
+}
@@ -0,0 +1,135 @@
+package await
+
+import (
+	"context"
+	"sync"
+)
+
+// FromChannel executes tasks received from in, running at most limit of them
+// concurrently, and delivers each outcome on the returned channel in completion order.
+// A limit <= 0 places no bound on concurrency.
+// The returned channel is closed once in is closed (or ctx is done) and every
+// started task has finished. After ctx is done no new tasks are started, and
+// results that cannot be delivered because nobody is receiving are dropped.
+func FromChannel[T any](ctx context.Context, in <-chan Task[T], limit int) <-chan Result[T] {
+	out := make(chan Result[T])
+
+	go func() {
+		defer close(out)
+
+		var sem chan struct{}
+		if limit > 0 {
+			sem = make(chan struct{}, limit)
+		}
+
+		var wg sync.WaitGroup
+		defer wg.Wait()
+
+		for {
+			var task Task[T]
+			var ok bool
+			select {
+			case <-ctx.Done():
+				return
+			case task, ok = <-in:
+				if !ok {
+					return
+				}
+			}
+
+			if sem != nil {
+				select {
+				case <-ctx.Done():
+					return
+				case sem <- struct{}{}:
+				}
+			}
+
+			wg.Add(1)
+			go func(task Task[T]) {
+				defer wg.Done()
+				if sem != nil {
+					defer func() { <-sem }()
+				}
+				val, err := task(ctx)
+				select {
+				case out <- Result[T]{Value: val, Err: err}:
+				case <-ctx.Done():
+				}
+			}(task)
+		}
+	}()
+
+	return out
+}
+
+// ResultsChannel executes all tasks concurrently and delivers each outcome on the
+// returned channel as soon as it completes, rather than waiting for the whole batch
+// like All does. The channel is buffered to hold every result, so tasks never block
+// on a slow consumer, and it is closed after the last task finishes.
+// Returns a closed channel if no tasks are provided.
+func ResultsChannel[T any](ctx context.Context, tasks ...Task[T]) <-chan Result[T] {
+	out := make(chan Result[T], len(tasks))
+	if len(tasks) == 0 {
+		close(out)
+		return out
+	}
+
+	var wg sync.WaitGroup
+	for _, t := range tasks {
+		wg.Add(1)
+		go func(task Task[T]) {
+			defer wg.Done()
+			select {
+			case <-ctx.Done():
+				out <- Result[T]{Err: ctx.Err()}
+			default:
+				val, err := task(ctx)
+				out <- Result[T]{Value: val, Err: err}
+			}
+		}(t)
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
+
+// AllOrderedStream executes all tasks concurrently like ResultsChannel but
+// delivers results strictly in task order: result i is sent as soon as tasks
+// 0 through i have completed. The channel is buffered to hold every result and
+// is closed after the last one is sent.
+// Returns a closed channel if no tasks are provided.
+func AllOrderedStream[T any](ctx context.Context, tasks ...Task[T]) <-chan Result[T] {
+	out := make(chan Result[T], len(tasks))
+	if len(tasks) == 0 {
+		close(out)
+		return out
+	}
+
+	slots := make([]chan Result[T], len(tasks))
+	for i, t := range tasks {
+		slots[i] = make(chan Result[T], 1)
+		go func(task Task[T], slot chan<- Result[T]) {
+			select {
+			case <-ctx.Done():
+				slot <- Result[T]{Err: ctx.Err()}
+			default:
+				val, err := task(ctx)
+				slot <- Result[T]{Value: val, Err: err}
+			}
+		}(t, slots[i])
+	}
+
+	go func() {
+		defer close(out)
+		for _, slot := range slots {
+			out <- <-slot
+		}
+	}()
+
+	return out
+}
@@ -0,0 +1,178 @@
+package await
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFromChannel(t *testing.T) {
+	t.Run("executes all tasks until input closes", func(t *testing.T) {
+		in := make(chan Task[int])
+		out := FromChannel(context.Background(), in, 2)
+
+		go func() {
+			for i := 1; i <= 5; i++ {
+				v := i
+				in <- func(ctx context.Context) (int, error) { return v, nil }
+			}
+			close(in)
+		}()
+
+		sum := 0
+		count := 0
+		for res := range out {
+			if res.Err != nil {
+				t.Fatalf("expected no error, got %v", res.Err)
+			}
+			sum += res.Value
+			count++
+		}
+		if count != 5 {
+			t.Fatalf("expected 5 results, got %d", count)
+		}
+		if sum != 15 {
+			t.Fatalf("expected sum 15, got %d", sum)
+		}
+	})
+
+	t.Run("respects concurrency limit", func(t *testing.T) {
+		in := make(chan Task[int], 10)
+		var running, maxRunning int32
+		for i := 0; i < 10; i++ {
+			in <- func(ctx context.Context) (int, error) {
+				n := atomic.AddInt32(&running, 1)
+				for {
+					m := atomic.LoadInt32(&maxRunning)
+					if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+				atomic.AddInt32(&running, -1)
+				return 0, nil
+			}
+		}
+		close(in)
+
+		for range FromChannel(context.Background(), in, 3) {
+		}
+		if maxRunning > 3 {
+			t.Fatalf("expected at most 3 concurrent tasks, got %d", maxRunning)
+		}
+	})
+
+	t.Run("task errors are delivered", func(t *testing.T) {
+		in := make(chan Task[int], 1)
+		in <- func(ctx context.Context) (int, error) { return 0, errors.New("boom") }
+		close(in)
+
+		res := <-FromChannel(context.Background(), in, 1)
+		if res.Err == nil || res.Err.Error() != "boom" {
+			t.Fatalf("expected boom error, got %v", res.Err)
+		}
+	})
+
+	t.Run("closes output on context cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		in := make(chan Task[int])
+		out := FromChannel(ctx, in, 1)
+		cancel()
+
+		select {
+		case _, ok := <-out:
+			if ok {
+				t.Fatal("expected closed channel")
+			}
+		case <-time.After(time.Second):
+			t.Fatal("output channel was not closed after cancellation")
+		}
+	})
+}
+
+func TestResultsChannel(t *testing.T) {
+	t.Run("delivers results in completion order", func(t *testing.T) {
+		slow := Task[int](func(ctx context.Context) (int, error) {
+			time.Sleep(50 * time.Millisecond)
+			return 1, nil
+		})
+		fast := Task[int](func(ctx context.Context) (int, error) {
+			return 2, nil
+		})
+
+		var got []int
+		for res := range ResultsChannel(context.Background(), slow, fast) {
+			got = append(got, res.Value)
+		}
+		if len(got) != 2 || got[0] != 2 || got[1] != 1 {
+			t.Fatalf("expected [2 1], got %v", got)
+		}
+	})
+
+	t.Run("empty tasks", func(t *testing.T) {
+		if _, ok := <-ResultsChannel[int](context.Background()); ok {
+			t.Fatal("expected closed channel")
+		}
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		task := Task[int](func(ctx context.Context) (int, error) { return 1, nil })
+		res := <-ResultsChannel(ctx, task)
+		if res.Err != context.Canceled {
+			t.Fatalf("expected context.Canceled, got %v", res.Err)
+		}
+	})
+}
+
+func TestAllOrderedStream(t *testing.T) {
+	t.Run("emits in task order as early as possible", func(t *testing.T) {
+		release := make(chan struct{})
+		tasks := []Task[int]{
+			func(ctx context.Context) (int, error) { return 0, nil },
+			func(ctx context.Context) (int, error) {
+				<-release
+				return 1, nil
+			},
+			func(ctx context.Context) (int, error) { return 2, errors.New("failed") },
+		}
+
+		out := AllOrderedStream(context.Background(), tasks...)
+
+		select {
+		case r := <-out:
+			if r.Value != 0 || r.Err != nil {
+				t.Fatalf("expected task 0 first, got %+v", r)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("expected task 0 before the slow task finished")
+		}
+
+		select {
+		case r := <-out:
+			t.Fatalf("expected task 2 to wait for task 1, got %+v", r)
+		case <-time.After(20 * time.Millisecond):
+		}
+
+		close(release)
+		if r := <-out; r.Value != 1 {
+			t.Fatalf("expected task 1, got %+v", r)
+		}
+		if r := <-out; r.Err == nil {
+			t.Fatalf("expected task 2 error, got %+v", r)
+		}
+		if _, ok := <-out; ok {
+			t.Fatal("expected channel to be closed")
+		}
+	})
+
+	t.Run("no tasks", func(t *testing.T) {
+		if _, ok := <-AllOrderedStream[int](context.Background()); ok {
+			t.Fatal("expected closed channel")
+		}
+	})
+}
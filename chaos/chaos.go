@@ -0,0 +1,176 @@
+// Package chaos injects faults into tasks for testing. An Injector wraps
+// tasks so that a configurable share of calls is delayed, fails or panics,
+// which exercises retry policies, fallbacks and hedging against realistic
+// failure patterns without a misbehaving dependency.
+//
+// Decisions are drawn from a seeded random source, so a test that makes its
+// calls in a fixed order sees the same faults on every run.
+package chaos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/remiges-tech/await"
+)
+
+var (
+	// ErrInvalidConfig is returned by New when the Config cannot be used.
+	ErrInvalidConfig = errors.New("invalid chaos configuration")
+
+	// ErrInjected is the error returned by injected failures when Config.Err
+	// is nil.
+	ErrInjected = errors.New("injected fault")
+)
+
+// Config sets the probability of each fault, from 0 (never) to 1 (always).
+// Every call first rolls for latency, then for a panic, then for an error;
+// a call that draws no panic or error runs the wrapped task.
+type Config struct {
+	Seed int64 // Seed for fault decisions; 0 picks a random seed
+
+	LatencyRate float64       // Probability of delaying a call
+	MinLatency  time.Duration // Shortest injected delay
+	MaxLatency  time.Duration // Longest injected delay (default: MinLatency)
+
+	PanicRate  float64 // Probability of panicking instead of running the task
+	PanicValue any     // Value to panic with (default: ErrInjected)
+
+	ErrorRate float64 // Probability of failing instead of running the task
+	Err       error   // Error to fail with (default: ErrInjected)
+}
+
+// Validate reports whether the config can be used with New.
+func (c Config) Validate() error {
+	for _, rate := range []struct {
+		name  string
+		value float64
+	}{
+		{"latency rate", c.LatencyRate},
+		{"panic rate", c.PanicRate},
+		{"error rate", c.ErrorRate},
+	} {
+		if !(rate.value >= 0 && rate.value <= 1) {
+			return fmt.Errorf("%w: %s must be between 0 and 1, got %v", ErrInvalidConfig, rate.name, rate.value)
+		}
+	}
+	switch {
+	case c.MinLatency < 0:
+		return fmt.Errorf("%w: min latency must not be negative, got %v", ErrInvalidConfig, c.MinLatency)
+	case c.MaxLatency != 0 && c.MaxLatency < c.MinLatency:
+		return fmt.Errorf("%w: max latency %v is below min latency %v", ErrInvalidConfig, c.MaxLatency, c.MinLatency)
+	}
+	return nil
+}
+
+// Stats counts the calls an Injector has seen and the faults it injected.
+type Stats struct {
+	Calls   int64 // Calls to wrapped tasks
+	Delayed int64 // Calls delayed by injected latency
+	Panics  int64 // Calls that panicked
+	Errors  int64 // Calls that failed with an injected error
+}
+
+// Injector decides which calls of the tasks it wraps get faults. It is safe
+// for concurrent use; with concurrent calls, which call gets which decision
+// depends on scheduling.
+type Injector struct {
+	config Config
+
+	mu  sync.Mutex
+	rng *rand.Rand
+
+	calls, delayed, panics, errs atomic.Int64
+}
+
+// New creates an Injector, filling in defaults for unset Config fields.
+func New(config Config) (*Injector, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if config.MaxLatency == 0 {
+		config.MaxLatency = config.MinLatency
+	}
+	if config.Err == nil {
+		config.Err = ErrInjected
+	}
+	if config.PanicValue == nil {
+		config.PanicValue = ErrInjected
+	}
+	seed := config.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return &Injector{config: config, rng: rand.New(rand.NewSource(seed))}, nil
+}
+
+// Stats returns the counts so far.
+func (inj *Injector) Stats() Stats {
+	return Stats{
+		Calls:   inj.calls.Load(),
+		Delayed: inj.delayed.Load(),
+		Panics:  inj.panics.Load(),
+		Errors:  inj.errs.Load(),
+	}
+}
+
+// fault is the outcome of the rolls for one call.
+type fault struct {
+	delay time.Duration
+	panic bool
+	err   bool
+}
+
+// roll draws the faults for one call. All draws are made under the lock and
+// in the same order, so a seed reproduces the same sequence of faults.
+func (inj *Injector) roll() fault {
+	inj.mu.Lock()
+	defer inj.mu.Unlock()
+
+	var f fault
+	if inj.rng.Float64() < inj.config.LatencyRate {
+		f.delay = inj.config.MinLatency
+		if spread := inj.config.MaxLatency - inj.config.MinLatency; spread > 0 {
+			f.delay += time.Duration(inj.rng.Int63n(int64(spread) + 1))
+		}
+	}
+	f.panic = inj.rng.Float64() < inj.config.PanicRate
+	f.err = inj.rng.Float64() < inj.config.ErrorRate
+	return f
+}
+
+// Wrap returns a task that injects faults drawn from inj before calling task.
+// Injected latency ends early with ctx's error if ctx is done. The result can
+// be passed to retry.Do, await.All or any other executor.
+func Wrap[T any](inj *Injector, task await.Task[T]) await.Task[T] {
+	return func(ctx context.Context) (T, error) {
+		var zero T
+		inj.calls.Add(1)
+		f := inj.roll()
+
+		if f.delay > 0 {
+			inj.delayed.Add(1)
+			timer := time.NewTimer(f.delay)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return zero, ctx.Err()
+			}
+		}
+		if f.panic {
+			inj.panics.Add(1)
+			panic(inj.config.PanicValue)
+		}
+		if f.err {
+			inj.errs.Add(1)
+			return zero, inj.config.Err
+		}
+		return task(ctx)
+	}
+}
@@ -0,0 +1,112 @@
+package chaos
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/remiges-tech/await"
+	"github.com/remiges-tech/await/retry"
+)
+
+func ok(ctx context.Context) (int, error) { return 1, nil }
+
+func TestWrap(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("seed reproduces faults", func(t *testing.T) {
+		outcomes := func() []bool {
+			inj, err := New(Config{Seed: 7, ErrorRate: 0.5})
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+			task := Wrap(inj, ok)
+			var failed []bool
+			for i := 0; i < 32; i++ {
+				_, err := task(ctx)
+				failed = append(failed, err != nil)
+			}
+			return failed
+		}
+		first, second := outcomes(), outcomes()
+		failures := 0
+		for i := range first {
+			if first[i] != second[i] {
+				t.Fatalf("call %d differs between runs with the same seed", i)
+			}
+			if first[i] {
+				failures++
+			}
+		}
+		if failures == 0 || failures == len(first) {
+			t.Fatalf("expected a mix of failures at rate 0.5, got %d of %d", failures, len(first))
+		}
+	})
+
+	t.Run("injected error", func(t *testing.T) {
+		errThrottled := errors.New("throttled")
+		inj, _ := New(Config{ErrorRate: 1, Err: errThrottled})
+		if _, err := Wrap(inj, ok)(ctx); !errors.Is(err, errThrottled) {
+			t.Fatalf("expected errThrottled, got %v", err)
+		}
+		if s := inj.Stats(); s.Calls != 1 || s.Errors != 1 {
+			t.Fatalf("unexpected stats %+v", s)
+		}
+	})
+
+	t.Run("injected panic", func(t *testing.T) {
+		inj, _ := New(Config{PanicRate: 1})
+		defer func() {
+			if p := recover(); p != ErrInjected {
+				t.Fatalf("expected panic with ErrInjected, got %v", p)
+			}
+			if inj.Stats().Panics != 1 {
+				t.Fatal("expected the panic to be counted")
+			}
+		}()
+		_, _ = Wrap(inj, ok)(ctx)
+	})
+
+	t.Run("works with await executors", func(t *testing.T) {
+		inj, _ := New(Config{Seed: 1, ErrorRate: 0.5})
+		primary := Wrap(inj, ok)
+		fallback := func(ctx context.Context) (int, error) { return 2, nil }
+		if _, err := await.Any(ctx, primary, fallback); err != nil {
+			t.Fatalf("expected the fallback to cover injected errors, got %v", err)
+		}
+	})
+
+	t.Run("latency respects ctx", func(t *testing.T) {
+		inj, _ := New(Config{LatencyRate: 1, MinLatency: time.Second})
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		if _, err := Wrap(inj, ok)(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected DeadlineExceeded, got %v", err)
+		}
+		if inj.Stats().Delayed != 1 {
+			t.Fatal("expected the call to be counted as delayed")
+		}
+	})
+
+	t.Run("exercises a retry policy", func(t *testing.T) {
+		inj, _ := New(Config{Seed: 3, ErrorRate: 0.5})
+		val, err := retry.Do(ctx, Wrap(inj, ok), retry.Options{Strategy: &retry.NoDelay{}, MaxAttempts: 10})
+		if err != nil || val != 1 {
+			t.Fatalf("expected retries to get past injected errors, got %d, %v", val, err)
+		}
+	})
+}
+
+func TestConfigValidate(t *testing.T) {
+	for name, config := range map[string]Config{
+		"error rate above 1":  {ErrorRate: 1.5},
+		"negative panic rate": {PanicRate: -0.1},
+		"negative latency":    {MinLatency: -time.Second},
+		"max below min":       {MinLatency: time.Second, MaxLatency: time.Millisecond},
+	} {
+		if _, err := New(config); !errors.Is(err, ErrInvalidConfig) {
+			t.Errorf("%s: expected ErrInvalidConfig, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package await
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+)
+
+// Checkpointer records which tasks of a long-running job have completed, so
+// that a job restarted after a crash skips them instead of running every task
+// again. Implementations may keep the record in a file, a database row or an
+// object store.
+type Checkpointer interface {
+	// Completed returns the indices recorded by earlier runs of the job.
+	Completed(ctx context.Context) ([]int, error)
+	// Checkpoint records that the tasks at indices completed successfully.
+	Checkpoint(ctx context.Context, indices []int) error
+}
+
+// GenerateWithCheckpoint is like Generate, but skips the indices cp reports as
+// completed and records each task that succeeds with cp. Skipped indices are
+// neither created nor passed to onResult. Failed tasks are not recorded, so a
+// resumed job retries them.
+//
+// Successes are recorded from the calling goroutine in batches, whenever no
+// other result is waiting, and once more before returning, so a crash loses
+// at most the results still being reported. If Checkpoint fails, no further
+// tasks are created and its error is returned once the running ones finish.
+// Unlike Generate, it returns nil without running anything when every index
+// has already completed.
+func GenerateWithCheckpoint[T any](ctx context.Context, n, limit int, cp Checkpointer, gen func(i int) Task[T], onResult func(i int, r Result[T])) error {
+	if n <= 0 {
+		return ErrNoTasks
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	completed, err := cp.Completed(ctx)
+	if err != nil {
+		return fmt.Errorf("load checkpoint: %w", err)
+	}
+	skip := make([]bool, n)
+	remaining := n
+	for _, i := range completed {
+		if i >= 0 && i < n && !skip[i] {
+			skip[i] = true
+			remaining--
+		}
+	}
+	if remaining == 0 {
+		return nil
+	}
+
+	runCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	var pending []int
+	var cpErr error
+	flush := func() {
+		if len(pending) == 0 || cpErr != nil {
+			return
+		}
+		// Results of tasks that were already running are still recorded
+		// after ctx is done.
+		if err := cp.Checkpoint(context.WithoutCancel(ctx), pending); err != nil {
+			cpErr = fmt.Errorf("checkpoint: %w", err)
+			cancel(cpErr)
+		}
+		pending = pending[:0]
+	}
+
+	err = generate(runCtx, n, remaining, limit, skip, gen, func(i int, r Result[T]) {
+		onResult(i, r)
+		if r.Err == nil {
+			pending = append(pending, i)
+		}
+	}, flush)
+	flush()
+	if cpErr != nil {
+		return cpErr
+	}
+	return err
+}
+
+// FileCheckpointer is a Checkpointer that appends completed indices to a
+// file, one per line, syncing after every Checkpoint. It is safe for
+// concurrent use.
+type FileCheckpointer struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// OpenFileCheckpointer opens the checkpoint file at path, creating it if it
+// does not exist. A partially written last line, left by a crash during a
+// write, is discarded.
+func OpenFileCheckpointer(path string) (*FileCheckpointer, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	c := &FileCheckpointer{file: file}
+	data, err := c.read()
+	if err == nil {
+		err = file.Truncate(int64(len(data)))
+	}
+	if err == nil {
+		_, err = file.Seek(0, io.SeekEnd)
+	}
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// Completed returns the indices recorded in the file.
+func (c *FileCheckpointer) Completed(ctx context.Context) ([]int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	data, err := c.read()
+	if err != nil {
+		return nil, err
+	}
+
+	var indices []int
+	for _, line := range bytes.Split(data, []byte{'\n'}) {
+		if len(line) == 0 {
+			continue
+		}
+		i, err := strconv.Atoi(string(line))
+		if err != nil {
+			return nil, fmt.Errorf("checkpoint file %s: %w", c.file.Name(), err)
+		}
+		indices = append(indices, i)
+	}
+	return indices, nil
+}
+
+// Checkpoint appends indices to the file and syncs it.
+func (c *FileCheckpointer) Checkpoint(ctx context.Context, indices []int) error {
+	var buf []byte
+	for _, i := range indices {
+		buf = strconv.AppendInt(buf, int64(i), 10)
+		buf = append(buf, '\n')
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, err := c.file.Write(buf); err != nil {
+		return err
+	}
+	return c.file.Sync()
+}
+
+// Close closes the file.
+func (c *FileCheckpointer) Close() error {
+	return c.file.Close()
+}
+
+// read returns the file's complete lines, dropping any trailing partial one.
+func (c *FileCheckpointer) read() ([]byte, error) {
+	data, err := os.ReadFile(c.file.Name())
+	if err != nil {
+		return nil, err
+	}
+	return data[:bytes.LastIndexByte(data, '\n')+1], nil
+}
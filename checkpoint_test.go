@@ -0,0 +1,151 @@
+package await
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+)
+
+// memoryCheckpointer records checkpoints in memory and can be made to fail.
+type memoryCheckpointer struct {
+	mu      sync.Mutex
+	indices []int
+	calls   int
+	err     error
+}
+
+func (c *memoryCheckpointer) Completed(ctx context.Context) ([]int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]int(nil), c.indices...), nil
+}
+
+func (c *memoryCheckpointer) Checkpoint(ctx context.Context, indices []int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls++
+	if c.err != nil {
+		return c.err
+	}
+	c.indices = append(c.indices, indices...)
+	return nil
+}
+
+func TestGenerateWithCheckpoint(t *testing.T) {
+	ctx := context.Background()
+	const n = 100
+
+	t.Run("resumes after a crash", func(t *testing.T) {
+		cp := &memoryCheckpointer{}
+		crashCtx, crash := context.WithCancel(ctx)
+		ran := 0
+		err := GenerateWithCheckpoint(crashCtx, n, 1, cp, func(i int) Task[int] {
+			return func(ctx context.Context) (int, error) {
+				if i%10 == 0 {
+					return 0, errors.New("failed")
+				}
+				return i, nil
+			}
+		}, func(i int, r Result[int]) {
+			if ran++; ran == 40 {
+				crash()
+			}
+		})
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+		first := len(cp.indices)
+		if first == 0 || first >= 40 {
+			t.Fatalf("expected the successes before the crash recorded, got %d", first)
+		}
+
+		seen := make(map[int]bool)
+		err = GenerateWithCheckpoint(ctx, n, 4, cp, func(i int) Task[int] {
+			return func(ctx context.Context) (int, error) { return i, nil }
+		}, func(i int, r Result[int]) {
+			seen[i] = true
+		})
+		if err != nil {
+			t.Fatalf("expected no error on resume, got %v", err)
+		}
+		if len(seen)+first != n {
+			t.Fatalf("expected %d tasks rerun, got %d", n-first, len(seen))
+		}
+		for _, i := range cp.indices[:first] {
+			if seen[i] {
+				t.Fatalf("completed index %d was run again", i)
+			}
+		}
+		if !seen[0] {
+			t.Fatal("expected failed index 0 to be retried")
+		}
+
+		if err := GenerateWithCheckpoint(ctx, n, 4, cp, func(i int) Task[int] {
+			t.Fatalf("index %d run after the job completed", i)
+			return nil
+		}, func(int, Result[int]) {}); err != nil {
+			t.Fatalf("expected nil for a completed job, got %v", err)
+		}
+	})
+
+	t.Run("checkpoint failure stops the job", func(t *testing.T) {
+		errStore := errors.New("store down")
+		cp := &memoryCheckpointer{err: errStore}
+		reported := 0
+		err := GenerateWithCheckpoint(ctx, n, 1, cp, func(i int) Task[int] {
+			return func(ctx context.Context) (int, error) { return i, nil }
+		}, func(int, Result[int]) { reported++ })
+		if !errors.Is(err, errStore) {
+			t.Fatalf("expected store error, got %v", err)
+		}
+		if reported == n || cp.calls != 1 {
+			t.Fatalf("expected the job to stop after one failed checkpoint, got %d results, %d calls", reported, cp.calls)
+		}
+	})
+}
+
+func TestFileCheckpointer(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "job.ckpt")
+
+	cp, err := OpenFileCheckpointer(path)
+	if err != nil {
+		t.Fatalf("OpenFileCheckpointer: %v", err)
+	}
+	if err := cp.Checkpoint(ctx, []int{3, 1}); err != nil {
+		t.Fatalf("Checkpoint: %v", err)
+	}
+	if err := cp.Checkpoint(ctx, []int{7}); err != nil {
+		t.Fatalf("Checkpoint: %v", err)
+	}
+	cp.Close()
+
+	// Simulate a crash in the middle of writing the next line.
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	f.WriteString("12")
+	f.Close()
+
+	cp, err = OpenFileCheckpointer(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer cp.Close()
+	if err := cp.Checkpoint(ctx, []int{9}); err != nil {
+		t.Fatalf("Checkpoint: %v", err)
+	}
+	got, err := cp.Completed(ctx)
+	if err != nil {
+		t.Fatalf("Completed: %v", err)
+	}
+	sort.Ints(got)
+	if len(got) != 4 || got[0] != 1 || got[1] != 3 || got[2] != 7 || got[3] != 9 {
+		t.Fatalf("expected [1 3 7 9] without the partial line, got %v", got)
+	}
+}
@@ -0,0 +1,20 @@
+package await
+
+import "context"
+
+// WithCleanup wraps task so that cleanup runs once the task returns, whether
+// it succeeded, failed, was cancelled or panicked. This holds for the losers
+// of Any and Race too: their cleanup runs when they finish, even if the call
+// that started them has already returned. Tasks that never start, because
+// the call settled or ctx was done first, have nothing to clean up and do not
+// call cleanup.
+//
+// cleanup receives the task's context detached from its cancellation, so it
+// keeps the context's values and can finish releasing file handles, locks or
+// remote leases after the task was cancelled; it should bound its own work.
+func WithCleanup[T any](task Task[T], cleanup func(ctx context.Context)) Task[T] {
+	return func(ctx context.Context) (T, error) {
+		defer cleanup(context.WithoutCancel(ctx))
+		return task(ctx)
+	}
+}
@@ -0,0 +1,72 @@
+package await
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWithCleanup(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("runs after each outcome", func(t *testing.T) {
+		for name, task := range map[string]Task[int]{
+			"success": func(ctx context.Context) (int, error) { return 1, nil },
+			"failure": func(ctx context.Context) (int, error) { return 0, errors.New("failed") },
+		} {
+			cleaned := false
+			WithCleanup(task, func(ctx context.Context) { cleaned = true })(ctx)
+			if !cleaned {
+				t.Errorf("%s: expected cleanup to run", name)
+			}
+		}
+	})
+
+	t.Run("runs on panic", func(t *testing.T) {
+		cleaned := false
+		func() {
+			defer func() { recover() }()
+			WithCleanup(func(ctx context.Context) (int, error) { panic("boom") },
+				func(ctx context.Context) { cleaned = true })(ctx)
+		}()
+		if !cleaned {
+			t.Fatal("expected cleanup to run on panic")
+		}
+	})
+
+	t.Run("loser of Any cleans up after the call returns", func(t *testing.T) {
+		type key struct{}
+		started := make(chan struct{})
+		cleaned := make(chan context.Context, 1)
+
+		loser := WithCleanup(func(ctx context.Context) (int, error) {
+			close(started)
+			<-ctx.Done()
+			time.Sleep(10 * time.Millisecond) // slow to notice, like a blocking release
+			return 0, ctx.Err()
+		}, func(ctx context.Context) { cleaned <- ctx })
+
+		winner := func(ctx context.Context) (int, error) {
+			<-started
+			return 1, nil
+		}
+
+		val, err := Any(context.WithValue(ctx, key{}, "req-1"), loser, winner)
+		if err != nil || val != 1 {
+			t.Fatalf("expected winner, got %d, %v", val, err)
+		}
+
+		select {
+		case cctx := <-cleaned:
+			if cctx.Err() != nil {
+				t.Error("expected cleanup context not to be cancelled")
+			}
+			if cctx.Value(key{}) != "req-1" {
+				t.Error("expected cleanup context to keep values")
+			}
+		case <-time.After(time.Second):
+			t.Fatal("expected loser cleanup to run")
+		}
+	})
+}
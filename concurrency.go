@@ -0,0 +1,44 @@
+package await
+
+import "runtime"
+
+// TaskKind describes what limits a task's throughput, for AutoConcurrency.
+// Kinds can be combined with |.
+type TaskKind int
+
+const (
+	// TaskKindCPU marks tasks that keep a CPU busy, such as hashing or encoding.
+	TaskKindCPU TaskKind = 1 << iota
+	// TaskKindIO marks tasks that mostly wait on the network or disk.
+	TaskKindIO
+)
+
+// Multipliers of GOMAXPROCS used by AutoConcurrency.
+const (
+	ioConcurrencyFactor    = 16
+	mixedConcurrencyFactor = 4
+	maxAutoConcurrency     = 256
+)
+
+// AutoConcurrency returns a worker count for NewPool, FromChannel or Generate
+// suited to tasks of the given kind, based on runtime.GOMAXPROCS:
+//
+//   - TaskKindCPU: GOMAXPROCS, since more workers only add scheduling overhead.
+//   - TaskKindIO: 16 × GOMAXPROCS, capped at 256, since workers spend most of
+//     their time waiting.
+//   - TaskKindCPU | TaskKindIO: 4 × GOMAXPROCS, capped at 256.
+//
+// Any other kind is treated as TaskKindCPU. The result is a starting point:
+// limits imposed by the dependency being called, such as a connection pool
+// or rate limit, take precedence.
+func AutoConcurrency(kind TaskKind) int {
+	procs := runtime.GOMAXPROCS(0)
+	switch {
+	case kind&TaskKindCPU != 0 && kind&TaskKindIO != 0:
+		return min(procs*mixedConcurrencyFactor, maxAutoConcurrency)
+	case kind&TaskKindIO != 0:
+		return min(procs*ioConcurrencyFactor, maxAutoConcurrency)
+	default:
+		return procs
+	}
+}
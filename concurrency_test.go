@@ -0,0 +1,31 @@
+package await
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestAutoConcurrency(t *testing.T) {
+	prev := runtime.GOMAXPROCS(4)
+	t.Cleanup(func() { runtime.GOMAXPROCS(prev) })
+
+	for _, tc := range []struct {
+		name string
+		kind TaskKind
+		want int
+	}{
+		{"cpu", TaskKindCPU, 4},
+		{"io", TaskKindIO, 64},
+		{"mixed", TaskKindCPU | TaskKindIO, 16},
+		{"unspecified", 0, 4},
+	} {
+		if got := AutoConcurrency(tc.kind); got != tc.want {
+			t.Errorf("%s: expected %d, got %d", tc.name, tc.want, got)
+		}
+	}
+
+	runtime.GOMAXPROCS(64)
+	if got := AutoConcurrency(TaskKindIO); got != maxAutoConcurrency {
+		t.Errorf("expected IO concurrency capped at %d, got %d", maxAutoConcurrency, got)
+	}
+}
@@ -0,0 +1,167 @@
+// Package coordinator runs the same request against several named providers
+// concurrently, retrying each according to its own policy, and returns the first
+// successful response together with per-provider status tracking.
+// It generalizes the "ask every vendor, take the first answer" pattern from the
+// KYC example to arbitrary request and response types.
+package coordinator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/remiges-tech/await"
+	"github.com/remiges-tech/await/retry"
+)
+
+// ErrNoProviders is returned when a Coordinator has no providers configured.
+var ErrNoProviders = errors.New("no providers configured")
+
+// Provider performs a request against a single backend.
+type Provider[Req, Resp any] func(ctx context.Context, req Req) (Resp, error)
+
+// State describes the progress of a single provider during a Run.
+type State string
+
+const (
+	StatePending State = "pending" // Provider call in progress (or abandoned after another provider won)
+	StateSuccess State = "success" // Provider returned a response
+	StateFailed  State = "failed"  // Provider failed after exhausting its retries
+)
+
+// Status tracks the outcome and history of a single provider during a Run.
+type Status[Resp any] struct {
+	Provider    string        // Provider name
+	State       State         // Current state of the provider call
+	Response    Resp          // Response when State is StateSuccess
+	Err         error         // Last error when State is StateFailed
+	Attempts    int           // Number of attempts made so far
+	LastAttempt time.Time     // Start time of the most recent attempt
+	Duration    time.Duration // Time from start to final result
+}
+
+// Config holds retry and timeout settings for a Coordinator.
+type Config struct {
+	Retry         retry.Options            // Default retry policy for every provider
+	ProviderRetry map[string]retry.Options // Per-provider retry policies overriding Retry
+	Timeout       time.Duration            // Overall time budget per provider, including retries (0 = none)
+}
+
+// Outcome describes a Run: the winning provider, if any, and every provider's status.
+type Outcome[Resp any] struct {
+	Winner   string                  // Name of the provider that answered first
+	Response Resp                    // Response from the winning provider
+	Statuses map[string]Status[Resp] // Snapshot of every provider's status when Run returned
+}
+
+// Coordinator fans a request out to named providers and returns the first success.
+type Coordinator[Req, Resp any] struct {
+	providers map[string]Provider[Req, Resp]
+	config    Config
+}
+
+// New creates a Coordinator for the given providers.
+func New[Req, Resp any](providers map[string]Provider[Req, Resp], config Config) *Coordinator[Req, Resp] {
+	return &Coordinator[Req, Resp]{
+		providers: providers,
+		config:    config,
+	}
+}
+
+// Run sends req to every provider concurrently and returns as soon as one succeeds;
+// the remaining providers are cancelled. If every provider fails, the returned error
+// wraps an *await.AggregateError and the Outcome still carries the status snapshot.
+func (c *Coordinator[Req, Resp]) Run(ctx context.Context, req Req) (*Outcome[Resp], error) {
+	if len(c.providers) == 0 {
+		return nil, ErrNoProviders
+	}
+
+	var mu sync.Mutex
+	statuses := make(map[string]*Status[Resp], len(c.providers))
+
+	type winner struct {
+		name string
+		resp Resp
+	}
+
+	names := make([]string, 0, len(c.providers))
+	for name := range c.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	tasks := make([]await.Task[winner], 0, len(names))
+	for _, name := range names {
+		name := name
+		provider := c.providers[name]
+		status := &Status[Resp]{Provider: name, State: StatePending}
+		statuses[name] = status
+
+		tasks = append(tasks, func(ctx context.Context) (winner, error) {
+			start := time.Now()
+			if c.config.Timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, c.config.Timeout)
+				defer cancel()
+			}
+
+			call := func(ctx context.Context, attempt int) (Resp, error) {
+				mu.Lock()
+				status.Attempts = attempt
+				status.LastAttempt = time.Now()
+				mu.Unlock()
+				return provider(ctx, req)
+			}
+
+			resp, err := retry.DoWithAttempt(ctx, call, c.retryOptions(name))
+
+			mu.Lock()
+			defer mu.Unlock()
+			status.Duration = time.Since(start)
+			if err != nil {
+				status.State = StateFailed
+				status.Err = err
+				return winner{}, fmt.Errorf("%s: %w", name, err)
+			}
+			status.State = StateSuccess
+			status.Response = resp
+			return winner{name: name, resp: resp}, nil
+		})
+	}
+
+	w, err := await.Any(ctx, tasks...)
+
+	mu.Lock()
+	snapshot := make(map[string]Status[Resp], len(statuses))
+	for name, s := range statuses {
+		snapshot[name] = *s
+	}
+	mu.Unlock()
+
+	outcome := &Outcome[Resp]{Statuses: snapshot}
+	if err != nil {
+		return outcome, fmt.Errorf("all providers failed: %w", err)
+	}
+	outcome.Winner = w.name
+	outcome.Response = w.resp
+	return outcome, nil
+}
+
+// retryOptions resolves the retry policy for a provider, falling back to a
+// single attempt when no policy is configured.
+func (c *Coordinator[Req, Resp]) retryOptions(name string) retry.Options {
+	opts := c.config.Retry
+	if override, ok := c.config.ProviderRetry[name]; ok {
+		opts = override
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 1
+	}
+	if opts.Strategy == nil {
+		opts.Strategy = retry.DefaultOptions().Strategy
+	}
+	return opts
+}
@@ -0,0 +1,118 @@
+package coordinator
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/remiges-tech/await"
+	"github.com/remiges-tech/await/retry"
+)
+
+func TestRun(t *testing.T) {
+	t.Run("first success wins", func(t *testing.T) {
+		providers := map[string]Provider[string, string]{
+			"fast": func(ctx context.Context, req string) (string, error) {
+				return "fast:" + req, nil
+			},
+			"slow": func(ctx context.Context, req string) (string, error) {
+				select {
+				case <-time.After(200 * time.Millisecond):
+					return "slow:" + req, nil
+				case <-ctx.Done():
+					return "", ctx.Err()
+				}
+			},
+		}
+
+		outcome, err := New(providers, Config{}).Run(context.Background(), "pan")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if outcome.Winner != "fast" || outcome.Response != "fast:pan" {
+			t.Fatalf("expected fast to win with 'fast:pan', got %s/%s", outcome.Winner, outcome.Response)
+		}
+		if len(outcome.Statuses) != 2 {
+			t.Fatalf("expected 2 statuses, got %d", len(outcome.Statuses))
+		}
+		if outcome.Statuses["fast"].State != StateSuccess {
+			t.Fatalf("expected fast to be successful, got %s", outcome.Statuses["fast"].State)
+		}
+	})
+
+	t.Run("per-provider retry options", func(t *testing.T) {
+		var flakyCalls int32
+		providers := map[string]Provider[int, int]{
+			"flaky": func(ctx context.Context, req int) (int, error) {
+				if atomic.AddInt32(&flakyCalls, 1) < 3 {
+					return 0, errors.New("temporary")
+				}
+				return req * 2, nil
+			},
+		}
+
+		config := Config{
+			Retry: retry.Options{Strategy: &retry.NoDelay{}, MaxAttempts: 1},
+			ProviderRetry: map[string]retry.Options{
+				"flaky": {Strategy: &retry.NoDelay{}, MaxAttempts: 3},
+			},
+		}
+
+		outcome, err := New(providers, config).Run(context.Background(), 21)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if outcome.Response != 42 {
+			t.Fatalf("expected 42, got %d", outcome.Response)
+		}
+		if outcome.Statuses["flaky"].Attempts != 3 {
+			t.Fatalf("expected 3 attempts, got %d", outcome.Statuses["flaky"].Attempts)
+		}
+	})
+
+	t.Run("all providers fail", func(t *testing.T) {
+		errA := errors.New("a down")
+		providers := map[string]Provider[int, int]{
+			"a": func(ctx context.Context, req int) (int, error) { return 0, errA },
+			"b": func(ctx context.Context, req int) (int, error) { return 0, errors.New("b down") },
+		}
+
+		outcome, err := New(providers, Config{}).Run(context.Background(), 1)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		var aggErr *await.AggregateError
+		if !errors.As(err, &aggErr) || len(aggErr.Errors) != 2 {
+			t.Fatalf("expected AggregateError with 2 errors, got %v", err)
+		}
+		if !errors.Is(err, errA) {
+			t.Fatalf("expected error to wrap provider error, got %v", err)
+		}
+		if outcome == nil || outcome.Statuses["a"].State != StateFailed {
+			t.Fatalf("expected failed status for provider a, got %+v", outcome)
+		}
+	})
+
+	t.Run("timeout per provider", func(t *testing.T) {
+		providers := map[string]Provider[int, int]{
+			"hang": func(ctx context.Context, req int) (int, error) {
+				<-ctx.Done()
+				return 0, ctx.Err()
+			},
+		}
+
+		_, err := New(providers, Config{Timeout: 10 * time.Millisecond}).Run(context.Background(), 1)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+		}
+	})
+
+	t.Run("no providers", func(t *testing.T) {
+		_, err := New(map[string]Provider[int, int]{}, Config{}).Run(context.Background(), 1)
+		if err != ErrNoProviders {
+			t.Fatalf("expected ErrNoProviders, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,43 @@
+package await
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// withDeadlines wraps each task to run under its entry in deadlines and
+// returns the task indices ordered earliest deadline first, tasks without a
+// deadline last in argument order.
+func withDeadlines[T any](deadlines []time.Time, tasks []Task[T]) ([]Task[T], []int, error) {
+	if len(deadlines) != len(tasks) {
+		return nil, nil, fmt.Errorf("%w: %d deadlines for %d tasks", ErrInvalidDeadlines, len(deadlines), len(tasks))
+	}
+
+	wrapped := make([]Task[T], len(tasks))
+	order := make([]int, len(tasks))
+	for i, task := range tasks {
+		order[i] = i
+		deadline := deadlines[i]
+		if deadline.IsZero() {
+			wrapped[i] = task
+			continue
+		}
+		task := task
+		wrapped[i] = func(ctx context.Context) (T, error) {
+			ctx, cancel := context.WithDeadline(ctx, deadline)
+			defer cancel()
+			return task(ctx)
+		}
+	}
+
+	sort.SliceStable(order, func(a, b int) bool {
+		da, db := deadlines[order[a]], deadlines[order[b]]
+		if da.IsZero() || db.IsZero() {
+			return !da.IsZero() && db.IsZero()
+		}
+		return da.Before(db)
+	})
+	return wrapped, order, nil
+}
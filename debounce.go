@@ -0,0 +1,79 @@
+package await
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Debounce wraps a task so that bursts of calls are coalesced into a single execution.
+// Each call restarts the wait timer; once wait elapses without another call, the task
+// runs once using the context of the most recent call. Every caller in the burst
+// receives the same Future.
+func Debounce[T any](task Task[T], wait time.Duration) func(ctx context.Context) *Future[T] {
+	var (
+		mu       sync.Mutex
+		pending  *Future[T]
+		deadline time.Time
+		lastCtx  context.Context
+	)
+
+	var fire func()
+	fire = func() {
+		mu.Lock()
+		if remaining := time.Until(deadline); remaining > 0 {
+			// Calls arrived after the timer was armed; wait out the extended window.
+			time.AfterFunc(remaining, fire)
+			mu.Unlock()
+			return
+		}
+		f, runCtx := pending, lastCtx
+		pending = nil
+		mu.Unlock()
+
+		f.complete(task(runCtx))
+	}
+
+	return func(ctx context.Context) *Future[T] {
+		mu.Lock()
+		defer mu.Unlock()
+
+		lastCtx = ctx
+		deadline = time.Now().Add(wait)
+		if pending != nil {
+			return pending
+		}
+
+		pending = newFuture[T]()
+		time.AfterFunc(wait, fire)
+		return pending
+	}
+}
+
+// Throttle wraps a task so that it starts at most once per interval.
+// The first call starts the task; calls within interval of that start receive
+// the same Future instead of triggering another execution.
+func Throttle[T any](task Task[T], interval time.Duration) func(ctx context.Context) *Future[T] {
+	var (
+		mu      sync.Mutex
+		current *Future[T]
+		started time.Time
+	)
+
+	return func(ctx context.Context) *Future[T] {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if current != nil && time.Since(started) < interval {
+			return current
+		}
+
+		f := newFuture[T]()
+		current = f
+		started = time.Now()
+		go func() {
+			f.complete(task(ctx))
+		}()
+		return f
+	}
+}
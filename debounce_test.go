@@ -0,0 +1,72 @@
+package await
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDebounce(t *testing.T) {
+	t.Run("coalesces a burst into one execution", func(t *testing.T) {
+		var calls int32
+		refresh := Debounce(Task[int](func(ctx context.Context) (int, error) {
+			return int(atomic.AddInt32(&calls, 1)), nil
+		}), 20*time.Millisecond)
+
+		var futures []*Future[int]
+		for i := 0; i < 5; i++ {
+			futures = append(futures, refresh(context.Background()))
+			time.Sleep(5 * time.Millisecond)
+		}
+
+		for _, f := range futures {
+			if f != futures[0] {
+				t.Fatal("expected all callers in the burst to share a future")
+			}
+		}
+		val, err := futures[0].Await(context.Background())
+		if err != nil || val != 1 {
+			t.Fatalf("expected {1, nil}, got {%d, %v}", val, err)
+		}
+		if atomic.LoadInt32(&calls) != 1 {
+			t.Fatalf("expected 1 execution, got %d", calls)
+		}
+	})
+
+	t.Run("separate bursts execute separately", func(t *testing.T) {
+		var calls int32
+		refresh := Debounce(Task[int](func(ctx context.Context) (int, error) {
+			return int(atomic.AddInt32(&calls, 1)), nil
+		}), 5*time.Millisecond)
+
+		first, _ := refresh(context.Background()).Await(context.Background())
+		second, _ := refresh(context.Background()).Await(context.Background())
+		if first != 1 || second != 2 {
+			t.Fatalf("expected executions 1 and 2, got %d and %d", first, second)
+		}
+	})
+}
+
+func TestThrottle(t *testing.T) {
+	t.Run("shares execution within interval", func(t *testing.T) {
+		var calls int32
+		fill := Throttle(Task[int](func(ctx context.Context) (int, error) {
+			return int(atomic.AddInt32(&calls, 1)), nil
+		}), 50*time.Millisecond)
+
+		f1 := fill(context.Background())
+		f2 := fill(context.Background())
+		if f1 != f2 {
+			t.Fatal("expected calls within interval to share a future")
+		}
+		if val, _ := f1.Await(context.Background()); val != 1 {
+			t.Fatalf("expected 1, got %d", val)
+		}
+
+		time.Sleep(60 * time.Millisecond)
+		if val, _ := fill(context.Background()).Await(context.Background()); val != 2 {
+			t.Fatalf("expected second execution after interval, got %d", val)
+		}
+	})
+}
@@ -0,0 +1,166 @@
+// Package errclass sorts errors into a small set of classes so that retry
+// loops, circuit breakers and hedging make consistent decisions about the
+// same failure. It has no dependencies on the rest of the module, so any
+// package can classify its errors without import cycles.
+//
+// Classify consults, in order: the first error in the chain implementing
+// Classer, the classifiers added with Register, and built-in rules for
+// context cancellation, HTTP status codes and common transient network errors.
+package errclass
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"sync/atomic"
+	"syscall"
+)
+
+// Class is the broad category of a failure.
+type Class int
+
+const (
+	Unknown   Class = iota // Not recognised; callers apply their own default
+	Transient              // Likely to succeed if tried again: timeouts, resets, 5xx
+	Throttled              // Rejected for load; retry, but back off and avoid adding load
+	Permanent              // Will fail again: bad input, authentication, most 4xx
+	Cancelled              // The caller gave up; not a fault of the dependency
+)
+
+// String returns the class name in lower case.
+func (c Class) String() string {
+	switch c {
+	case Transient:
+		return "transient"
+	case Throttled:
+		return "throttled"
+	case Permanent:
+		return "permanent"
+	case Cancelled:
+		return "cancelled"
+	default:
+		return "unknown"
+	}
+}
+
+// Retryable reports whether errors of the class are worth retrying.
+func (c Class) Retryable() bool {
+	return c == Transient || c == Throttled
+}
+
+// Classer is implemented by errors that know their own class.
+type Classer interface {
+	ErrorClass() Class
+}
+
+// Classifier maps an error to a class, returning Unknown to leave the decision
+// to the next classifier and the built-in rules.
+type Classifier func(err error) Class
+
+var (
+	classifiersMu sync.Mutex
+	classifiers   atomic.Pointer[[]Classifier]
+)
+
+// Register adds a classifier consulted by Classify after Classer and before
+// the built-in rules, typically from an init function of a package that owns
+// sentinel errors. Classifiers run in registration order; the first class
+// other than Unknown wins.
+func Register(c Classifier) {
+	classifiersMu.Lock()
+	defer classifiersMu.Unlock()
+
+	var next []Classifier
+	if cur := classifiers.Load(); cur != nil {
+		next = append(next, *cur...)
+	}
+	next = append(next, c)
+	classifiers.Store(&next)
+}
+
+// Classify returns the class of err, or Unknown for nil and unrecognised errors.
+func Classify(err error) Class {
+	if err == nil {
+		return Unknown
+	}
+
+	var classer Classer
+	if errors.As(err, &classer) {
+		return classer.ErrorClass()
+	}
+	if cs := classifiers.Load(); cs != nil {
+		for _, c := range *cs {
+			if class := c(err); class != Unknown {
+				return class
+			}
+		}
+	}
+
+	if errors.Is(err, context.Canceled) {
+		return Cancelled
+	}
+	if status, ok := httpStatus(err); ok {
+		return classifyStatus(status)
+	}
+	for _, e := range transientErrors {
+		if errors.Is(err, e) {
+			return Transient
+		}
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return Transient
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsTemporary {
+		return Transient
+	}
+	return Unknown
+}
+
+// transientErrors are standard library errors that usually clear up on retry.
+var transientErrors = []error{
+	context.DeadlineExceeded,
+	os.ErrDeadlineExceeded,
+	io.ErrUnexpectedEOF,
+	syscall.ECONNRESET,
+	syscall.ECONNREFUSED,
+	syscall.ECONNABORTED,
+	syscall.EPIPE,
+	syscall.ETIMEDOUT,
+	syscall.EHOSTUNREACH,
+	syscall.ENETUNREACH,
+	syscall.EAGAIN,
+}
+
+// httpStatus returns the status code of the first error in err's chain with
+// an HTTPStatusCode method, such as retry.HTTPError.
+func httpStatus(err error) (int, bool) {
+	var sc interface{ HTTPStatusCode() int }
+	if errors.As(err, &sc) {
+		return sc.HTTPStatusCode(), true
+	}
+	return 0, false
+}
+
+// classifyStatus classifies an HTTP status code.
+func classifyStatus(status int) Class {
+	switch {
+	case status == http.StatusTooManyRequests:
+		return Throttled
+	case status == http.StatusRequestTimeout,
+		status == http.StatusInternalServerError,
+		status == http.StatusBadGateway,
+		status == http.StatusServiceUnavailable,
+		status == http.StatusGatewayTimeout:
+		return Transient
+	case status >= 400 && status < 500:
+		return Permanent
+	default:
+		return Unknown
+	}
+}
@@ -0,0 +1,82 @@
+package errclass
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"syscall"
+	"testing"
+)
+
+// statusError is an HTTP client error carrying a status code.
+type statusError struct{ code int }
+
+func (e *statusError) Error() string       { return fmt.Sprintf("status %d", e.code) }
+func (e *statusError) HTTPStatusCode() int { return e.code }
+
+// classedError knows its own class.
+type classedError struct{ class Class }
+
+func (e *classedError) Error() string     { return "classed" }
+func (e *classedError) ErrorClass() Class { return e.class }
+
+func TestClassify(t *testing.T) {
+	cases := map[error]Class{
+		nil:                      Unknown,
+		errors.New("boom"):       Unknown,
+		io.EOF:                   Unknown,
+		context.Canceled:         Cancelled,
+		context.DeadlineExceeded: Transient,
+		io.ErrUnexpectedEOF:      Transient,
+		fmt.Errorf("read: %w", syscall.ECONNRESET):           Transient,
+		&net.DNSError{Err: "timeout", IsTimeout: true}:       Transient,
+		&net.DNSError{Err: "no such host", IsNotFound: true}: Unknown,
+		&statusError{429}: Throttled,
+		&statusError{503}: Transient,
+		&statusError{404}: Permanent,
+		&statusError{200}: Unknown,
+		fmt.Errorf("wrapped: %w", &classedError{Permanent}): Permanent,
+		// A Classer takes precedence over the built-in rules.
+		fmt.Errorf("%w: %w", &classedError{Throttled}, context.Canceled): Throttled,
+	}
+	for err, want := range cases {
+		if got := Classify(err); got != want {
+			t.Errorf("Classify(%v) = %v, want %v", err, got, want)
+		}
+	}
+}
+
+func TestRegister(t *testing.T) {
+	// Register is global; restore the classifiers for the other tests.
+	saved := classifiers.Load()
+	t.Cleanup(func() { classifiers.Store(saved) })
+
+	errQuota := errors.New("quota exhausted")
+	errOther := errors.New("other")
+	Register(func(err error) Class {
+		if errors.Is(err, errQuota) {
+			return Throttled
+		}
+		return Unknown
+	})
+
+	if got := Classify(fmt.Errorf("call: %w", errQuota)); got != Throttled {
+		t.Errorf("expected registered classifier to return Throttled, got %v", got)
+	}
+	if got := Classify(errOther); got != Unknown {
+		t.Errorf("expected other errors to fall through, got %v", got)
+	}
+	if got := Classify(context.Canceled); got != Cancelled {
+		t.Errorf("expected built-in rules after classifiers, got %v", got)
+	}
+}
+
+func TestClassRetryable(t *testing.T) {
+	for class, want := range map[Class]bool{Unknown: false, Transient: true, Throttled: true, Permanent: false, Cancelled: false} {
+		if class.Retryable() != want {
+			t.Errorf("%v.Retryable() = %v, want %v", class, !want, want)
+		}
+	}
+}
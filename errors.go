@@ -1,6 +1,7 @@
 package await
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
@@ -9,32 +10,272 @@ import (
 var (
 	// ErrNoTasks is returned when an empty task slice is provided to All, Any, or Race.
 	ErrNoTasks = errors.New("no tasks provided")
+
+	// ErrPoolClosed is returned by futures for tasks submitted to a closed Pool.
+	ErrPoolClosed = errors.New("pool is closed")
+
+	// ErrLifecycleStopped is returned when starting a task on a Lifecycle that is shutting down.
+	ErrLifecycleStopped = errors.New("lifecycle is stopped")
+
+	// ErrSiblingSucceeded is the context.Cause seen by tasks that Any cancels
+	// because another task succeeded.
+	ErrSiblingSucceeded = errors.New("sibling task succeeded")
+
+	// ErrSiblingCompleted is the context.Cause seen by tasks that Race cancels
+	// because another task completed first.
+	ErrSiblingCompleted = errors.New("sibling task completed")
+
+	// ErrNoMatch is recorded by Find for tasks that succeeded with a value
+	// that did not satisfy the predicate.
+	ErrNoMatch = errors.New("value did not match predicate")
+
+	// ErrInvalidQuorum is returned by Some when n is not between 1 and the
+	// number of tasks.
+	ErrInvalidQuorum = errors.New("invalid quorum size")
+
+	// ErrInvalidLaunchOrder is returned by Any when Options.LaunchOrder or
+	// Options.LaunchWeights does not match the tasks.
+	ErrInvalidLaunchOrder = errors.New("invalid launch order")
+
+	// ErrScopeClosed is returned when starting a task on a Scope whose WithScope call has returned.
+	ErrScopeClosed = errors.New("scope is closed")
+
+	// ErrInsufficientTime is returned for a task wrapped with WithEstimate whose
+	// estimate exceeds the time left before its context deadline.
+	ErrInsufficientTime = errors.New("insufficient time before deadline")
+
+	// ErrChannelClosed is returned by a FromChannelValue task whose channel
+	// was closed before delivering a value.
+	ErrChannelClosed = errors.New("channel closed")
+
+	// ErrIgnoredCancellation annotates the error of a task wrapped with
+	// WithCancelCheck that returned long after its context was done.
+	ErrIgnoredCancellation = errors.New("task did not honor context cancellation")
+
+	// ErrTaskCancelled is the context.Cause seen by a task cancelled by name
+	// with CancelGroup.CancelTask.
+	ErrTaskCancelled = errors.New("task cancelled by name")
+
+	// ErrInvalidResult is wrapped by the error recorded for a task whose value
+	// was rejected by Options.Validate.
+	ErrInvalidResult = errors.New("result failed validation")
+
+	// ErrSkipped is wrapped by the error recorded for a task of a
+	// concurrency-limited All that was never started because ctx was done
+	// while it waited, as opposed to a task that ran and failed.
+	ErrSkipped = errors.New("task skipped")
+
+	// ErrInvalidDeadlines is returned by All when Options.Deadlines does not
+	// have one entry per task.
+	ErrInvalidDeadlines = errors.New("invalid task deadlines")
+
+	// ErrNoSpawner is returned by the Spawner of a context that belongs to
+	// neither a Scope nor a task run with Options.Subtasks.
+	ErrNoSpawner = errors.New("no spawner in context")
+
+	// ErrOverloaded is wrapped by the error of a task that Options.Admission
+	// refused to start because the process was overloaded.
+	ErrOverloaded = errors.New("process overloaded")
+
+	// ErrTooManyFailures is wrapped by the cancellation cause and the error
+	// All returns when Options.AbortAfterFailures stops a run.
+	ErrTooManyFailures = errors.New("too many tasks failed")
 )
 
+// CancelledBySibling reports whether ctx was cancelled by Any or Race because
+// another task won, as opposed to the caller cancelling the parent context.
+// Tasks can use it to skip alerting or rollback work for expected cancellations.
+func CancelledBySibling(ctx context.Context) bool {
+	cause := context.Cause(ctx)
+	return errors.Is(cause, ErrSiblingSucceeded) || errors.Is(cause, ErrSiblingCompleted)
+}
+
+// DefaultAggregateErrorLimit is the number of errors AggregateError.Error lists
+// before summarizing the rest, when Limit is zero.
+const DefaultAggregateErrorLimit = 10
+
 // AggregateError contains multiple errors from concurrent operations.
 // Returned by Any when all tasks fail.
 type AggregateError struct {
-	Errors []error // All errors that occurred during execution
+	Errors  []error  // All errors that occurred during execution
+	Indices []int    // Task index of each error, parallel to Errors; nil if unknown
+	Names   []string // Task name of each error ("" if unnamed), parallel to Errors; nil if names were not tracked
+	Repeats []int    // Number of later errors merged into each entry by Options.DedupErrors, parallel to Errors; nil without it
+	Limit   int      // Errors listed by Error before truncating (0 = DefaultAggregateErrorLimit, < 0 = no limit)
+}
+
+// newAggregateError builds the error returned by Any. failed holds the tracked
+// identity of each error when tracking was enabled, and is used for Names.
+func newAggregateError(opts Options, errs []error, indices []int, failed []*taskInfo) *AggregateError {
+	var repeats []int
+	if opts.DedupErrors {
+		errs, indices, failed, repeats = dedupErrors(opts.SameError, errs, indices, failed)
+	}
+	e := &AggregateError{Errors: errs, Indices: indices, Repeats: repeats, Limit: opts.ErrorLimit}
+	if len(failed) == len(errs) {
+		e.Names = make([]string, len(failed))
+		for i, info := range failed {
+			e.Names[i] = info.name
+		}
+	}
+	return e
+}
+
+// dedupErrors keeps the first of each group of matching errors, dropping the
+// rest along with their indices and tracking info, and returns how many were
+// merged into each kept error. Errors match when same reports true for their
+// task errors, or by errors.Is in either direction when same is nil.
+func dedupErrors(same func(a, b error) bool, errs []error, indices []int, failed []*taskInfo) ([]error, []int, []*taskInfo, []int) {
+	if same == nil {
+		same = func(a, b error) bool { return errors.Is(a, b) || errors.Is(b, a) }
+	}
+	tracked := len(failed) == len(errs)
+	keptErrs := errs[:0:0]
+	var keptIndices []int
+	var keptFailed []*taskInfo
+	repeats := make([]int, 0, len(errs))
+
+next:
+	for i, err := range errs {
+		for k, kept := range keptErrs {
+			if err != nil && kept != nil && same(unwrapTaskError(kept), unwrapTaskError(err)) {
+				repeats[k]++
+				continue next
+			}
+		}
+		keptErrs = append(keptErrs, err)
+		repeats = append(repeats, 0)
+		if i < len(indices) {
+			keptIndices = append(keptIndices, indices[i])
+		}
+		if tracked {
+			keptFailed = append(keptFailed, failed[i])
+		}
+	}
+	return keptErrs, keptIndices, keptFailed, repeats
+}
+
+// unwrapTaskError returns the task's own error if err is a *TaskError.
+func unwrapTaskError(err error) error {
+	if te, ok := err.(*TaskError); ok {
+		return te.Err
+	}
+	return err
 }
 
-// Error returns a formatted message listing all contained errors.
+// Len returns the number of contained errors.
+func (e *AggregateError) Len() int {
+	return len(e.Errors)
+}
+
+// Error returns a formatted message listing the contained errors, each
+// prefixed with its task index and name when known. Only the first Limit
+// errors are listed; the rest are summarized by count.
 func (e *AggregateError) Error() string {
 	if len(e.Errors) == 0 {
 		return "no errors"
 	}
 
+	limit := e.Limit
+	if limit == 0 {
+		limit = DefaultAggregateErrorLimit
+	}
+
 	var messages []string
-	for _, err := range e.Errors {
-		if err != nil {
-			messages = append(messages, err.Error())
+	listed := 0
+	for i, err := range e.Errors {
+		if err == nil {
+			continue
+		}
+		if limit > 0 && listed == limit {
+			messages = append(messages, fmt.Sprintf("... and %d more", e.countNonNil(i)))
+			break
+		}
+		msg := e.label(i, err)
+		if i < len(e.Repeats) && e.Repeats[i] > 0 {
+			msg = fmt.Sprintf("%s (+%d identical)", msg, e.Repeats[i])
 		}
+		messages = append(messages, msg)
+		listed++
 	}
 
 	return fmt.Sprintf("multiple errors occurred: [%s]", strings.Join(messages, "; "))
 }
 
+// label formats the i-th error with its task identity. Errors that are already
+// *TaskError carry their own identity and are left as is.
+func (e *AggregateError) label(i int, err error) string {
+	var te *TaskError
+	if i >= len(e.Indices) || errors.As(err, &te) {
+		return err.Error()
+	}
+	if i < len(e.Names) && e.Names[i] != "" {
+		return fmt.Sprintf("task %d (%s): %v", e.Indices[i], e.Names[i], err)
+	}
+	return fmt.Sprintf("task %d: %v", e.Indices[i], err)
+}
+
+// countNonNil returns the number of non-nil errors from position i onwards.
+func (e *AggregateError) countNonNil(i int) int {
+	n := 0
+	for _, err := range e.Errors[i:] {
+		if err != nil {
+			n++
+		}
+	}
+	return n
+}
+
 // Unwrap returns all contained errors for use with errors.Is and errors.As.
 // Allows checking if an AggregateError contains a specific error type.
 func (e *AggregateError) Unwrap() []error {
 	return e.Errors
 }
+
+// IndexedError pairs a failed result's error with its position in the results
+// slice. Returned by Partition.
+type IndexedError struct {
+	Index int   // Position of the result in the slice
+	Err   error // The result's error
+}
+
+// Error returns the error prefixed with its index.
+func (e IndexedError) Error() string {
+	return fmt.Sprintf("task %d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e IndexedError) Unwrap() error {
+	return e.Err
+}
+
+// TaskError identifies which task produced an error.
+// Returned by the WithOptions variants of All, Any and Race when Options.TaskErrors is set.
+type TaskError struct {
+	Index int    // Position of the task in the argument list
+	Name  string // Name given with Named, or "" if unnamed
+	Err   error  // The error returned by the task
+}
+
+// Error returns the task's error prefixed with its index and name.
+func (e *TaskError) Error() string {
+	if e.Name != "" {
+		return fmt.Sprintf("task %d (%s): %v", e.Index, e.Name, e.Err)
+	}
+	return fmt.Sprintf("task %d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the task's error.
+func (e *TaskError) Unwrap() error {
+	return e.Err
+}
+
+// Timeout reports whether the task failed because a context deadline passed.
+func (e *TaskError) Timeout() bool {
+	return errors.Is(e.Err, context.DeadlineExceeded)
+}
+
+// Canceled reports whether the task failed because its context was cancelled.
+func (e *TaskError) Canceled() bool {
+	return errors.Is(e.Err, context.Canceled)
+}
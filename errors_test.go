@@ -0,0 +1,124 @@
+package await
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestAggregateErrorFormatting(t *testing.T) {
+	t.Run("labels errors with task identity", func(t *testing.T) {
+		_, err := AnyWithOptions(context.Background(), Options{Sequential: true},
+			Named("primary", func(ctx context.Context) (int, error) { return 0, errors.New("down") }),
+			func(ctx context.Context) (int, error) { return 0, errors.New("timeout") },
+		)
+		var aggErr *AggregateError
+		if !errors.As(err, &aggErr) {
+			t.Fatalf("expected AggregateError, got %v", err)
+		}
+		if aggErr.Len() != 2 {
+			t.Fatalf("expected 2 errors, got %d", aggErr.Len())
+		}
+		if got := aggErr.Error(); got != "multiple errors occurred: [task 0: down; task 1: timeout]" {
+			t.Fatalf("unexpected message %q", got)
+		}
+
+		aggErr.Names = []string{"primary", ""}
+		if got := aggErr.Error(); got != "multiple errors occurred: [task 0 (primary): down; task 1: timeout]" {
+			t.Fatalf("unexpected message %q", got)
+		}
+	})
+
+	t.Run("task errors are not labeled twice", func(t *testing.T) {
+		_, err := AnyWithOptions(context.Background(), Options{TaskErrors: true, Sequential: true},
+			Named("primary", func(ctx context.Context) (int, error) { return 0, errors.New("down") }),
+		)
+		if got := err.Error(); got != "multiple errors occurred: [task 0 (primary): down]" {
+			t.Fatalf("unexpected message %q", got)
+		}
+	})
+
+	t.Run("truncates long lists", func(t *testing.T) {
+		tasks := make([]Task[int], 25)
+		for i := range tasks {
+			i := i
+			tasks[i] = func(ctx context.Context) (int, error) { return 0, fmt.Errorf("e%d", i) }
+		}
+
+		_, err := AnyWithOptions(context.Background(), Options{Sequential: true}, tasks...)
+		msg := err.Error()
+		if !strings.HasSuffix(msg, "task 9: e9; ... and 15 more]") {
+			t.Fatalf("expected default truncation after 10 errors, got %q", msg)
+		}
+
+		_, err = AnyWithOptions(context.Background(), Options{Sequential: true, ErrorLimit: 2}, tasks...)
+		if got := err.Error(); got != "multiple errors occurred: [task 0: e0; task 1: e1; ... and 23 more]" {
+			t.Fatalf("unexpected message %q", got)
+		}
+
+		_, err = AnyWithOptions(context.Background(), Options{Sequential: true, ErrorLimit: -1}, tasks...)
+		if strings.Contains(err.Error(), "more") || !strings.Contains(err.Error(), "task 24: e24") {
+			t.Fatalf("expected every error listed, got %q", err.Error())
+		}
+	})
+
+	t.Run("without identity", func(t *testing.T) {
+		aggErr := &AggregateError{Errors: []error{errors.New("a"), nil, errors.New("b")}}
+		if got := aggErr.Error(); got != "multiple errors occurred: [a; b]" {
+			t.Fatalf("unexpected message %q", got)
+		}
+	})
+}
+
+func TestDedupErrors(t *testing.T) {
+	ctx := context.Background()
+	errDown := errors.New("down")
+	fail := func(err error) Task[int] {
+		return func(ctx context.Context) (int, error) { return 0, err }
+	}
+
+	t.Run("errors.Is", func(t *testing.T) {
+		_, err := AnyWithOptions(ctx, Options{Sequential: true, DedupErrors: true, TaskErrors: true},
+			fail(errDown),
+			fail(fmt.Errorf("provider b: %w", errDown)),
+			fail(errors.New("timeout")),
+			fail(errDown),
+		)
+		var aggErr *AggregateError
+		if !errors.As(err, &aggErr) {
+			t.Fatalf("expected AggregateError, got %v", err)
+		}
+		if aggErr.Len() != 2 || aggErr.Indices[0] != 0 || aggErr.Indices[1] != 2 {
+			t.Fatalf("expected errors of tasks 0 and 2, got %v at %v", aggErr.Errors, aggErr.Indices)
+		}
+		if aggErr.Repeats[0] != 2 || aggErr.Repeats[1] != 0 {
+			t.Fatalf("expected repeats [2 0], got %v", aggErr.Repeats)
+		}
+		if !strings.Contains(err.Error(), "down (+2 identical)") {
+			t.Fatalf("expected repeat count in message, got %q", err.Error())
+		}
+	})
+
+	t.Run("comparator", func(t *testing.T) {
+		sameMessage := func(a, b error) bool { return a.Error() == b.Error() }
+		_, err := AnyWithOptions(ctx, Options{DedupErrors: true, SameError: sameMessage},
+			fail(errors.New("down")),
+			fail(errors.New("down")),
+			fail(errors.New("down")),
+		)
+		var aggErr *AggregateError
+		if !errors.As(err, &aggErr) || aggErr.Len() != 1 || aggErr.Repeats[0] != 2 {
+			t.Fatalf("expected one error repeated twice, got %v", err)
+		}
+	})
+
+	t.Run("off by default", func(t *testing.T) {
+		_, err := Any(ctx, fail(errDown), fail(errDown))
+		var aggErr *AggregateError
+		if !errors.As(err, &aggErr) || aggErr.Len() != 2 || aggErr.Repeats != nil {
+			t.Fatalf("expected both errors kept, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,25 @@
+package await
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// WithEstimate declares how long task is expected to take. When the wrapped task
+// starts, it fails fast with ErrInsufficientTime instead of running if its context
+// deadline leaves less than estimate. Tasks without a deadline always run.
+//
+// The check happens when the task actually starts, so a task queued in a Pool or
+// FromChannel is judged against the time remaining once a worker picks it up.
+func WithEstimate[T any](task Task[T], estimate time.Duration) Task[T] {
+	return func(ctx context.Context) (T, error) {
+		if deadline, ok := ctx.Deadline(); ok {
+			if remaining := time.Until(deadline); remaining < estimate {
+				var zero T
+				return zero, fmt.Errorf("%w: estimated %v, %v remaining", ErrInsufficientTime, estimate, remaining.Round(time.Millisecond))
+			}
+		}
+		return task(ctx)
+	}
+}
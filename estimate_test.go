@@ -0,0 +1,71 @@
+package await
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWithEstimate(t *testing.T) {
+	var ran int
+	slow := WithEstimate(Task[int](func(ctx context.Context) (int, error) {
+		ran++
+		return 1, nil
+	}), 200*time.Millisecond)
+
+	t.Run("no deadline runs", func(t *testing.T) {
+		ran = 0
+		val, err := slow(context.Background())
+		if err != nil || val != 1 || ran != 1 {
+			t.Fatalf("expected task to run, got {%d, %v}", val, err)
+		}
+	})
+
+	t.Run("enough time runs", func(t *testing.T) {
+		ran = 0
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		if _, err := slow(ctx); err != nil || ran != 1 {
+			t.Fatalf("expected task to run, got %v", err)
+		}
+	})
+
+	t.Run("All records ErrInsufficientTime", func(t *testing.T) {
+		ran = 0
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		fast := Task[int](func(ctx context.Context) (int, error) { return 2, nil })
+		results, err := All(ctx, slow, fast)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !errors.Is(results[0].Err, ErrInsufficientTime) {
+			t.Fatalf("expected ErrInsufficientTime, got %v", results[0].Err)
+		}
+		if results[1].Value != 2 || ran != 0 {
+			t.Fatalf("expected only the fast task to run, got %v (slow ran %d times)", results, ran)
+		}
+	})
+
+	t.Run("Pool checks at start", func(t *testing.T) {
+		ran = 0
+		ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+		defer cancel()
+
+		p := NewPool[int](ctx, 1)
+		defer p.Close()
+
+		p.Submit(func(ctx context.Context) (int, error) {
+			time.Sleep(150 * time.Millisecond)
+			return 0, nil
+		})
+		// Enough time at submission, but not once the blocker has finished.
+		_, err := p.Submit(slow).Await(context.Background())
+		if !errors.Is(err, ErrInsufficientTime) || ran != 0 {
+			t.Fatalf("expected ErrInsufficientTime, got %v", err)
+		}
+	})
+}
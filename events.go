@@ -0,0 +1,135 @@
+package await
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Event is a notification published on the package's event bus, for
+// dashboards and tracking that want a single stream instead of per-call
+// callbacks. Its concrete type is TaskQueued, TaskStarted, TaskFinished,
+// RetryScheduled or CircuitOpened.
+type Event interface {
+	isEvent()
+}
+
+// TaskQueued is published when a task is submitted to a Pool.
+type TaskQueued struct {
+	Time     time.Time
+	Priority Priority // The task's priority
+	Pending  int      // Tasks waiting for a worker, including this one
+}
+
+// TaskStarted is published when All, Any, Race or a Pool starts a task.
+type TaskStarted struct {
+	Time  time.Time
+	Index int // Position of the task in the call's arguments; -1 for Pool tasks
+}
+
+// TaskFinished is published when a task started by All, Any, Race or a Pool
+// returns.
+type TaskFinished struct {
+	Time     time.Time
+	Index    int           // As in TaskStarted
+	Name     string        // Name given with Named, or ""
+	Duration time.Duration // How long the task ran
+	Err      error         // The task's error, or nil
+}
+
+// RetryScheduled is published by the retry package when an attempt failed
+// and another one will follow after Delay.
+type RetryScheduled struct {
+	Time    time.Time
+	Attempt int           // The attempt that failed
+	Delay   time.Duration // Wait before the next attempt
+	Err     error         // The attempt's error
+}
+
+// CircuitOpened is published by circuit breakers built on this package when
+// they start skipping a dependency.
+type CircuitOpened struct {
+	Time  time.Time
+	Name  string    // The dependency whose circuit opened
+	Until time.Time // When the dependency will be tried again
+}
+
+func (TaskQueued) isEvent()     {}
+func (TaskStarted) isEvent()    {}
+func (TaskFinished) isEvent()   {}
+func (RetryScheduled) isEvent() {}
+func (CircuitOpened) isEvent()  {}
+
+type subscriber struct {
+	fn func(Event)
+}
+
+var (
+	subscribersMu sync.Mutex
+	subscribers   atomic.Pointer[[]*subscriber]
+)
+
+// Subscribe registers fn to receive every published event and returns a
+// function that removes it. fn is called synchronously from the goroutine
+// that published the event, possibly concurrently with other events, so it
+// must be safe for concurrent use and return quickly; hand events to a
+// channel or queue for slow consumers.
+//
+// Tasks are tracked while anyone is subscribed, which costs a small
+// allocation per task, so subscribe for as long as the events are needed.
+func Subscribe(fn func(Event)) (unsubscribe func()) {
+	s := &subscriber{fn: fn}
+	subscribersMu.Lock()
+	defer subscribersMu.Unlock()
+
+	var next []*subscriber
+	if cur := subscribers.Load(); cur != nil {
+		next = append(next, *cur...)
+	}
+	next = append(next, s)
+	subscribers.Store(&next)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { removeSubscriber(s) })
+	}
+}
+
+func removeSubscriber(s *subscriber) {
+	subscribersMu.Lock()
+	defer subscribersMu.Unlock()
+
+	cur := subscribers.Load()
+	if cur == nil {
+		return
+	}
+	next := make([]*subscriber, 0, len(*cur))
+	for _, other := range *cur {
+		if other != s {
+			next = append(next, other)
+		}
+	}
+	if len(next) == 0 {
+		subscribers.Store(nil)
+		return
+	}
+	subscribers.Store(&next)
+}
+
+// Subscribed reports whether any subscriber is registered. Publishers check
+// it before building an event, so that events cost nothing when unobserved.
+func Subscribed() bool {
+	return subscribers.Load() != nil
+}
+
+// Publish delivers ev to every subscriber. It is exported for packages built
+// on await, such as retry, to publish their events on the same bus.
+func Publish(ev Event) {
+	subs := subscribers.Load()
+	if subs == nil {
+		return
+	}
+	for _, s := range *subs {
+		s.fn(ev)
+	}
+}
@@ -0,0 +1,98 @@
+package await
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+// eventLog collects published events for a test.
+type eventLog struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func subscribeLog(t *testing.T) *eventLog {
+	t.Helper()
+	l := &eventLog{}
+	t.Cleanup(Subscribe(func(ev Event) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.events = append(l.events, ev)
+	}))
+	return l
+}
+
+func (l *eventLog) snapshot() []Event {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]Event(nil), l.events...)
+}
+
+func TestEvents(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("All publishes start and finish per task", func(t *testing.T) {
+		log := subscribeLog(t)
+		errDown := errors.New("down")
+
+		_, err := All(ctx,
+			Named("cvl", func(ctx context.Context) (int, error) { return 1, nil }),
+			func(ctx context.Context) (int, error) { return 0, errDown },
+		)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		started, finished := map[int]bool{}, map[int]TaskFinished{}
+		for _, ev := range log.snapshot() {
+			switch ev := ev.(type) {
+			case TaskStarted:
+				started[ev.Index] = true
+			case TaskFinished:
+				finished[ev.Index] = ev
+			}
+		}
+		if !started[0] || !started[1] {
+			t.Fatalf("expected both tasks started, got %v", started)
+		}
+		if finished[0].Name != "cvl" || finished[0].Err != nil || !errors.Is(finished[1].Err, errDown) {
+			t.Fatalf("unexpected finish events %+v", finished)
+		}
+	})
+
+	t.Run("Pool publishes queued tasks", func(t *testing.T) {
+		log := subscribeLog(t)
+		pool := NewPool[int](ctx, 1)
+		_, _ = pool.Submit(Named("job", func(ctx context.Context) (int, error) { return 1, nil }), WithPriority(PriorityHigh)).Await(ctx)
+		pool.Close()
+
+		var queued, finished bool
+		for _, ev := range log.snapshot() {
+			switch ev := ev.(type) {
+			case TaskQueued:
+				queued = ev.Priority == PriorityHigh
+			case TaskFinished:
+				finished = ev.Index == -1 && ev.Name == "job"
+			}
+		}
+		if !queued || !finished {
+			t.Fatalf("expected queued and finished events, got %+v", log.snapshot())
+		}
+	})
+
+	t.Run("unsubscribe stops delivery", func(t *testing.T) {
+		calls := 0
+		unsubscribe := Subscribe(func(Event) { calls++ })
+		unsubscribe()
+		unsubscribe()
+		if Subscribed() {
+			t.Fatal("expected no subscribers left")
+		}
+		_, _ = All(ctx, func(ctx context.Context) (int, error) { return 1, nil })
+		if calls != 0 {
+			t.Fatalf("expected no events after unsubscribing, got %d", calls)
+		}
+	})
+}
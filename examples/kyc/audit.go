@@ -0,0 +1,151 @@
+package kyc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/remiges-tech/await/errclass"
+)
+
+// AuditRecord describes one attempt to verify a PAN with one provider.
+type AuditRecord struct {
+	Time     time.Time     `json:"time"`            // When the attempt started
+	CheckID  string        `json:"check_id"`        // Shared by every attempt of one CheckKYC call
+	PAN      string        `json:"pan"`             // Masked PAN; only the last four characters are kept
+	Provider string        `json:"provider"`        // Provider name
+	Attempt  int           `json:"attempt"`         // 1-based attempt number for this provider
+	Duration time.Duration `json:"duration_ns"`     // How long the attempt took
+	Outcome  string        `json:"outcome"`         // "success", "failed" or "cancelled"
+	Class    string        `json:"class,omitempty"` // errclass class of the failure
+	Error    string        `json:"error,omitempty"` // Failure message
+	State    KYCState      `json:"state,omitempty"` // Canonical KYC state on success
+}
+
+// AuditSink receives an AuditRecord for every provider attempt. Implementations
+// must be safe for concurrent use, since providers are checked in parallel.
+type AuditSink interface {
+	Audit(rec AuditRecord) error
+}
+
+// newAuditRecord builds the record for an attempt that started at start and
+// returned status and err.
+func newAuditRecord(checkID, pan, provider string, attempt int, start time.Time, status KYCStatus, err error) AuditRecord {
+	rec := AuditRecord{
+		Time:     start,
+		CheckID:  checkID,
+		PAN:      maskPAN(pan),
+		Provider: provider,
+		Attempt:  attempt,
+		Duration: time.Since(start),
+		Outcome:  "success",
+		State:    status.State,
+	}
+	if err != nil {
+		class := errclass.Classify(err)
+		rec.Outcome = "failed"
+		if class == errclass.Cancelled {
+			rec.Outcome = "cancelled"
+		}
+		rec.Class = class.String()
+		rec.Error = err.Error()
+		rec.State = ""
+	}
+	return rec
+}
+
+// maskPAN hides all but the last four characters of a PAN.
+func maskPAN(pan string) string {
+	if len(pan) <= 4 {
+		return strings.Repeat("*", len(pan))
+	}
+	return strings.Repeat("*", len(pan)-4) + pan[len(pan)-4:]
+}
+
+// newCheckID returns a random ID correlating the attempts of one check.
+func newCheckID() string {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// JSONFileSink appends audit records to a file as JSON lines.
+type JSONFileSink struct {
+	mu   sync.Mutex
+	file *os.File
+	enc  *json.Encoder
+}
+
+// NewJSONFileSink opens path for appending, creating it if needed.
+func NewJSONFileSink(path string) (*JSONFileSink, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONFileSink{file: f, enc: json.NewEncoder(f)}, nil
+}
+
+// Audit writes rec as one line of JSON.
+func (s *JSONFileSink) Audit(rec AuditRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return os.ErrClosed
+	}
+	return s.enc.Encode(rec)
+}
+
+// Close flushes the file to disk and closes it.
+func (s *JSONFileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return nil
+	}
+	err := errors.Join(s.file.Sync(), s.file.Close())
+	s.file = nil
+	return err
+}
+
+// SlogSink writes audit records to a structured logger, one "kyc attempt"
+// event per record: at Info level for successes and Warn for failures.
+type SlogSink struct {
+	logger *slog.Logger
+}
+
+// NewSlogSink creates a SlogSink writing to logger.
+func NewSlogSink(logger *slog.Logger) *SlogSink {
+	return &SlogSink{logger: logger}
+}
+
+// Audit logs rec.
+func (s *SlogSink) Audit(rec AuditRecord) error {
+	level := slog.LevelInfo
+	if rec.Outcome != "success" {
+		level = slog.LevelWarn
+	}
+	attrs := []slog.Attr{
+		slog.String("check_id", rec.CheckID),
+		slog.String("pan", rec.PAN),
+		slog.String("provider", rec.Provider),
+		slog.Int("attempt", rec.Attempt),
+		slog.Duration("duration", rec.Duration),
+		slog.String("outcome", rec.Outcome),
+	}
+	if rec.Error != "" {
+		attrs = append(attrs, slog.String("class", rec.Class), slog.String("error", rec.Error))
+	} else {
+		attrs = append(attrs, slog.String("state", string(rec.State)))
+	}
+	s.logger.LogAttrs(context.Background(), level, "kyc attempt", attrs...)
+	return nil
+}
@@ -0,0 +1,112 @@
+package kyc_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/remiges-tech/await/examples/kyc"
+)
+
+// memorySink collects audit records in memory.
+type memorySink struct {
+	mu      sync.Mutex
+	records []kyc.AuditRecord
+}
+
+func (s *memorySink) Audit(rec kyc.AuditRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = append(s.records, rec)
+	return nil
+}
+
+func TestCoordinatorAudit(t *testing.T) {
+	sink := &memorySink{}
+	config := kyc.DefaultCoordinatorConfig()
+	config.RetryBackoff = time.Millisecond
+	config.Audit = sink
+
+	providers := map[string]kyc.KYCProvider{
+		"CAMS": &MockProvider{name: "CAMS", shouldFail: true, failCount: 1},
+	}
+	coordinator := kyc.NewCoordinator(providers, config)
+	if _, _, _, err := coordinator.CheckKYC(context.Background(), kyc.PanDetails{PAN: "ABCDE1234F"}); err != nil {
+		t.Fatalf("Expected success, got %v", err)
+	}
+
+	if len(sink.records) != 2 {
+		t.Fatalf("Expected a record per attempt, got %+v", sink.records)
+	}
+	failed, succeeded := sink.records[0], sink.records[1]
+	if failed.Outcome != "failed" || failed.Attempt != 1 || failed.Error == "" || failed.Class == "" {
+		t.Errorf("Unexpected failed attempt record %+v", failed)
+	}
+	if succeeded.Outcome != "success" || succeeded.Attempt != 2 || succeeded.State != kyc.StateVerified {
+		t.Errorf("Unexpected successful attempt record %+v", succeeded)
+	}
+	if failed.CheckID == "" || failed.CheckID != succeeded.CheckID {
+		t.Errorf("Expected attempts of one check to share a check ID, got %q and %q", failed.CheckID, succeeded.CheckID)
+	}
+	if succeeded.PAN != "******234F" || succeeded.Provider != "CAMS" {
+		t.Errorf("Expected masked PAN and provider name, got %q, %q", succeeded.PAN, succeeded.Provider)
+	}
+}
+
+func TestJSONFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	sink, err := kyc.NewJSONFileSink(path)
+	if err != nil {
+		t.Fatalf("NewJSONFileSink: %v", err)
+	}
+	for _, provider := range []string{"CVL", "NDML"} {
+		if err := sink.Audit(kyc.AuditRecord{Provider: provider, Attempt: 1, Outcome: "success"}); err != nil {
+			t.Fatalf("Audit: %v", err)
+		}
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if err := sink.Audit(kyc.AuditRecord{}); err == nil {
+		t.Error("Expected an error auditing to a closed sink")
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer f.Close()
+	var providers []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var rec kyc.AuditRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			t.Fatalf("Expected JSON lines, got %q: %v", scanner.Text(), err)
+		}
+		providers = append(providers, rec.Provider)
+	}
+	if strings.Join(providers, ",") != "CVL,NDML" {
+		t.Errorf("Expected records in write order, got %v", providers)
+	}
+}
+
+func TestSlogSink(t *testing.T) {
+	var buf bytes.Buffer
+	sink := kyc.NewSlogSink(slog.New(slog.NewJSONHandler(&buf, nil)))
+	_ = sink.Audit(kyc.AuditRecord{Provider: "KARVY", Attempt: 2, Outcome: "failed", Class: "transient", Error: "timeout"})
+
+	out := buf.String()
+	for _, want := range []string{`"level":"WARN"`, `"msg":"kyc attempt"`, `"provider":"KARVY"`, `"class":"transient"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %s in %s", want, out)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+// Package authretry refreshes an expired credential and retries a provider
+// call once when it fails with kyc.ErrAuthentication.
+//
+// The coordinator classifies kyc.ErrAuthentication as permanent, so its retry
+// loop never retries it: another attempt with the same token would fail the
+// same way. Credential rotation is handled inside a single attempt instead.
+// The call is retried once with a fresh token, and if that also fails with
+// ErrAuthentication the error is returned and stays permanent. A refresh that
+// itself fails is classified by its own error. A token service that is down
+// can therefore still be retried by the coordinator.
+package authretry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/remiges-tech/await/examples/kyc"
+)
+
+// ErrRefreshFailed is wrapped by the error returned when the token could not
+// be refreshed after an authentication failure.
+var ErrRefreshFailed = errors.New("token refresh failed")
+
+// TokenSource supplies provider credentials.
+type TokenSource interface {
+	// Token returns the current token.
+	Token(ctx context.Context) (string, error)
+	// Refresh replaces stale, the token a call was rejected with, and returns
+	// the new token. If the token has already been replaced since stale was
+	// handed out, it returns the current token without refreshing again, so
+	// concurrent callers rejected together share one refresh.
+	Refresh(ctx context.Context, stale string) (string, error)
+}
+
+// Do calls fn with the current token. If fn fails with kyc.ErrAuthentication,
+// the token is refreshed and fn is called once more with the new token.
+func Do[T any](ctx context.Context, src TokenSource, fn func(ctx context.Context, token string) (T, error)) (T, error) {
+	var zero T
+	token, err := src.Token(ctx)
+	if err != nil {
+		return zero, err
+	}
+
+	val, err := fn(ctx, token)
+	if !errors.Is(err, kyc.ErrAuthentication) {
+		return val, err
+	}
+
+	token, refreshErr := src.Refresh(ctx, token)
+	if refreshErr != nil {
+		return zero, fmt.Errorf("%w: %w", ErrRefreshFailed, refreshErr)
+	}
+	return fn(ctx, token)
+}
+
+// CheckFunc performs a KYC check with the given token.
+type CheckFunc func(ctx context.Context, token string, panDetails kyc.PanDetails) (kyc.KYCStatus, error)
+
+// Provider returns a kyc.KYCProvider that runs check through Do, for
+// registering a token-authenticated provider with the coordinator.
+func Provider(src TokenSource, check CheckFunc) kyc.KYCProvider {
+	return &provider{src: src, check: check}
+}
+
+type provider struct {
+	src   TokenSource
+	check CheckFunc
+}
+
+func (p *provider) CheckKYC(ctx context.Context, panDetails kyc.PanDetails) (kyc.KYCStatus, error) {
+	return Do(ctx, p.src, func(ctx context.Context, token string) (kyc.KYCStatus, error) {
+		return p.check(ctx, token, panDetails)
+	})
+}
+
+// CachedSource is a TokenSource that caches the token obtained from fetch,
+// fetching one on first use and again on each needed refresh. Fetches are
+// serialized, and callers wait for a fetch in progress rather than starting
+// their own.
+type CachedSource struct {
+	fetch func(ctx context.Context) (string, error)
+
+	mu    sync.Mutex
+	token string
+}
+
+// NewCachedSource creates a CachedSource that obtains tokens from fetch, such
+// as a login call to the provider's auth endpoint.
+func NewCachedSource(fetch func(ctx context.Context) (string, error)) *CachedSource {
+	return &CachedSource{fetch: fetch}
+}
+
+// Token returns the cached token, fetching one if there is none yet.
+func (s *CachedSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token != "" {
+		return s.token, nil
+	}
+	return s.refreshLocked(ctx)
+}
+
+// Refresh fetches a new token unless stale has already been replaced.
+func (s *CachedSource) Refresh(ctx context.Context, stale string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token != "" && s.token != stale {
+		return s.token, nil
+	}
+	return s.refreshLocked(ctx)
+}
+
+func (s *CachedSource) refreshLocked(ctx context.Context) (string, error) {
+	token, err := s.fetch(ctx)
+	if err != nil {
+		return "", err
+	}
+	s.token = token
+	return token, nil
+}
@@ -0,0 +1,105 @@
+package authretry_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/remiges-tech/await/errclass"
+	"github.com/remiges-tech/await/examples/kyc"
+	"github.com/remiges-tech/await/examples/kyc/authretry"
+)
+
+// tokenServer issues numbered tokens and accepts only the latest one.
+type tokenServer struct {
+	issued atomic.Int32
+}
+
+func (s *tokenServer) login(ctx context.Context) (string, error) {
+	return fmt.Sprintf("token-%d", s.issued.Add(1)), nil
+}
+
+func (s *tokenServer) check(ctx context.Context, token string, pan kyc.PanDetails) (kyc.KYCStatus, error) {
+	if token != fmt.Sprintf("token-%d", s.issued.Load()) {
+		return kyc.KYCStatus{}, fmt.Errorf("NDML: %w", kyc.ErrAuthentication)
+	}
+	return kyc.KYCStatus{Status: "VERIFIED"}, nil
+}
+
+func TestProviderRefreshesExpiredToken(t *testing.T) {
+	ctx := context.Background()
+	server := &tokenServer{}
+	src := authretry.NewCachedSource(server.login)
+	provider := authretry.Provider(src, server.check)
+
+	if _, err := provider.CheckKYC(ctx, kyc.PanDetails{PAN: "AAAAA1111A"}); err != nil {
+		t.Fatalf("Expected first check to succeed, got %v", err)
+	}
+
+	// Rotate credentials behind the cached token's back.
+	_, _ = server.login(ctx)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := provider.CheckKYC(ctx, kyc.PanDetails{PAN: "AAAAA1111A"})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("Expected check to succeed after refresh, got %v", err)
+		}
+	}
+	if n := server.issued.Load(); n != 3 {
+		t.Errorf("Expected concurrent callers to share one refresh (3 tokens issued), got %d", n)
+	}
+}
+
+func TestDoRetriesOnlyOnce(t *testing.T) {
+	ctx := context.Background()
+	src := authretry.NewCachedSource((&tokenServer{}).login)
+
+	calls := 0
+	_, err := authretry.Do(ctx, src, func(ctx context.Context, token string) (int, error) {
+		calls++
+		return 0, kyc.ErrAuthentication
+	})
+	if calls != 2 {
+		t.Errorf("Expected one retry after refresh, got %d calls", calls)
+	}
+	if !errors.Is(err, kyc.ErrAuthentication) || errclass.Classify(err) != errclass.Permanent {
+		t.Errorf("Expected a permanent authentication error, got %v (%v)", err, errclass.Classify(err))
+	}
+}
+
+func TestDoRefreshFailure(t *testing.T) {
+	ctx := context.Background()
+	errTokenService := errors.New("token service unavailable")
+	fetches := 0
+	src := authretry.NewCachedSource(func(ctx context.Context) (string, error) {
+		fetches++
+		if fetches > 1 {
+			return "", fmt.Errorf("%w: %w", kyc.ErrProviderUnavailable, errTokenService)
+		}
+		return "token-1", nil
+	})
+
+	_, err := authretry.Do(ctx, src, func(ctx context.Context, token string) (int, error) {
+		return 0, kyc.ErrAuthentication
+	})
+	if !errors.Is(err, authretry.ErrRefreshFailed) || !errors.Is(err, errTokenService) {
+		t.Fatalf("Expected refresh failure, got %v", err)
+	}
+	if errclass.Classify(err) != errclass.Transient {
+		t.Errorf("Expected a refresh failure to keep the token service's class, got %v", errclass.Classify(err))
+	}
+}
@@ -0,0 +1,86 @@
+package kyc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/remiges-tech/await"
+)
+
+// BatchResult holds the outcome of CheckKYCBatch, keyed by PAN.
+type BatchResult struct {
+	// Winners maps each verified PAN to the provider that decided its check.
+	Winners map[string]string
+
+	// Statuses maps each PAN to the status of every provider tried for it.
+	Statuses map[string]map[string]*ProviderStatus
+
+	// Errors maps each PAN whose check failed to its error.
+	Errors map[string]error
+}
+
+type batchCheck struct {
+	providerName string
+	statuses     map[string]*ProviderStatus
+}
+
+// CheckKYCBatch verifies many PANs concurrently, running each through CheckKYC
+// on a worker pool of CoordinatorConfig.BatchConcurrency workers. Provider
+// requests across the whole batch respect each provider's RateLimit. A PAN
+// listed more than once is checked once.
+//
+// Failed checks are reported in BatchResult.Errors rather than failing the
+// batch, including checks cut short because ctx was cancelled; the returned
+// error is only set when pans is empty.
+func (c *Coordinator) CheckKYCBatch(ctx context.Context, pans []PanDetails) (BatchResult, error) {
+	if len(pans) == 0 {
+		return BatchResult{}, fmt.Errorf("no PANs to check")
+	}
+
+	workers := c.config.BatchConcurrency
+	if workers <= 0 {
+		workers = await.AutoConcurrency(await.TaskKindIO)
+	}
+	pool := await.NewPool[batchCheck](ctx, workers)
+
+	futures := make(map[string]*await.Future[batchCheck], len(pans))
+	for _, pan := range pans {
+		if _, ok := futures[pan.PAN]; ok {
+			continue
+		}
+		panDetails := pan
+		futures[pan.PAN] = pool.Submit(func(ctx context.Context) (batchCheck, error) {
+			_, providerName, statuses, err := c.CheckKYC(ctx, panDetails)
+			return batchCheck{providerName: providerName, statuses: statuses}, err
+		})
+	}
+	pool.Close()
+
+	result := BatchResult{
+		Winners:  make(map[string]string, len(futures)),
+		Statuses: make(map[string]map[string]*ProviderStatus, len(futures)),
+		Errors:   make(map[string]error),
+	}
+	for pan, future := range futures {
+		// Close waits for the workers, which complete every queued future, so
+		// an unfinished one is unexpected; count it as failed, not verified.
+		check, ok := future.Result()
+		if !ok {
+			err := ctx.Err()
+			if err == nil {
+				err = fmt.Errorf("check for %s did not complete", pan)
+			}
+			result.Errors[pan] = err
+			continue
+		}
+		if check.Value.statuses != nil {
+			result.Statuses[pan] = check.Value.statuses
+		}
+		if check.Err != nil {
+			result.Errors[pan] = check.Err
+			continue
+		}
+		result.Winners[pan] = check.Value.providerName
+	}
+	return result, nil
+}
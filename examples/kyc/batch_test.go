@@ -0,0 +1,142 @@
+package kyc_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/remiges-tech/await/examples/kyc"
+)
+
+// batchProvider rejects PANs listed in invalid and records how many checks
+// run at once and when each one started.
+type batchProvider struct {
+	delay   time.Duration
+	invalid map[string]bool
+
+	inFlight    atomic.Int32
+	maxInFlight atomic.Int32
+
+	mu     sync.Mutex
+	starts []time.Time
+}
+
+func (p *batchProvider) CheckKYC(ctx context.Context, panDetails kyc.PanDetails) (kyc.KYCStatus, error) {
+	p.mu.Lock()
+	p.starts = append(p.starts, time.Now())
+	p.mu.Unlock()
+
+	n := p.inFlight.Add(1)
+	defer p.inFlight.Add(-1)
+	for {
+		peak := p.maxInFlight.Load()
+		if n <= peak || p.maxInFlight.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+
+	select {
+	case <-time.After(p.delay):
+	case <-ctx.Done():
+		return kyc.KYCStatus{}, ctx.Err()
+	}
+	if p.invalid[panDetails.PAN] {
+		return kyc.KYCStatus{}, kyc.ErrInvalidPAN
+	}
+	return kyc.KYCStatus{Status: "VERIFIED"}, nil
+}
+
+func TestCheckKYCBatch(t *testing.T) {
+	provider := &batchProvider{delay: 10 * time.Millisecond, invalid: map[string]bool{"BADPAN0000": true}}
+	config := kyc.DefaultCoordinatorConfig()
+	config.BatchConcurrency = 2
+	coordinator := kyc.NewCoordinator(map[string]kyc.KYCProvider{"CAMS": provider}, config)
+
+	pans := []kyc.PanDetails{
+		{PAN: "ABCDE1234F"}, {PAN: "FGHIJ5678K"}, {PAN: "BADPAN0000"},
+		{PAN: "LMNOP9012Q"}, {PAN: "RSTUV3456W"}, {PAN: "ABCDE1234F"},
+	}
+	result, err := coordinator.CheckKYCBatch(context.Background(), pans)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(result.Winners) != 4 {
+		t.Errorf("Expected 4 verified PANs, got %v", result.Winners)
+	}
+	for pan, winner := range result.Winners {
+		if winner != "CAMS" || result.Statuses[pan]["CAMS"] == nil {
+			t.Errorf("Expected CAMS to verify %s with a status, got %q", pan, winner)
+		}
+	}
+	if len(result.Errors) != 1 || !errors.Is(result.Errors["BADPAN0000"], kyc.ErrInvalidPAN) {
+		t.Errorf("Expected ErrInvalidPAN for BADPAN0000 only, got %v", result.Errors)
+	}
+	if n := len(provider.starts); n != 5 {
+		t.Errorf("Expected each distinct PAN checked once, got %d checks", n)
+	}
+	if peak := provider.maxInFlight.Load(); peak > 2 {
+		t.Errorf("Expected at most 2 checks at once, got %d", peak)
+	}
+
+	if _, err := coordinator.CheckKYCBatch(context.Background(), nil); err == nil {
+		t.Error("Expected an error for an empty batch")
+	}
+}
+
+func TestCheckKYCBatchCancelled(t *testing.T) {
+	provider := &batchProvider{delay: 10 * time.Millisecond}
+	coordinator := kyc.NewCoordinator(map[string]kyc.KYCProvider{"CAMS": provider}, kyc.DefaultCoordinatorConfig())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	pans := []kyc.PanDetails{{PAN: "ABCDE1234F"}, {PAN: "FGHIJ5678K"}}
+	result, err := coordinator.CheckKYCBatch(ctx, pans)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result.Winners) != 0 {
+		t.Errorf("Expected no verified PANs, got %v", result.Winners)
+	}
+	for _, pan := range pans {
+		if !errors.Is(result.Errors[pan.PAN], context.Canceled) {
+			t.Errorf("Expected context.Canceled for %s, got %v", pan.PAN, result.Errors[pan.PAN])
+		}
+	}
+}
+
+func TestCheckKYCBatchRateLimit(t *testing.T) {
+	provider := &batchProvider{}
+	config := kyc.DefaultCoordinatorConfig()
+	config.BatchConcurrency = 5
+	config.Providers = map[string]kyc.ProviderConfig{"CAMS": {RateLimit: 50}}
+	coordinator := kyc.NewCoordinator(map[string]kyc.KYCProvider{"CAMS": provider}, config)
+
+	pans := []kyc.PanDetails{
+		{PAN: "ABCDE1234F"}, {PAN: "FGHIJ5678K"}, {PAN: "KLMNO9012P"},
+		{PAN: "QRSTU3456V"}, {PAN: "WXYZA7890B"},
+	}
+	result, err := coordinator.CheckKYCBatch(context.Background(), pans)
+	if err != nil || len(result.Winners) != len(pans) {
+		t.Fatalf("Expected all PANs verified, got %v, %v", result.Errors, err)
+	}
+
+	provider.mu.Lock()
+	defer provider.mu.Unlock()
+	first, last := provider.starts[0], provider.starts[0]
+	for _, start := range provider.starts {
+		if start.Before(first) {
+			first = start
+		}
+		if start.After(last) {
+			last = start
+		}
+	}
+	// Five requests at 50 per second are spaced 20ms apart.
+	if spread := last.Sub(first); spread < 70*time.Millisecond {
+		t.Errorf("Expected requests spread over at least 70ms, got %v", spread)
+	}
+}
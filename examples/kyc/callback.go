@@ -0,0 +1,125 @@
+package kyc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sort"
+	"sync"
+
+	"github.com/remiges-tech/await"
+)
+
+// AsyncKYCProvider is implemented by providers that acknowledge a request
+// immediately and deliver the verification result later via callback.
+type AsyncKYCProvider interface {
+	// SubmitKYC starts verification. The provider must quote callbackToken
+	// when it reports the result, which is then passed to Coordinator.Resolve.
+	SubmitKYC(ctx context.Context, callbackToken string, panDetails PanDetails) error
+}
+
+// CallbackRegistry correlates callback tokens with the checks waiting for them.
+type CallbackRegistry struct {
+	mu      sync.Mutex
+	waiting map[string]chan KYCStatus
+}
+
+// NewCallbackRegistry creates an empty CallbackRegistry.
+func NewCallbackRegistry() *CallbackRegistry {
+	return &CallbackRegistry{waiting: make(map[string]chan KYCStatus)}
+}
+
+// Expect registers token and returns a Future that completes when the token is
+// resolved, or with the context error if ctx is done first. The token is
+// forgotten as soon as the waiter gives up, so a late Resolve fails.
+func (r *CallbackRegistry) Expect(ctx context.Context, token string) *await.Future[KYCStatus] {
+	ch := make(chan KYCStatus, 1)
+
+	r.mu.Lock()
+	r.waiting[token] = ch
+	r.mu.Unlock()
+
+	return await.Go(ctx, func(ctx context.Context) (KYCStatus, error) {
+		select {
+		case status := <-ch:
+			return status, nil
+		case <-ctx.Done():
+		}
+
+		r.mu.Lock()
+		_, waiting := r.waiting[token]
+		delete(r.waiting, token)
+		r.mu.Unlock()
+		if !waiting {
+			// Resolve took the token before we could give up, so its
+			// result has been delivered and must not be lost.
+			return <-ch, nil
+		}
+		return KYCStatus{}, ctx.Err()
+	})
+}
+
+// Resolve delivers the callback result for token.
+// Returns ErrUnknownToken if nothing is waiting for it, including a waiter
+// whose context is done.
+func (r *CallbackRegistry) Resolve(token string, status KYCStatus) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ch, ok := r.waiting[token]
+	if !ok {
+		return ErrUnknownToken
+	}
+	delete(r.waiting, token)
+	ch <- status
+	return nil
+}
+
+// Pending returns the sorted tokens still awaiting a callback.
+func (r *CallbackRegistry) Pending() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tokens := make([]string, 0, len(r.waiting))
+	for token := range r.waiting {
+		tokens = append(tokens, token)
+	}
+	sort.Strings(tokens)
+	return tokens
+}
+
+// asyncProvider adapts an AsyncKYCProvider to KYCProvider by submitting the
+// request and waiting on the registry for the callback.
+type asyncProvider struct {
+	name      string
+	provider  AsyncKYCProvider
+	callbacks *CallbackRegistry
+}
+
+// CheckKYC submits the request and blocks until the callback arrives or ctx is done.
+func (a *asyncProvider) CheckKYC(ctx context.Context, panDetails PanDetails) (KYCStatus, error) {
+	token, err := newCallbackToken(a.name)
+	if err != nil {
+		return KYCStatus{}, err
+	}
+
+	future := a.callbacks.Expect(ctx, token)
+	if err := a.provider.SubmitKYC(ctx, token, panDetails); err != nil {
+		// Release the registration; the Future then completes with the resolved value.
+		a.callbacks.Resolve(token, KYCStatus{})
+		return KYCStatus{}, err
+	}
+	// The Future observes ctx itself, so wait for it to settle rather than racing
+	// it on ctx; by then the token has been released from the registry.
+	<-future.Done()
+	res, _ := future.Result()
+	return res.Value, res.Err
+}
+
+func newCallbackToken(provider string) (string, error) {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return provider + "-" + hex.EncodeToString(b), nil
+}
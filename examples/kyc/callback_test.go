@@ -0,0 +1,111 @@
+package kyc_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/remiges-tech/await/examples/kyc"
+)
+
+// MockAsyncProvider records submitted callback tokens.
+type MockAsyncProvider struct {
+	mu     sync.Mutex
+	tokens []string
+	err    error
+}
+
+func (m *MockAsyncProvider) SubmitKYC(ctx context.Context, callbackToken string, panDetails kyc.PanDetails) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tokens = append(m.tokens, callbackToken)
+	return m.err
+}
+
+func TestCoordinatorAsyncProvider(t *testing.T) {
+	coordinator := kyc.NewCoordinator(map[string]kyc.KYCProvider{}, kyc.DefaultCoordinatorConfig())
+	vendor := &MockAsyncProvider{}
+	coordinator.RegisterAsyncProvider("Webhook", vendor)
+
+	go func() {
+		for {
+			if pending := coordinator.PendingCallbacks(); len(pending) > 0 {
+				if err := coordinator.Resolve(pending[0], kyc.KYCStatus{Status: "VERIFIED"}); err != nil {
+					t.Errorf("Unexpected resolve error: %v", err)
+				}
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}()
+
+	status, providerName, _, err := coordinator.CheckKYC(context.Background(), kyc.PanDetails{PAN: "ASYNC0001"})
+	if err != nil {
+		t.Fatalf("Expected success, got error: %v", err)
+	}
+	if providerName != "Webhook" || status.KYCResponse.State != kyc.StateVerified {
+		t.Errorf("Expected Webhook to verify, got %s/%s", providerName, status.KYCResponse.State)
+	}
+	if len(coordinator.PendingCallbacks()) != 0 {
+		t.Errorf("Expected no pending callbacks after resolution")
+	}
+}
+
+func TestCoordinatorAsyncProviderTimeout(t *testing.T) {
+	config := kyc.DefaultCoordinatorConfig()
+	config.MaxRetries = 1
+	config.RequestTimeout = 20 * time.Millisecond
+
+	coordinator := kyc.NewCoordinator(map[string]kyc.KYCProvider{}, config)
+	coordinator.RegisterAsyncProvider("Silent", &MockAsyncProvider{})
+
+	_, _, _, err := coordinator.CheckKYC(context.Background(), kyc.PanDetails{PAN: "ASYNC0002"})
+	if !errors.Is(err, kyc.ErrTimeout) {
+		t.Fatalf("Expected ErrTimeout when callback never arrives, got %v", err)
+	}
+	if len(coordinator.PendingCallbacks()) != 0 {
+		t.Errorf("Expected expired callbacks to be forgotten")
+	}
+}
+
+func TestCallbackRegistry(t *testing.T) {
+	registry := kyc.NewCallbackRegistry()
+
+	if err := registry.Resolve("missing", kyc.KYCStatus{}); !errors.Is(err, kyc.ErrUnknownToken) {
+		t.Errorf("Expected ErrUnknownToken, got %v", err)
+	}
+
+	future := registry.Expect(context.Background(), "tok-1")
+	if err := registry.Resolve("tok-1", kyc.KYCStatus{Status: "REJECTED"}); err != nil {
+		t.Fatalf("Unexpected resolve error: %v", err)
+	}
+
+	status, err := future.Await(context.Background())
+	if err != nil || status.Status != "REJECTED" {
+		t.Errorf("Expected REJECTED status, got %v (err: %v)", status, err)
+	}
+
+	t.Run("resolve after the waiter gives up", func(t *testing.T) {
+		for i := 0; i < 200; i++ {
+			ctx, cancel := context.WithCancel(context.Background())
+			future := registry.Expect(ctx, "tok-2")
+			cancel()
+			resolveErr := registry.Resolve("tok-2", kyc.KYCStatus{Status: "VERIFIED"})
+
+			status, err := future.Await(context.Background())
+			switch {
+			case resolveErr == nil && (err != nil || status.Status != "VERIFIED"):
+				t.Fatalf("Expected an accepted callback to be delivered, got %v (err: %v)", status, err)
+			case resolveErr != nil && !errors.Is(resolveErr, kyc.ErrUnknownToken):
+				t.Fatalf("Expected ErrUnknownToken, got %v", resolveErr)
+			case resolveErr != nil && !errors.Is(err, context.Canceled):
+				t.Fatalf("Expected context.Canceled for a refused callback, got %v", err)
+			}
+			if err := registry.Resolve("tok-2", kyc.KYCStatus{}); !errors.Is(err, kyc.ErrUnknownToken) {
+				t.Fatalf("Expected ErrUnknownToken once the waiter has gone, got %v", err)
+			}
+		}
+	})
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/remiges-tech/await/examples/kyc"
+	"github.com/remiges-tech/await/examples/kyc/kyctest"
+	"github.com/remiges-tech/await/examples/kyc/providers"
+	"github.com/remiges-tech/await/retry"
+)
+
+// This demo runs the coordinator against local mock KRA servers over HTTP:
+// CAMS is slow but reliable, CVL is flaky, NDML is down and KARVY stalls on
+// its first request. Watch NDML's circuit open after a few checks.
+func main() {
+	unavailable := kyctest.Reply{StatusCode: http.StatusServiceUnavailable}
+	servers := map[string]*kyctest.Server{
+		"CAMS":  kyctest.NewServer(kyctest.Reply{Delay: 150 * time.Millisecond}),
+		"CVL":   kyctest.NewServer(unavailable, kyctest.Reply{Delay: 100 * time.Millisecond}, unavailable),
+		"NDML":  kyctest.NewServer(unavailable),
+		"KARVY": kyctest.NewServer(kyctest.Reply{Delay: 5 * time.Second}, kyctest.Reply{Delay: 100 * time.Millisecond}),
+	}
+
+	httpConfig := providers.HTTPConfig{
+		Retry: retry.Options{
+			MaxAttempts:    3,
+			Strategy:       &retry.ConstantDelay{Delay: 10 * time.Millisecond},
+			AttemptTimeout: time.Second,
+		},
+		Hedge: 2,
+	}
+	kycProviders := make(map[string]kyc.KYCProvider, len(servers))
+	for name, server := range servers {
+		defer server.Close()
+		kycProviders[name] = providers.NewHTTPProvider(name, server.URL, httpConfig)
+	}
+
+	config := kyc.DefaultCoordinatorConfig()
+	config.MaxRetries = 1 // the HTTP providers retry themselves
+	config.Health = kyc.HealthConfig{Window: 10, MinSamples: 3, FailureThreshold: 0.5, Cooldown: time.Minute}
+	coordinator := kyc.NewCoordinator(kycProviders, config)
+
+	for i := 0; i < 5; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		_, provider, _, err := coordinator.CheckKYC(ctx, kyc.PanDetails{PAN: "AAAAA1111A"})
+		cancel()
+		if err != nil {
+			log.Printf("check %d: KYC failed: %v", i+1, err)
+		} else {
+			log.Printf("check %d: KYC verified by %s", i+1, provider)
+		}
+	}
+
+	for name, health := range coordinator.Health() {
+		log.Printf("%s: success rate %.0f%%, circuit open: %v, requests: %d",
+			name, health.SuccessRate*100, health.CircuitOpen, servers[name].Requests())
+	}
+}
@@ -5,18 +5,148 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/remiges-tech/await"
+	"github.com/remiges-tech/await/errclass"
 	"github.com/remiges-tech/await/retry"
 )
 
+// VerificationMode selects how provider results are aggregated into a KYC decision.
+type VerificationMode int
+
+const (
+	// ModeFirstSuccess runs all providers concurrently and accepts the first success.
+	ModeFirstSuccess VerificationMode = iota
+
+	// ModeConsensus runs all providers concurrently and succeeds once ConsensusN
+	// providers report the same canonical KYCState.
+	ModeConsensus
+
+	// ModeAll waits for every provider to finish and reports the fastest success.
+	ModeAll
+
+	// ModeSequential tries providers one at a time, cheapest first, until one succeeds.
+	ModeSequential
+
+	// ModeCostAware tries providers cheapest first within CoordinatorConfig.Budget,
+	// starting the next one when the previous fails or exceeds the budget's
+	// LatencyThreshold.
+	ModeCostAware
+)
+
+// String returns the mode name.
+func (m VerificationMode) String() string {
+	switch m {
+	case ModeFirstSuccess:
+		return "first-success"
+	case ModeConsensus:
+		return "consensus"
+	case ModeAll:
+		return "all"
+	case ModeSequential:
+		return "sequential"
+	case ModeCostAware:
+		return "cost-aware"
+	default:
+		return fmt.Sprintf("VerificationMode(%d)", int(m))
+	}
+}
+
 // CoordinatorConfig holds configuration for the KYC coordinator.
 type CoordinatorConfig struct {
 	MaxRetries     int
 	RetryBackoff   time.Duration
 	RequestTimeout time.Duration
+
+	// Mode selects how provider results are aggregated. Defaults to ModeFirstSuccess.
+	Mode VerificationMode
+
+	// ConsensusN is the number of agreeing providers required in ModeConsensus.
+	// Values below 1 are treated as 1.
+	ConsensusN int
+
+	// Providers holds per-provider overrides keyed by provider name.
+	Providers map[string]ProviderConfig
+
+	// Health controls provider health tracking and circuit breaking.
+	Health HealthConfig
+
+	// Budget limits provider spend in ModeCostAware.
+	Budget BudgetConfig
+
+	// Audit, if set, receives a record of every provider attempt, including
+	// retries. Sink errors are logged and do not fail the check.
+	Audit AuditSink
+
+	// BatchConcurrency caps how many PANs CheckKYCBatch checks at once.
+	// Zero uses await.AutoConcurrency(await.TaskKindIO).
+	BatchConcurrency int
+}
+
+// BudgetConfig controls which providers ModeCostAware may call and when.
+type BudgetConfig struct {
+	// MaxCost caps the summed ProviderConfig.Cost of the providers a single
+	// check may call. Providers are admitted cheapest first; zero means no cap.
+	MaxCost int
+
+	// LatencyThreshold is how long a provider may run before the next cheapest
+	// one is started alongside it. Zero waits for each provider to fail first.
+	LatencyThreshold time.Duration
+}
+
+// ProviderConfig overrides coordinator settings for a single provider.
+// Zero values inherit the coordinator-wide setting.
+type ProviderConfig struct {
+	MaxRetries     int
+	RetryBackoff   time.Duration
+	RequestTimeout time.Duration
+
+	// Mapper normalizes this provider's raw responses.
+	// Defaults to DefaultResponseMapper.
+	Mapper ResponseMapper
+
+	// Cost orders providers in ModeSequential and ModeCostAware; cheaper
+	// providers are tried first. ModeCostAware also charges it against the Budget.
+	Cost int
+
+	// Disabled excludes the provider from KYC checks.
+	Disabled bool
+
+	// RateLimit caps requests to the provider per second, across all checks
+	// and retries the coordinator runs. Zero means no limit.
+	RateLimit float64
+}
+
+// ForProvider returns the effective settings for the named provider,
+// applying any override on top of the coordinator-wide values.
+func (c CoordinatorConfig) ForProvider(name string) ProviderConfig {
+	resolved := ProviderConfig{
+		MaxRetries:     c.MaxRetries,
+		RetryBackoff:   c.RetryBackoff,
+		RequestTimeout: c.RequestTimeout,
+	}
+
+	override, ok := c.Providers[name]
+	if !ok {
+		return resolved
+	}
+	if override.MaxRetries > 0 {
+		resolved.MaxRetries = override.MaxRetries
+	}
+	if override.RetryBackoff > 0 {
+		resolved.RetryBackoff = override.RetryBackoff
+	}
+	if override.RequestTimeout > 0 {
+		resolved.RequestTimeout = override.RequestTimeout
+	}
+	resolved.Mapper = override.Mapper
+	resolved.Cost = override.Cost
+	resolved.Disabled = override.Disabled
+	resolved.RateLimit = override.RateLimit
+	return resolved
 }
 
 // DefaultCoordinatorConfig returns default configuration.
@@ -25,6 +155,7 @@ func DefaultCoordinatorConfig() CoordinatorConfig {
 		MaxRetries:     3,
 		RetryBackoff:   2 * time.Second,
 		RequestTimeout: 30 * time.Second,
+		Health:         DefaultHealthConfig(),
 	}
 }
 
@@ -32,18 +163,63 @@ func DefaultCoordinatorConfig() CoordinatorConfig {
 type Coordinator struct {
 	providers map[string]KYCProvider
 	config    CoordinatorConfig
+	health    *HealthTracker
+	callbacks *CallbackRegistry
+	limiters  map[string]*rateLimiter
 }
 
 // NewCoordinator creates a new KYC coordinator.
 func NewCoordinator(providers map[string]KYCProvider, config CoordinatorConfig) *Coordinator {
+	registered := make(map[string]KYCProvider, len(providers))
+	for name, p := range providers {
+		registered[name] = p
+	}
+	limiters := make(map[string]*rateLimiter)
+	for name, pc := range config.Providers {
+		if pc.RateLimit > 0 {
+			limiters[name] = newRateLimiter(pc.RateLimit)
+		}
+	}
 	return &Coordinator{
-		providers: providers,
+		providers: registered,
 		config:    config,
+		health:    NewHealthTracker(config.Health),
+		callbacks: NewCallbackRegistry(),
+		limiters:  limiters,
+	}
+}
+
+// RegisterAsyncProvider adds a callback-based provider. Its checks wait for
+// Resolve to be called with the token passed to SubmitKYC.
+// Must be called before CheckKYC is used concurrently.
+func (c *Coordinator) RegisterAsyncProvider(name string, provider AsyncKYCProvider) {
+	c.providers[name] = &asyncProvider{
+		name:      name,
+		provider:  provider,
+		callbacks: c.callbacks,
 	}
 }
 
-// CheckKYC runs KYC checks and returns as soon as one provider succeeds.
-// It also returns a map of all provider statuses for monitoring.
+// Resolve delivers an asynchronous provider's result, typically from a webhook handler.
+// Returns ErrUnknownToken if no check is waiting for the token.
+func (c *Coordinator) Resolve(token string, status KYCStatus) error {
+	return c.callbacks.Resolve(token, status)
+}
+
+// PendingCallbacks returns the tokens of asynchronous checks still awaiting Resolve.
+func (c *Coordinator) PendingCallbacks() []string {
+	return c.callbacks.Pending()
+}
+
+// Health returns a snapshot of each provider's rolling success rate, latency and circuit state.
+func (c *Coordinator) Health() map[string]ProviderHealth {
+	return c.health.Health()
+}
+
+// CheckKYC runs KYC checks according to the configured VerificationMode and returns
+// the deciding provider's status and name. In the default ModeFirstSuccess it returns
+// as soon as one provider succeeds. It also returns a map of all provider statuses
+// for monitoring.
 func (c *Coordinator) CheckKYC(ctx context.Context, panDetails PanDetails) (*ProviderStatus, string, map[string]*ProviderStatus, error) {
 	if len(c.providers) == 0 {
 		return nil, "", nil, fmt.Errorf("no providers configured")
@@ -52,19 +228,32 @@ func (c *Coordinator) CheckKYC(ctx context.Context, panDetails PanDetails) (*Pro
 	tracking := make(map[string]*ProviderStatus)
 	trackingMu := sync.Mutex{}
 
-	type providerResult struct {
-		status       *ProviderStatus
-		providerName string
+	var candidates []candidate
+	circuitOpen := 0
+	var checkID string
+	if c.config.Audit != nil {
+		checkID = newCheckID()
 	}
 
-	tasks := make([]await.Task[providerResult], 0, len(c.providers))
-
 	for providerName, provider := range c.providers {
 		name := providerName
 		prov := provider
+		provConfig := c.config.ForProvider(name)
+		if provConfig.Disabled {
+			continue
+		}
+		if !c.health.Allow(name) {
+			circuitOpen++
+			continue
+		}
 
 		task := func(ctx context.Context) (providerResult, error) {
 			startTime := time.Now()
+			if provConfig.RequestTimeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, provConfig.RequestTimeout)
+				defer cancel()
+			}
 			status := &ProviderStatus{
 				Provider: prov,
 				Status:   "pending",
@@ -74,14 +263,31 @@ func (c *Coordinator) CheckKYC(ctx context.Context, panDetails PanDetails) (*Pro
 			tracking[name] = status
 			trackingMu.Unlock()
 
+			limiter := c.limiters[name]
+			attempt := 0
 			checkKYC := func(ctx context.Context) (KYCStatus, error) {
-				return prov.CheckKYC(panDetails)
+				if limiter != nil {
+					if err := limiter.Wait(ctx); err != nil {
+						return KYCStatus{}, err
+					}
+				}
+				attempt++
+				attemptStart := time.Now()
+				raw, err := prov.CheckKYC(ctx, panDetails)
+				var response KYCStatus
+				if err == nil {
+					response, err = normalize(raw, provConfig.Mapper)
+				}
+				if c.config.Audit != nil {
+					c.audit(newAuditRecord(checkID, panDetails.PAN, name, attempt, attemptStart, response, err))
+				}
+				return response, err
 			}
 
 			retryOpts := retry.Options{
-				MaxAttempts: c.config.MaxRetries,
+				MaxAttempts: provConfig.MaxRetries,
 				Strategy: &retry.ConstantDelay{
-					Delay: c.config.RetryBackoff,
+					Delay: provConfig.RetryBackoff,
 				},
 				OnRetry: func(attempt int, err error) {
 					trackingMu.Lock()
@@ -94,9 +300,17 @@ func (c *Coordinator) CheckKYC(ctx context.Context, panDetails PanDetails) (*Pro
 			}
 
 			response, err := retry.Do(ctx, checkKYC, retryOpts)
+			if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) && provConfig.RequestTimeout > 0 {
+				err = fmt.Errorf("%w after %v: %w", ErrTimeout, provConfig.RequestTimeout, err)
+			}
+
+			elapsed := time.Since(startTime)
+			if countsAgainstProvider(err) {
+				c.health.Record(name, err == nil, elapsed)
+			}
 
 			trackingMu.Lock()
-			status.TotalTime = time.Since(startTime)
+			status.TotalTime = elapsed
 			if err != nil {
 				status.Status = "failed"
 				status.Error = err
@@ -113,10 +327,30 @@ func (c *Coordinator) CheckKYC(ctx context.Context, panDetails PanDetails) (*Pro
 			}, nil
 		}
 
-		tasks = append(tasks, task)
+		candidates = append(candidates, candidate{name: name, cost: provConfig.Cost, task: task})
 	}
 
-	result, err := await.Any(ctx, tasks...)
+	if len(candidates) == 0 {
+		if circuitOpen > 0 {
+			return nil, "", nil, fmt.Errorf("all enabled providers skipped by open circuit: %w", ErrProviderUnavailable)
+		}
+		return nil, "", nil, fmt.Errorf("no providers enabled")
+	}
+
+	var result providerResult
+	var err error
+	switch c.config.Mode {
+	case ModeConsensus:
+		result, err = runConsensus(ctx, candidates, c.config.ConsensusN)
+	case ModeAll:
+		result, err = runAll(ctx, candidates)
+	case ModeSequential:
+		result, err = runSequential(ctx, candidates)
+	case ModeCostAware:
+		result, err = runCostAware(ctx, candidates, c.config.Budget)
+	default:
+		result, err = await.Any(ctx, candidateTasks(candidates)...)
+	}
 	if err != nil {
 		return nil, "", tracking, fmt.Errorf("all providers failed: %w", err)
 	}
@@ -124,21 +358,163 @@ func (c *Coordinator) CheckKYC(ctx context.Context, panDetails PanDetails) (*Pro
 	return result.status, result.providerName, tracking, nil
 }
 
-// IsRetryable determines if an error should trigger a retry.
-func IsRetryable(err error) bool {
-	switch {
-	case errors.Is(err, ErrAuthentication):
-		return false
-	case errors.Is(err, ErrInvalidPAN):
-		return false
-	case errors.Is(err, ErrInvalidResponse):
-		return false
+// audit passes rec to the configured AuditSink.
+func (c *Coordinator) audit(rec AuditRecord) {
+	if err := c.config.Audit.Audit(rec); err != nil {
+		log.Printf("%s: audit record for attempt %d not written: %v", rec.Provider, rec.Attempt, err)
 	}
+}
 
-	var provErr *ProviderError
-	if errors.As(err, &provErr) && len(provErr.Code) > 0 && provErr.Code[0] == '4' {
-		return false
+type providerResult struct {
+	status       *ProviderStatus
+	providerName string
+}
+
+// candidate is an enabled provider's check, ready to be scheduled by a VerificationMode.
+type candidate struct {
+	name string
+	cost int
+	task await.Task[providerResult]
+}
+
+func candidateTasks(candidates []candidate) []await.Task[providerResult] {
+	tasks := make([]await.Task[providerResult], len(candidates))
+	for i, cand := range candidates {
+		tasks[i] = cand.task
+	}
+	return tasks
+}
+
+// runAll waits for every provider and returns the success with the lowest TotalTime.
+func runAll(ctx context.Context, candidates []candidate) (providerResult, error) {
+	results, err := await.All(ctx, candidateTasks(candidates)...)
+	if err != nil {
+		return providerResult{}, err
+	}
+
+	var best providerResult
+	var errs []error
+	for _, res := range results {
+		if res.Err != nil {
+			errs = append(errs, res.Err)
+			continue
+		}
+		if best.status == nil || res.Value.status.TotalTime < best.status.TotalTime {
+			best = res.Value
+		}
+	}
+	if best.status == nil {
+		return providerResult{}, &await.AggregateError{Errors: errs}
+	}
+	return best, nil
+}
+
+// byCost returns candidates in ascending cost order, breaking ties by name.
+func byCost(candidates []candidate) []candidate {
+	ordered := append([]candidate(nil), candidates...)
+	sort.Slice(ordered, func(i, j int) bool {
+		if ordered[i].cost != ordered[j].cost {
+			return ordered[i].cost < ordered[j].cost
+		}
+		return ordered[i].name < ordered[j].name
+	})
+	return ordered
+}
+
+// runSequential tries providers one at a time in ascending cost order.
+func runSequential(ctx context.Context, candidates []candidate) (providerResult, error) {
+	ordered := byCost(candidates)
+
+	errs := make([]error, 0, len(ordered))
+	for _, cand := range ordered {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+		result, err := cand.task(ctx)
+		if err == nil {
+			return result, nil
+		}
+		errs = append(errs, err)
+	}
+	return providerResult{}, &await.AggregateError{Errors: errs}
+}
+
+// runCostAware admits providers cheapest first until the budget's MaxCost is
+// reached, then runs them with await.Any, staggered by LatencyThreshold or
+// one at a time when it is zero. Providers never started cost nothing.
+func runCostAware(ctx context.Context, candidates []candidate, budget BudgetConfig) (providerResult, error) {
+	ordered := byCost(candidates)
+	if budget.MaxCost > 0 {
+		cheapest := ordered[0]
+		spent := 0
+		for i, cand := range ordered {
+			if spent+cand.cost > budget.MaxCost {
+				ordered = ordered[:i]
+				break
+			}
+			spent += cand.cost
+		}
+		if len(ordered) == 0 {
+			return providerResult{}, fmt.Errorf("%w: cheapest provider %s costs %d, budget is %d",
+				ErrBudgetExceeded, cheapest.name, cheapest.cost, budget.MaxCost)
+		}
+	}
+
+	opts := await.Options{
+		Sequential:   budget.LatencyThreshold <= 0,
+		StaggerDelay: budget.LatencyThreshold,
+	}
+	return await.AnyWithOptions(ctx, opts, candidateTasks(ordered)...)
+}
+
+// runConsensus runs all providers and returns once n of them report the same
+// canonical KYC state, cancelling the rest.
+func runConsensus(ctx context.Context, candidates []candidate, n int) (providerResult, error) {
+	if n < 1 {
+		n = 1
+	}
+	if n > len(candidates) {
+		return providerResult{}, fmt.Errorf("%w: need %d agreeing providers, only %d enabled", ErrNoConsensus, n, len(candidates))
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	votes := make(map[KYCState]int)
+	var errs []error
+	for res := range await.ResultsChannel(ctx, candidateTasks(candidates)...) {
+		if res.Err != nil {
+			errs = append(errs, res.Err)
+			continue
+		}
+		verdict := res.Value.status.KYCResponse.State
+		votes[verdict]++
+		if votes[verdict] >= n {
+			return res.Value, nil
+		}
 	}
 
+	errs = append(errs, fmt.Errorf("%w: no state reported by %d providers (votes: %v)", ErrNoConsensus, n, votes))
+	return providerResult{}, &await.AggregateError{Errors: errs}
+}
+
+// countsAgainstProvider reports whether an outcome says something about the
+// provider's health. Cancellations and permanent errors caused by the request,
+// such as an invalid PAN, do not; rejected credentials do, since every later
+// request to the provider will fail the same way.
+func countsAgainstProvider(err error) bool {
+	switch errclass.Classify(err) {
+	case errclass.Cancelled:
+		return false
+	case errclass.Permanent:
+		return errors.Is(err, ErrAuthentication)
+	}
 	return true
 }
+
+// IsRetryable determines if an error should trigger a retry: anything the
+// errclass taxonomy does not classify as Permanent.
+func IsRetryable(err error) bool {
+	return errclass.Classify(err) != errclass.Permanent
+}
@@ -2,6 +2,7 @@ package kyc_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
@@ -17,21 +18,30 @@ type MockProvider struct {
 	failCount    int
 	attemptCount int
 	delay        time.Duration
+	status       string
 }
 
-func (m *MockProvider) CheckKYC(panDetails kyc.PanDetails) (kyc.KYCStatus, error) {
+func (m *MockProvider) CheckKYC(ctx context.Context, panDetails kyc.PanDetails) (kyc.KYCStatus, error) {
 	m.attemptCount++
 
 	if m.delay > 0 {
-		time.Sleep(m.delay)
+		select {
+		case <-time.After(m.delay):
+		case <-ctx.Done():
+			return kyc.KYCStatus{}, ctx.Err()
+		}
 	}
 
 	if m.shouldFail && m.attemptCount <= m.failCount {
 		return kyc.KYCStatus{}, fmt.Errorf("mock provider %s failed on attempt %d", m.name, m.attemptCount)
 	}
 
+	status := m.status
+	if status == "" {
+		status = "VERIFIED"
+	}
 	return kyc.KYCStatus{
-		Status:    "VERIFIED",
+		Status:    status,
 		OtherInfo: nil,
 	}, nil
 }
@@ -229,3 +239,256 @@ func TestCoordinatorTimeout(t *testing.T) {
 func contains(s, substr string) bool {
 	return strings.Contains(s, substr)
 }
+
+func TestCoordinatorProviderOverrides(t *testing.T) {
+	providers := map[string]kyc.KYCProvider{
+		"Flaky":    &MockProvider{name: "Flaky", shouldFail: true, failCount: 3, delay: 5 * time.Millisecond},
+		"Disabled": &MockProvider{name: "Disabled", delay: 1 * time.Millisecond},
+	}
+
+	config := kyc.CoordinatorConfig{
+		MaxRetries:     1,
+		RetryBackoff:   10 * time.Millisecond,
+		RequestTimeout: 1 * time.Second,
+		Providers: map[string]kyc.ProviderConfig{
+			"Flaky":    {MaxRetries: 4, RetryBackoff: 1 * time.Millisecond},
+			"Disabled": {Disabled: true},
+		},
+	}
+	coordinator := kyc.NewCoordinator(providers, config)
+
+	_, providerName, allStatuses, err := coordinator.CheckKYC(context.Background(), kyc.PanDetails{PAN: "OVERRIDE1"})
+	if err != nil {
+		t.Fatalf("Expected success, got error: %v", err)
+	}
+
+	if providerName != "Flaky" {
+		t.Errorf("Expected Flaky to win with its own retry budget, got %s", providerName)
+	}
+
+	if _, ok := allStatuses["Disabled"]; ok {
+		t.Errorf("Expected disabled provider to be skipped")
+	}
+
+	if attempts := providers["Disabled"].(*MockProvider).attemptCount; attempts != 0 {
+		t.Errorf("Expected no calls to disabled provider, got %d", attempts)
+	}
+}
+
+func TestCoordinatorConfigForProvider(t *testing.T) {
+	config := kyc.CoordinatorConfig{
+		MaxRetries:     3,
+		RetryBackoff:   2 * time.Second,
+		RequestTimeout: 30 * time.Second,
+		Providers: map[string]kyc.ProviderConfig{
+			"CAMS": {RequestTimeout: 5 * time.Second},
+		},
+	}
+
+	cams := config.ForProvider("CAMS")
+	if cams.RequestTimeout != 5*time.Second || cams.MaxRetries != 3 || cams.RetryBackoff != 2*time.Second {
+		t.Errorf("Expected CAMS override merged with defaults, got %+v", cams)
+	}
+
+	ndml := config.ForProvider("NDML")
+	if ndml.RequestTimeout != 30*time.Second || ndml.Disabled {
+		t.Errorf("Expected NDML to inherit defaults, got %+v", ndml)
+	}
+}
+
+// LegacyMockProvider implements the pre-context provider interface.
+type LegacyMockProvider struct {
+	delay time.Duration
+}
+
+func (l *LegacyMockProvider) CheckKYC(panDetails kyc.PanDetails) (kyc.KYCStatus, error) {
+	time.Sleep(l.delay)
+	return kyc.KYCStatus{Status: "VERIFIED"}, nil
+}
+
+func TestAdaptLegacyProvider(t *testing.T) {
+	providers := map[string]kyc.KYCProvider{
+		"Legacy": kyc.AdaptLegacyProvider(&LegacyMockProvider{delay: 5 * time.Millisecond}),
+	}
+	coordinator := kyc.NewCoordinator(providers, kyc.DefaultCoordinatorConfig())
+
+	status, providerName, _, err := coordinator.CheckKYC(context.Background(), kyc.PanDetails{PAN: "LEGACY001"})
+	if err != nil {
+		t.Fatalf("Expected success, got error: %v", err)
+	}
+	if providerName != "Legacy" || status.KYCResponse.Status != "VERIFIED" {
+		t.Errorf("Expected Legacy provider to verify, got %s/%s", providerName, status.KYCResponse.Status)
+	}
+
+	slow := kyc.AdaptLegacyProvider(&LegacyMockProvider{delay: time.Second})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := slow.CheckKYC(ctx, kyc.PanDetails{PAN: "LEGACY002"}); err != context.DeadlineExceeded {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Expected adapter to return on context expiry, took %v", elapsed)
+	}
+}
+
+func TestCoordinatorModes(t *testing.T) {
+	panDetails := kyc.PanDetails{PAN: "MODES1234"}
+
+	t.Run("consensus", func(t *testing.T) {
+		providers := map[string]kyc.KYCProvider{
+			"Dissent": &MockProvider{name: "Dissent", delay: 1 * time.Millisecond, status: "REJECTED"},
+			"Agree1":  &MockProvider{name: "Agree1", delay: 10 * time.Millisecond},
+			"Agree2":  &MockProvider{name: "Agree2", delay: 20 * time.Millisecond},
+		}
+
+		config := kyc.DefaultCoordinatorConfig()
+		config.Mode = kyc.ModeConsensus
+		config.ConsensusN = 2
+
+		status, providerName, _, err := kyc.NewCoordinator(providers, config).CheckKYC(context.Background(), panDetails)
+		if err != nil {
+			t.Fatalf("Expected consensus, got error: %v", err)
+		}
+		if status.KYCResponse.Status != "VERIFIED" || providerName != "Agree2" {
+			t.Errorf("Expected VERIFIED consensus completed by Agree2, got %s by %s", status.KYCResponse.Status, providerName)
+		}
+	})
+
+	t.Run("consensus not reached", func(t *testing.T) {
+		providers := map[string]kyc.KYCProvider{
+			"Yes": &MockProvider{name: "Yes"},
+			"No":  &MockProvider{name: "No", status: "REJECTED"},
+		}
+
+		config := kyc.DefaultCoordinatorConfig()
+		config.Mode = kyc.ModeConsensus
+		config.ConsensusN = 2
+
+		_, _, _, err := kyc.NewCoordinator(providers, config).CheckKYC(context.Background(), panDetails)
+		if !errors.Is(err, kyc.ErrNoConsensus) {
+			t.Fatalf("Expected ErrNoConsensus, got %v", err)
+		}
+	})
+
+	t.Run("all", func(t *testing.T) {
+		providers := map[string]kyc.KYCProvider{
+			"Fast":   &MockProvider{name: "Fast", delay: 1 * time.Millisecond},
+			"Slow":   &MockProvider{name: "Slow", delay: 30 * time.Millisecond},
+			"Broken": &MockProvider{name: "Broken", shouldFail: true, failCount: 10},
+		}
+
+		config := kyc.DefaultCoordinatorConfig()
+		config.Mode = kyc.ModeAll
+		config.MaxRetries = 1
+
+		_, providerName, allStatuses, err := kyc.NewCoordinator(providers, config).CheckKYC(context.Background(), panDetails)
+		if err != nil {
+			t.Fatalf("Expected success, got error: %v", err)
+		}
+		if providerName != "Fast" {
+			t.Errorf("Expected Fast to be reported, got %s", providerName)
+		}
+		if allStatuses["Slow"].Status != "success" || allStatuses["Broken"].Status != "failed" {
+			t.Errorf("Expected every provider to finish, got Slow=%s Broken=%s", allStatuses["Slow"].Status, allStatuses["Broken"].Status)
+		}
+	})
+
+	t.Run("sequential", func(t *testing.T) {
+		providers := map[string]kyc.KYCProvider{
+			"Cheap":     &MockProvider{name: "Cheap", shouldFail: true, failCount: 10},
+			"Mid":       &MockProvider{name: "Mid"},
+			"Expensive": &MockProvider{name: "Expensive"},
+		}
+
+		config := kyc.DefaultCoordinatorConfig()
+		config.Mode = kyc.ModeSequential
+		config.MaxRetries = 1
+		config.Providers = map[string]kyc.ProviderConfig{
+			"Cheap":     {Cost: 1},
+			"Mid":       {Cost: 5},
+			"Expensive": {Cost: 10},
+		}
+
+		_, providerName, _, err := kyc.NewCoordinator(providers, config).CheckKYC(context.Background(), panDetails)
+		if err != nil {
+			t.Fatalf("Expected success, got error: %v", err)
+		}
+		if providerName != "Mid" {
+			t.Errorf("Expected Mid to verify after Cheap failed, got %s", providerName)
+		}
+		if calls := providers["Expensive"].(*MockProvider).attemptCount; calls != 0 {
+			t.Errorf("Expected Expensive not to be called, got %d calls", calls)
+		}
+	})
+	t.Run("cost aware", func(t *testing.T) {
+		costs := map[string]kyc.ProviderConfig{
+			"Cheap":     {Cost: 1},
+			"Mid":       {Cost: 5},
+			"Expensive": {Cost: 10},
+		}
+
+		t.Run("slow cheap provider", func(t *testing.T) {
+			providers := map[string]kyc.KYCProvider{
+				"Cheap":     &MockProvider{name: "Cheap", delay: time.Second},
+				"Mid":       &MockProvider{name: "Mid"},
+				"Expensive": &MockProvider{name: "Expensive"},
+			}
+
+			config := kyc.DefaultCoordinatorConfig()
+			config.Mode = kyc.ModeCostAware
+			config.Providers = costs
+			config.Budget = kyc.BudgetConfig{LatencyThreshold: 10 * time.Millisecond}
+
+			_, providerName, _, err := kyc.NewCoordinator(providers, config).CheckKYC(context.Background(), panDetails)
+			if err != nil {
+				t.Fatalf("Expected success, got error: %v", err)
+			}
+			if providerName != "Mid" {
+				t.Errorf("Expected Mid to take over from slow Cheap, got %s", providerName)
+			}
+			if calls := providers["Expensive"].(*MockProvider).attemptCount; calls != 0 {
+				t.Errorf("Expected Expensive not to be called, got %d calls", calls)
+			}
+		})
+
+		t.Run("budget excludes expensive provider", func(t *testing.T) {
+			providers := map[string]kyc.KYCProvider{
+				"Cheap":     &MockProvider{name: "Cheap", shouldFail: true, failCount: 10},
+				"Mid":       &MockProvider{name: "Mid", shouldFail: true, failCount: 10},
+				"Expensive": &MockProvider{name: "Expensive"},
+			}
+
+			config := kyc.DefaultCoordinatorConfig()
+			config.Mode = kyc.ModeCostAware
+			config.MaxRetries = 1
+			config.Providers = costs
+			config.Budget = kyc.BudgetConfig{MaxCost: 10}
+
+			_, _, allStatuses, err := kyc.NewCoordinator(providers, config).CheckKYC(context.Background(), panDetails)
+			if err == nil {
+				t.Fatal("Expected failure when only over-budget providers could succeed")
+			}
+			if _, called := allStatuses["Expensive"]; called {
+				t.Error("Expected Expensive to be excluded by the budget")
+			}
+		})
+
+		t.Run("cheapest over budget", func(t *testing.T) {
+			providers := map[string]kyc.KYCProvider{
+				"Expensive": &MockProvider{name: "Expensive"},
+			}
+
+			config := kyc.DefaultCoordinatorConfig()
+			config.Mode = kyc.ModeCostAware
+			config.Providers = costs
+			config.Budget = kyc.BudgetConfig{MaxCost: 5}
+
+			_, _, _, err := kyc.NewCoordinator(providers, config).CheckKYC(context.Background(), panDetails)
+			if !errors.Is(err, kyc.ErrBudgetExceeded) {
+				t.Fatalf("Expected ErrBudgetExceeded, got %v", err)
+			}
+		})
+	})
+}
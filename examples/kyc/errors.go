@@ -3,6 +3,8 @@ package kyc
 import (
 	"errors"
 	"fmt"
+
+	"github.com/remiges-tech/await/errclass"
 )
 
 // Common errors used across KYC providers.
@@ -13,8 +15,8 @@ var (
 	// ErrInvalidPAN indicates invalid PAN format.
 	ErrInvalidPAN = errors.New("invalid PAN format")
 
-	// ErrProviderUnavailable is returned when the KYC provider service is down.
-	// Currently unused but kept for future provider implementations.
+	// ErrProviderUnavailable is returned when the KYC provider service is down,
+	// or when every enabled provider is being skipped by an open circuit.
 	ErrProviderUnavailable = errors.New("provider service unavailable")
 
 	// ErrRateLimitExceeded is returned when too many requests are made to a provider.
@@ -24,11 +26,43 @@ var (
 	// ErrInvalidResponse indicates malformed response from provider.
 	ErrInvalidResponse = errors.New("invalid response from provider")
 
-	// ErrTimeout is returned when a provider request times out.
-	// Currently unused but kept for future provider implementations.
+	// ErrNoConsensus is returned in ModeConsensus when not enough providers agree.
+	ErrNoConsensus = errors.New("providers did not reach consensus")
+
+	// ErrBudgetExceeded is returned in ModeCostAware when even the cheapest
+	// enabled provider costs more than Budget.MaxCost.
+	ErrBudgetExceeded = errors.New("provider budget exceeded")
+
+	// ErrUnknownToken is returned by Resolve when no check is waiting for the callback token.
+	ErrUnknownToken = errors.New("unknown callback token")
+
+	// ErrTimeout is returned when a provider does not answer within its RequestTimeout.
 	ErrTimeout = errors.New("request timeout")
 )
 
+func init() {
+	errclass.Register(classify)
+}
+
+// classify places the KYC errors in the shared errclass taxonomy, so retries
+// and the circuit breaker agree on what a failure means.
+func classify(err error) errclass.Class {
+	switch {
+	case errors.Is(err, ErrAuthentication), errors.Is(err, ErrInvalidPAN), errors.Is(err, ErrInvalidResponse):
+		return errclass.Permanent
+	case errors.Is(err, ErrRateLimitExceeded):
+		return errclass.Throttled
+	case errors.Is(err, ErrProviderUnavailable), errors.Is(err, ErrTimeout):
+		return errclass.Transient
+	}
+
+	var provErr *ProviderError
+	if errors.As(err, &provErr) && len(provErr.Code) > 0 && provErr.Code[0] == '4' {
+		return errclass.Permanent
+	}
+	return errclass.Unknown
+}
+
 // ProviderError wraps provider-specific errors with additional context.
 type ProviderError struct {
 	Provider string
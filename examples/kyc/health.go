@@ -0,0 +1,193 @@
+package kyc
+
+import (
+	"sync"
+	"time"
+
+	"github.com/remiges-tech/await"
+)
+
+// HealthConfig controls how provider health is evaluated.
+// A zero FailureThreshold disables circuit breaking; outcomes are still tracked.
+type HealthConfig struct {
+	// Window is the number of most recent outcomes considered per provider.
+	Window int
+
+	// MinSamples is the number of outcomes required before the circuit can open.
+	MinSamples int
+
+	// FailureThreshold is the failure ratio (0-1) within the window that opens the circuit.
+	FailureThreshold float64
+
+	// Cooldown is how long an open circuit skips the provider before it is tried again.
+	Cooldown time.Duration
+}
+
+// DefaultHealthConfig returns default health tracking configuration.
+func DefaultHealthConfig() HealthConfig {
+	return HealthConfig{
+		Window:           20,
+		MinSamples:       5,
+		FailureThreshold: 0.5,
+		Cooldown:         30 * time.Second,
+	}
+}
+
+// ProviderHealth is a point-in-time view of a provider's recent behaviour.
+type ProviderHealth struct {
+	// Samples is the number of outcomes in the rolling window.
+	Samples int
+
+	// SuccessRate is the fraction of successful outcomes in the window.
+	SuccessRate float64
+
+	// AvgLatency is the mean duration of outcomes in the window.
+	AvgLatency time.Duration
+
+	// CircuitOpen reports whether the provider is currently being skipped.
+	CircuitOpen bool
+
+	// OpenUntil is when an open circuit will allow the provider to be tried again.
+	OpenUntil time.Time
+}
+
+type outcome struct {
+	success bool
+	latency time.Duration
+}
+
+type providerWindow struct {
+	outcomes  []outcome
+	next      int
+	openUntil time.Time
+}
+
+// HealthTracker records rolling success rates and latencies per provider and
+// opens a circuit for providers whose failure ratio crosses the configured threshold.
+type HealthTracker struct {
+	mu        sync.Mutex
+	config    HealthConfig
+	providers map[string]*providerWindow
+	now       func() time.Time
+}
+
+// NewHealthTracker creates a HealthTracker with the given configuration.
+func NewHealthTracker(config HealthConfig) *HealthTracker {
+	if config.Window <= 0 {
+		config.Window = DefaultHealthConfig().Window
+	}
+	return &HealthTracker{
+		config:    config,
+		providers: make(map[string]*providerWindow),
+		now:       time.Now,
+	}
+}
+
+// Record adds the outcome of a provider check to its rolling window. When the
+// provider's circuit opens, an await.CircuitOpened event is published.
+func (h *HealthTracker) Record(provider string, success bool, latency time.Duration) {
+	if opened, ok := h.record(provider, success, latency); ok && await.Subscribed() {
+		await.Publish(opened)
+	}
+}
+
+// record updates the provider's window and reports the event to publish if
+// the circuit went from closed to open.
+func (h *HealthTracker) record(provider string, success bool, latency time.Duration) (await.CircuitOpened, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	w := h.window(provider)
+	now := h.now()
+	halfOpen := !w.openUntil.IsZero() && !now.Before(w.openUntil)
+	wasOpen := now.Before(w.openUntil)
+
+	if halfOpen && success {
+		// The trial call after cooldown succeeded: close the circuit and start afresh.
+		w.outcomes = w.outcomes[:0]
+		w.next = 0
+		w.openUntil = time.Time{}
+	}
+
+	o := outcome{success: success, latency: latency}
+	if len(w.outcomes) < h.config.Window {
+		w.outcomes = append(w.outcomes, o)
+	} else {
+		w.outcomes[w.next] = o
+		w.next = (w.next + 1) % h.config.Window
+	}
+
+	if success || h.config.FailureThreshold <= 0 {
+		return await.CircuitOpened{}, false
+	}
+	if halfOpen || (len(w.outcomes) >= h.config.MinSamples && failureRatio(w.outcomes) >= h.config.FailureThreshold) {
+		w.openUntil = now.Add(h.config.Cooldown)
+		if !wasOpen {
+			return await.CircuitOpened{Time: now, Name: provider, Until: w.openUntil}, true
+		}
+	}
+	return await.CircuitOpened{}, false
+}
+
+// Allow reports whether the provider's circuit is closed (or its cooldown has elapsed).
+func (h *HealthTracker) Allow(provider string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	w, ok := h.providers[provider]
+	if !ok {
+		return true
+	}
+	return !h.now().Before(w.openUntil)
+}
+
+// Health returns a snapshot of every tracked provider's health.
+func (h *HealthTracker) Health() map[string]ProviderHealth {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := h.now()
+	snapshot := make(map[string]ProviderHealth, len(h.providers))
+	for name, w := range h.providers {
+		health := ProviderHealth{
+			Samples:     len(w.outcomes),
+			CircuitOpen: now.Before(w.openUntil),
+		}
+		if health.CircuitOpen {
+			health.OpenUntil = w.openUntil
+		}
+		if len(w.outcomes) > 0 {
+			var successes int
+			var total time.Duration
+			for _, o := range w.outcomes {
+				if o.success {
+					successes++
+				}
+				total += o.latency
+			}
+			health.SuccessRate = float64(successes) / float64(len(w.outcomes))
+			health.AvgLatency = total / time.Duration(len(w.outcomes))
+		}
+		snapshot[name] = health
+	}
+	return snapshot
+}
+
+func (h *HealthTracker) window(provider string) *providerWindow {
+	w, ok := h.providers[provider]
+	if !ok {
+		w = &providerWindow{}
+		h.providers[provider] = w
+	}
+	return w
+}
+
+func failureRatio(outcomes []outcome) float64 {
+	var failures int
+	for _, o := range outcomes {
+		if !o.success {
+			failures++
+		}
+	}
+	return float64(failures) / float64(len(outcomes))
+}
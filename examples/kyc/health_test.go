@@ -0,0 +1,169 @@
+package kyc_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/remiges-tech/await"
+	"github.com/remiges-tech/await/examples/kyc"
+)
+
+func TestHealthTrackerCircuit(t *testing.T) {
+	tracker := kyc.NewHealthTracker(kyc.HealthConfig{
+		Window:           4,
+		MinSamples:       2,
+		FailureThreshold: 0.5,
+		Cooldown:         20 * time.Millisecond,
+	})
+
+	tracker.Record("CAMS", true, 10*time.Millisecond)
+	if !tracker.Allow("CAMS") {
+		t.Fatalf("Expected circuit closed after success")
+	}
+
+	tracker.Record("CAMS", false, 30*time.Millisecond)
+	if tracker.Allow("CAMS") {
+		t.Fatalf("Expected circuit open at 50%% failure rate")
+	}
+
+	health := tracker.Health()["CAMS"]
+	if !health.CircuitOpen || health.Samples != 2 || health.SuccessRate != 0.5 || health.AvgLatency != 20*time.Millisecond {
+		t.Errorf("Unexpected health snapshot: %+v", health)
+	}
+
+	time.Sleep(25 * time.Millisecond)
+	if !tracker.Allow("CAMS") {
+		t.Fatalf("Expected circuit to allow a trial call after cooldown")
+	}
+
+	tracker.Record("CAMS", true, 10*time.Millisecond)
+	health = tracker.Health()["CAMS"]
+	if health.CircuitOpen || health.Samples != 1 || health.SuccessRate != 1 {
+		t.Errorf("Expected circuit closed and window reset after successful trial, got %+v", health)
+	}
+}
+
+func TestHealthTrackerPublishesCircuitOpened(t *testing.T) {
+	opened := make(chan await.CircuitOpened, 4)
+	t.Cleanup(await.Subscribe(func(ev await.Event) {
+		if ev, ok := ev.(await.CircuitOpened); ok {
+			opened <- ev
+		}
+	}))
+
+	tracker := kyc.NewHealthTracker(kyc.HealthConfig{
+		Window:           4,
+		MinSamples:       1,
+		FailureThreshold: 0.5,
+		Cooldown:         time.Minute,
+	})
+	tracker.Record("KRA", false, time.Millisecond)
+	tracker.Record("KRA", false, time.Millisecond) // already open: no second event
+
+	if len(opened) != 1 {
+		t.Fatalf("Expected one CircuitOpened event, got %d", len(opened))
+	}
+	if ev := <-opened; ev.Name != "KRA" || !ev.Until.After(ev.Time) {
+		t.Errorf("Unexpected event %+v", ev)
+	}
+}
+
+func TestHealthTrackerDisabledCircuit(t *testing.T) {
+	tracker := kyc.NewHealthTracker(kyc.HealthConfig{})
+	for i := 0; i < 10; i++ {
+		tracker.Record("NDML", false, time.Millisecond)
+	}
+	if !tracker.Allow("NDML") {
+		t.Errorf("Expected circuit breaking disabled with zero FailureThreshold")
+	}
+	if tracker.Health()["NDML"].SuccessRate != 0 {
+		t.Errorf("Expected outcomes to still be tracked")
+	}
+}
+
+func TestCoordinatorSkipsOpenCircuit(t *testing.T) {
+	providers := map[string]kyc.KYCProvider{
+		"Broken": &MockProvider{name: "Broken", shouldFail: true, failCount: 100},
+	}
+
+	config := kyc.CoordinatorConfig{
+		MaxRetries:   1,
+		RetryBackoff: time.Millisecond,
+		Health: kyc.HealthConfig{
+			Window:           5,
+			MinSamples:       2,
+			FailureThreshold: 0.5,
+			Cooldown:         time.Minute,
+		},
+	}
+	coordinator := kyc.NewCoordinator(providers, config)
+	panDetails := kyc.PanDetails{PAN: "CIRCUIT01"}
+
+	for i := 0; i < 2; i++ {
+		if _, _, _, err := coordinator.CheckKYC(context.Background(), panDetails); err == nil {
+			t.Fatalf("Expected failure on call %d", i+1)
+		}
+	}
+
+	_, _, _, err := coordinator.CheckKYC(context.Background(), panDetails)
+	if !errors.Is(err, kyc.ErrProviderUnavailable) {
+		t.Fatalf("Expected ErrProviderUnavailable with open circuit, got %v", err)
+	}
+
+	if attempts := providers["Broken"].(*MockProvider).attemptCount; attempts != 2 {
+		t.Errorf("Expected provider to be skipped once circuit opened, got %d calls", attempts)
+	}
+
+	if !coordinator.Health()["Broken"].CircuitOpen {
+		t.Errorf("Expected Health() to report open circuit")
+	}
+}
+
+// failingProvider always fails with err.
+type failingProvider struct {
+	err error
+}
+
+func (p *failingProvider) CheckKYC(ctx context.Context, panDetails kyc.PanDetails) (kyc.KYCStatus, error) {
+	return kyc.KYCStatus{}, p.err
+}
+
+func TestCoordinatorCircuitPermanentErrors(t *testing.T) {
+	config := kyc.CoordinatorConfig{
+		MaxRetries:   1,
+		RetryBackoff: time.Millisecond,
+		Health: kyc.HealthConfig{
+			Window:           5,
+			MinSamples:       2,
+			FailureThreshold: 0.5,
+			Cooldown:         time.Minute,
+		},
+	}
+	panDetails := kyc.PanDetails{PAN: "CIRCUIT02"}
+
+	t.Run("authentication failures open the circuit", func(t *testing.T) {
+		coordinator := kyc.NewCoordinator(map[string]kyc.KYCProvider{
+			"Expired": &failingProvider{err: kyc.ErrAuthentication},
+		}, config)
+		for i := 0; i < 2; i++ {
+			coordinator.CheckKYC(context.Background(), panDetails)
+		}
+		if !coordinator.Health()["Expired"].CircuitOpen {
+			t.Errorf("Expected rejected credentials to open the circuit, got %+v", coordinator.Health()["Expired"])
+		}
+	})
+
+	t.Run("invalid PANs are not held against the provider", func(t *testing.T) {
+		coordinator := kyc.NewCoordinator(map[string]kyc.KYCProvider{
+			"Strict": &failingProvider{err: kyc.ErrInvalidPAN},
+		}, config)
+		for i := 0; i < 2; i++ {
+			coordinator.CheckKYC(context.Background(), panDetails)
+		}
+		if health := coordinator.Health()["Strict"]; health.CircuitOpen || health.Samples != 0 {
+			t.Errorf("Expected invalid PANs not to be recorded, got %+v", health)
+		}
+	})
+}
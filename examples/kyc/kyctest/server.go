@@ -0,0 +1,124 @@
+// Package kyctest provides a scriptable HTTP KYC provider for tests and demos.
+//
+// A Server speaks the JSON protocol of providers.HTTPProvider: a POST to
+// providers.CheckPath with a providers.CheckRequest body is answered with a
+// providers.CheckResponse or an error status code. Scripted Replies make it
+// slow, flaky or unavailable so retries, timeouts, hedging and circuit
+// breaking can be exercised end to end.
+package kyctest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/remiges-tech/await/examples/kyc/providers"
+)
+
+// Reply scripts a Server's answer to one request.
+type Reply struct {
+	StatusCode int           // HTTP status code; 0 means 200
+	Status     string        // KYC status sent with a 200 (default "VERIFIED")
+	Delay      time.Duration // Wait before answering; cut short if the client goes away
+	RetryAfter time.Duration // Sent as a Retry-After header, rounded down to whole seconds
+}
+
+// Server is an httptest.Server that answers KYC checks from a script of
+// Replies. Requests with a malformed PAN are always rejected with 400.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	replies  []Reply
+	requests int
+}
+
+// NewServer starts a Server that answers requests with replies in order,
+// repeating the last one once the script runs out. With no replies every
+// check succeeds immediately. Close the server when done.
+func NewServer(replies ...Reply) *Server {
+	s := &Server{replies: replies}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// Script replaces the remaining replies, for example to bring a failing
+// provider back up.
+func (s *Server) Script(replies ...Reply) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.replies = replies
+}
+
+// Requests returns the number of KYC checks the server has received.
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+// next counts a request and returns its scripted reply.
+func (s *Server) next() Reply {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests++
+	if len(s.replies) == 0 {
+		return Reply{}
+	}
+	reply := s.replies[0]
+	if len(s.replies) > 1 {
+		s.replies = s.replies[1:]
+	}
+	return reply
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != providers.CheckPath {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	reply := s.next()
+
+	// Read the body before waiting: the server only notices a client that
+	// has gone away once the body has been consumed.
+	var req providers.CheckRequest
+	decodeErr := json.NewDecoder(r.Body).Decode(&req)
+
+	if reply.Delay > 0 {
+		timer := time.NewTimer(reply.Delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	if decodeErr != nil || len(req.PAN) != 10 {
+		http.Error(w, "invalid PAN", http.StatusBadRequest)
+		return
+	}
+
+	if reply.RetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(reply.RetryAfter/time.Second)))
+	}
+	if reply.StatusCode != 0 && reply.StatusCode != http.StatusOK {
+		http.Error(w, http.StatusText(reply.StatusCode), reply.StatusCode)
+		return
+	}
+
+	status := reply.Status
+	if status == "" {
+		status = "VERIFIED"
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(providers.CheckResponse{Status: status, PAN: req.PAN})
+}
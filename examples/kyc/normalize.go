@@ -0,0 +1,77 @@
+package kyc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// KYCState is the canonical KYC outcome shared by all providers.
+type KYCState string
+
+const (
+	StateVerified   KYCState = "VERIFIED"
+	StateRejected   KYCState = "REJECTED"
+	StatePending    KYCState = "PENDING"
+	StateIncomplete KYCState = "INCOMPLETE"
+)
+
+// ResponseMapper maps a provider's raw response into the canonical KYCState.
+// Returning an error marks the response as invalid.
+type ResponseMapper func(raw KYCStatus) (KYCState, error)
+
+// defaultStatusMap holds common vendor status strings, keyed in upper case.
+var defaultStatusMap = map[string]KYCState{
+	"VERIFIED":      StateVerified,
+	"VALIDATED":     StateVerified,
+	"REGISTERED":    StateVerified,
+	"REJECTED":      StateRejected,
+	"DECLINED":      StateRejected,
+	"PENDING":       StatePending,
+	"UNDER_PROCESS": StatePending,
+	"ON_HOLD":       StatePending,
+	"INCOMPLETE":    StateIncomplete,
+}
+
+// DefaultResponseMapper maps common vendor status strings case-insensitively.
+// Unknown statuses are rejected as invalid.
+func DefaultResponseMapper(raw KYCStatus) (KYCState, error) {
+	return MapStatuses(defaultStatusMap)(raw)
+}
+
+// MapStatuses returns a ResponseMapper backed by a lookup table of vendor status
+// strings. Keys are matched case-insensitively after trimming whitespace.
+func MapStatuses(table map[string]KYCState) ResponseMapper {
+	normalized := make(map[string]KYCState, len(table))
+	for k, v := range table {
+		normalized[strings.ToUpper(strings.TrimSpace(k))] = v
+	}
+
+	return func(raw KYCStatus) (KYCState, error) {
+		state, ok := normalized[strings.ToUpper(strings.TrimSpace(raw.Status))]
+		if !ok {
+			return "", fmt.Errorf("unrecognized status %q", raw.Status)
+		}
+		return state, nil
+	}
+}
+
+// normalize applies mapper to raw and records the canonical state.
+// Mapping failures are reported as ErrInvalidResponse, which is not retried.
+func normalize(raw KYCStatus, mapper ResponseMapper) (KYCStatus, error) {
+	if mapper == nil {
+		mapper = DefaultResponseMapper
+	}
+
+	state, err := mapper(raw)
+	if err != nil {
+		return KYCStatus{}, fmt.Errorf("%w: %v", ErrInvalidResponse, err)
+	}
+	switch state {
+	case StateVerified, StateRejected, StatePending, StateIncomplete:
+	default:
+		return KYCStatus{}, fmt.Errorf("%w: mapper returned unknown state %q", ErrInvalidResponse, state)
+	}
+
+	raw.State = state
+	return raw, nil
+}
@@ -0,0 +1,61 @@
+package kyc_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/remiges-tech/await/examples/kyc"
+)
+
+func TestDefaultResponseMapper(t *testing.T) {
+	cases := map[string]kyc.KYCState{
+		"VERIFIED":      kyc.StateVerified,
+		" registered ":  kyc.StateVerified,
+		"Rejected":      kyc.StateRejected,
+		"UNDER_PROCESS": kyc.StatePending,
+		"incomplete":    kyc.StateIncomplete,
+	}
+	for raw, expected := range cases {
+		state, err := kyc.DefaultResponseMapper(kyc.KYCStatus{Status: raw})
+		if err != nil || state != expected {
+			t.Errorf("Expected %q to map to %s, got %s (err: %v)", raw, expected, state, err)
+		}
+	}
+
+	if _, err := kyc.DefaultResponseMapper(kyc.KYCStatus{Status: "??"}); err == nil {
+		t.Errorf("Expected error for unrecognized status")
+	}
+}
+
+func TestCoordinatorResponseMapper(t *testing.T) {
+	providers := map[string]kyc.KYCProvider{
+		"Vendor": &MockProvider{name: "Vendor", status: "K01"},
+	}
+
+	config := kyc.DefaultCoordinatorConfig()
+	config.Providers = map[string]kyc.ProviderConfig{
+		"Vendor": {Mapper: kyc.MapStatuses(map[string]kyc.KYCState{"K01": kyc.StateVerified})},
+	}
+
+	status, _, _, err := kyc.NewCoordinator(providers, config).CheckKYC(context.Background(), kyc.PanDetails{PAN: "MAPPER001"})
+	if err != nil {
+		t.Fatalf("Expected success, got error: %v", err)
+	}
+	if status.KYCResponse.State != kyc.StateVerified || status.KYCResponse.Status != "K01" {
+		t.Errorf("Expected raw K01 mapped to VERIFIED, got %s -> %s", status.KYCResponse.Status, status.KYCResponse.State)
+	}
+}
+
+func TestCoordinatorInvalidResponse(t *testing.T) {
+	provider := &MockProvider{name: "Garbage", status: "???"}
+	providers := map[string]kyc.KYCProvider{"Garbage": provider}
+
+	_, _, _, err := kyc.NewCoordinator(providers, kyc.DefaultCoordinatorConfig()).CheckKYC(context.Background(), kyc.PanDetails{PAN: "MAPPER002"})
+	if !errors.Is(err, kyc.ErrInvalidResponse) {
+		t.Fatalf("Expected ErrInvalidResponse, got %v", err)
+	}
+	if provider.attemptCount != 1 {
+		t.Errorf("Expected invalid responses not to be retried, got %d attempts", provider.attemptCount)
+	}
+}
@@ -1,6 +1,8 @@
 package providers
 
 import (
+	"context"
+
 	"github.com/remiges-tech/await/examples/kyc"
 )
 
@@ -13,7 +15,7 @@ func NewCVLProvider() *CVLProvider {
 }
 
 // CheckKYC implements the KYCProvider interface for CVL.
-func (c *CVLProvider) CheckKYC(panDetails kyc.PanDetails) (kyc.KYCStatus, error) {
+func (c *CVLProvider) CheckKYC(ctx context.Context, panDetails kyc.PanDetails) (kyc.KYCStatus, error) {
 	return kyc.KYCStatus{
 		Status:    "VERIFIED",
 		OtherInfo: nil,
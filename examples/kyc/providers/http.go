@@ -0,0 +1,151 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/remiges-tech/await/examples/kyc"
+	"github.com/remiges-tech/await/retry"
+)
+
+// CheckPath is the path HTTPProvider posts KYC checks to, under its base URL.
+const CheckPath = "/kyc"
+
+// CheckRequest is the JSON body of a KYC check request.
+type CheckRequest struct {
+	PAN string `json:"pan"`
+}
+
+// CheckResponse is the JSON body of a successful KYC check response.
+type CheckResponse struct {
+	Status string `json:"status"`
+	PAN    string `json:"pan"`
+}
+
+// HTTPConfig controls how an HTTPProvider calls its endpoint.
+type HTTPConfig struct {
+	// Client sends the requests. Defaults to http.DefaultClient.
+	Client *http.Client
+
+	// Retry configures the provider's own retry loop. A zero MaxAttempts
+	// means 3 attempts, a nil Strategy exponential backoff from 100ms, a zero
+	// AttemptTimeout 5s per request, and a nil RetryIf retries what
+	// kyc.IsRetryable allows.
+	Retry retry.Options
+
+	// Hedge, if above 1, runs that many requests at once for each retry after
+	// the first failure and keeps the first answer, using retry.DoParallel.
+	// KYC checks are reads, so duplicate requests are safe.
+	Hedge int
+}
+
+// HTTPProvider checks KYC status against an HTTP endpoint, such as a
+// kyctest.Server, posting a CheckRequest to CheckPath and retrying failed
+// requests itself. Responses are mapped to the kyc errors so the
+// coordinator's retry and circuit breaker treat them correctly: 400 becomes
+// ErrInvalidPAN, 401 and 403 ErrAuthentication, 429 ErrRateLimitExceeded,
+// 5xx ErrProviderUnavailable and a timed out request ErrTimeout, each wrapped
+// in a retry.HTTPError when there was a response.
+type HTTPProvider struct {
+	name   string
+	url    string
+	config HTTPConfig
+}
+
+// NewHTTPProvider creates a provider that posts checks to baseURL.
+func NewHTTPProvider(name, baseURL string, config HTTPConfig) *HTTPProvider {
+	if config.Client == nil {
+		config.Client = http.DefaultClient
+	}
+	if config.Retry.MaxAttempts == 0 {
+		config.Retry.MaxAttempts = 3
+	}
+	if config.Retry.Strategy == nil {
+		config.Retry.Strategy = &retry.ExponentialBackoff{
+			InitialDelay: 100 * time.Millisecond,
+			Multiplier:   2,
+			MaxDelay:     2 * time.Second,
+		}
+	}
+	if config.Retry.AttemptTimeout == 0 {
+		config.Retry.AttemptTimeout = 5 * time.Second
+	}
+	if config.Retry.RetryIf == nil {
+		config.Retry.RetryIf = kyc.IsRetryable
+	}
+	return &HTTPProvider{name: name, url: baseURL + CheckPath, config: config}
+}
+
+// CheckKYC implements the KYCProvider interface.
+func (p *HTTPProvider) CheckKYC(ctx context.Context, panDetails kyc.PanDetails) (kyc.KYCStatus, error) {
+	call := func(ctx context.Context) (kyc.KYCStatus, error) {
+		return p.call(ctx, panDetails)
+	}
+	if p.config.Hedge > 1 {
+		opts := p.config.Retry
+		opts.ParallelAttempts = p.config.Hedge
+		return retry.DoParallel(ctx, call, opts)
+	}
+	return retry.Do(ctx, call, p.config.Retry)
+}
+
+// call makes a single request.
+func (p *HTTPProvider) call(ctx context.Context, panDetails kyc.PanDetails) (kyc.KYCStatus, error) {
+	body, err := json.Marshal(CheckRequest{PAN: panDetails.PAN})
+	if err != nil {
+		return kyc.KYCStatus{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return kyc.KYCStatus{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.config.Client.Do(req)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return kyc.KYCStatus{}, fmt.Errorf("%s: %w: %v", p.name, kyc.ErrTimeout, err)
+		}
+		if ctx.Err() != nil {
+			return kyc.KYCStatus{}, ctx.Err()
+		}
+		return kyc.KYCStatus{}, fmt.Errorf("%s: %w: %v", p.name, kyc.ErrProviderUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return kyc.KYCStatus{}, fmt.Errorf("%s: %w", p.name, statusError(resp))
+	}
+
+	var out CheckResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return kyc.KYCStatus{}, fmt.Errorf("%s: %w: %v", p.name, kyc.ErrInvalidResponse, err)
+	}
+	return kyc.KYCStatus{Status: out.Status}, nil
+}
+
+// statusError converts an unsuccessful response into a retry.HTTPError
+// wrapping the matching kyc error.
+func statusError(resp *http.Response) error {
+	httpErr := &retry.HTTPError{StatusCode: resp.StatusCode}
+	switch {
+	case resp.StatusCode == http.StatusBadRequest:
+		httpErr.Err = kyc.ErrInvalidPAN
+	case resp.StatusCode == http.StatusUnauthorized, resp.StatusCode == http.StatusForbidden:
+		httpErr.Err = kyc.ErrAuthentication
+	case resp.StatusCode == http.StatusTooManyRequests:
+		httpErr.Err = kyc.ErrRateLimitExceeded
+	case resp.StatusCode >= 500:
+		httpErr.Err = kyc.ErrProviderUnavailable
+	}
+	if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && secs > 0 {
+		httpErr.RetryAfter = time.Duration(secs) * time.Second
+	}
+	return httpErr
+}
@@ -0,0 +1,172 @@
+package providers_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/remiges-tech/await/examples/kyc"
+	"github.com/remiges-tech/await/examples/kyc/kyctest"
+	"github.com/remiges-tech/await/examples/kyc/providers"
+	"github.com/remiges-tech/await/retry"
+)
+
+var validPAN = kyc.PanDetails{PAN: "ABCDE1234F"}
+
+func fastRetry(attempts int, timeout time.Duration) retry.Options {
+	return retry.Options{
+		MaxAttempts:    attempts,
+		Strategy:       &retry.ConstantDelay{Delay: time.Millisecond},
+		AttemptTimeout: timeout,
+	}
+}
+
+func TestHTTPProvider(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		server := kyctest.NewServer(kyctest.Reply{Status: "REGISTERED"})
+		defer server.Close()
+		p := providers.NewHTTPProvider("CAMS", server.URL, providers.HTTPConfig{})
+
+		status, err := p.CheckKYC(ctx, validPAN)
+		if err != nil || status.Status != "REGISTERED" {
+			t.Fatalf("Expected REGISTERED, got %+v, %v", status, err)
+		}
+	})
+
+	t.Run("retries unavailable", func(t *testing.T) {
+		unavailable := kyctest.Reply{StatusCode: http.StatusServiceUnavailable}
+		server := kyctest.NewServer(unavailable, unavailable, kyctest.Reply{})
+		defer server.Close()
+		p := providers.NewHTTPProvider("CAMS", server.URL, providers.HTTPConfig{Retry: fastRetry(3, time.Second)})
+
+		if _, err := p.CheckKYC(ctx, validPAN); err != nil {
+			t.Fatalf("Expected success on third request, got %v", err)
+		}
+		if n := server.Requests(); n != 3 {
+			t.Errorf("Expected 3 requests, got %d", n)
+		}
+	})
+
+	t.Run("default attempts keep a custom strategy", func(t *testing.T) {
+		unavailable := kyctest.Reply{StatusCode: http.StatusServiceUnavailable}
+		server := kyctest.NewServer(unavailable, unavailable, kyctest.Reply{})
+		defer server.Close()
+		p := providers.NewHTTPProvider("CAMS", server.URL, providers.HTTPConfig{
+			Retry: retry.Options{Strategy: &retry.ConstantDelay{Delay: time.Millisecond}},
+		})
+
+		start := time.Now()
+		if _, err := p.CheckKYC(ctx, validPAN); err != nil {
+			t.Fatalf("Expected success on third request, got %v", err)
+		}
+		if n := server.Requests(); n != 3 {
+			t.Errorf("Expected 3 requests, got %d", n)
+		}
+		if elapsed := time.Since(start); elapsed > 250*time.Millisecond {
+			t.Errorf("Expected the 1ms strategy between retries, took %v", elapsed)
+		}
+	})
+
+	t.Run("invalid PAN is not retried", func(t *testing.T) {
+		server := kyctest.NewServer()
+		defer server.Close()
+		p := providers.NewHTTPProvider("CAMS", server.URL, providers.HTTPConfig{Retry: fastRetry(3, time.Second)})
+
+		_, err := p.CheckKYC(ctx, kyc.PanDetails{PAN: "BAD"})
+		var httpErr *retry.HTTPError
+		if !errors.Is(err, kyc.ErrInvalidPAN) || !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusBadRequest {
+			t.Fatalf("Expected ErrInvalidPAN with status 400, got %v", err)
+		}
+		if n := server.Requests(); n != 1 {
+			t.Errorf("Expected 1 request, got %d", n)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		server := kyctest.NewServer(kyctest.Reply{Delay: time.Second})
+		defer server.Close()
+		p := providers.NewHTTPProvider("CAMS", server.URL, providers.HTTPConfig{Retry: fastRetry(2, 20*time.Millisecond)})
+
+		start := time.Now()
+		if _, err := p.CheckKYC(ctx, validPAN); !errors.Is(err, kyc.ErrTimeout) {
+			t.Fatalf("Expected ErrTimeout, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+			t.Errorf("Expected requests to be abandoned at their timeout, took %v", elapsed)
+		}
+		if n := server.Requests(); n != 2 {
+			t.Errorf("Expected 2 requests, got %d", n)
+		}
+	})
+
+	t.Run("rate limited", func(t *testing.T) {
+		server := kyctest.NewServer(kyctest.Reply{StatusCode: http.StatusTooManyRequests})
+		defer server.Close()
+		p := providers.NewHTTPProvider("CAMS", server.URL, providers.HTTPConfig{Retry: fastRetry(2, time.Second)})
+
+		if _, err := p.CheckKYC(ctx, validPAN); !errors.Is(err, kyc.ErrRateLimitExceeded) {
+			t.Fatalf("Expected ErrRateLimitExceeded, got %v", err)
+		}
+	})
+
+	t.Run("hedged retries", func(t *testing.T) {
+		// The first request fails; of the hedged pair that follows, one is
+		// slow and the other answers at once.
+		server := kyctest.NewServer(
+			kyctest.Reply{StatusCode: http.StatusBadGateway},
+			kyctest.Reply{Delay: time.Second},
+			kyctest.Reply{},
+		)
+		defer server.Close()
+		p := providers.NewHTTPProvider("CAMS", server.URL, providers.HTTPConfig{Retry: fastRetry(3, 2*time.Second), Hedge: 2})
+
+		start := time.Now()
+		if _, err := p.CheckKYC(ctx, validPAN); err != nil {
+			t.Fatalf("Expected hedged success, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+			t.Errorf("Expected the fast hedged request to win, took %v", elapsed)
+		}
+		if n := server.Requests(); n != 3 {
+			t.Errorf("Expected 3 requests, got %d", n)
+		}
+	})
+}
+
+func TestHTTPProvidersWithCoordinator(t *testing.T) {
+	healthy := kyctest.NewServer(kyctest.Reply{Delay: 20 * time.Millisecond})
+	defer healthy.Close()
+	down := kyctest.NewServer(kyctest.Reply{StatusCode: http.StatusServiceUnavailable})
+	defer down.Close()
+
+	httpConfig := providers.HTTPConfig{Retry: fastRetry(2, time.Second)}
+	config := kyc.DefaultCoordinatorConfig()
+	config.MaxRetries = 1 // the HTTP providers retry themselves
+	config.Health = kyc.HealthConfig{Window: 4, MinSamples: 2, FailureThreshold: 0.5, Cooldown: time.Minute}
+	coordinator := kyc.NewCoordinator(map[string]kyc.KYCProvider{
+		"CAMS": providers.NewHTTPProvider("CAMS", healthy.URL, httpConfig),
+		"CVL":  providers.NewHTTPProvider("CVL", down.URL, httpConfig),
+	}, config)
+
+	for i := 0; i < 4; i++ {
+		_, winner, _, err := coordinator.CheckKYC(context.Background(), validPAN)
+		if err != nil || winner != "CAMS" {
+			t.Fatalf("check %d: expected CAMS to verify, got %q, %v", i, winner, err)
+		}
+	}
+
+	if !coordinator.Health()["CVL"].CircuitOpen {
+		t.Fatalf("Expected CVL circuit to open, got %+v", coordinator.Health()["CVL"])
+	}
+	requests := down.Requests()
+	if _, _, _, err := coordinator.CheckKYC(context.Background(), validPAN); err != nil {
+		t.Fatalf("Expected CAMS to verify with CVL skipped, got %v", err)
+	}
+	if n := down.Requests(); n != requests {
+		t.Errorf("Expected no requests to CVL while its circuit is open, got %d more", n-requests)
+	}
+}
@@ -1,6 +1,8 @@
 package providers
 
 import (
+	"context"
+
 	"github.com/remiges-tech/await/examples/kyc"
 )
 
@@ -13,7 +15,7 @@ func NewNDMLProvider() *NDMLProvider {
 }
 
 // CheckKYC implements the KYCProvider interface for NDML.
-func (n *NDMLProvider) CheckKYC(panDetails kyc.PanDetails) (kyc.KYCStatus, error) {
+func (n *NDMLProvider) CheckKYC(ctx context.Context, panDetails kyc.PanDetails) (kyc.KYCStatus, error) {
 	return kyc.KYCStatus{
 		Status:    "VERIFIED",
 		OtherInfo: nil,
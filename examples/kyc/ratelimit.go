@@ -0,0 +1,47 @@
+package kyc
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// rateLimiter spaces requests to a provider evenly at a fixed rate, shared by
+// every check the coordinator runs against that provider.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// newRateLimiter creates a limiter allowing perSecond requests per second.
+func newRateLimiter(perSecond float64) *rateLimiter {
+	return &rateLimiter{interval: time.Duration(float64(time.Second) / perSecond)}
+}
+
+// Wait blocks until the caller may send its request, or returns ctx's error.
+// A caller that gives up still uses its slot, which keeps Wait lock-free
+// while sleeping at the cost of occasionally running below the limit.
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	slot := l.next
+	if slot.Before(now) {
+		slot = now
+	}
+	l.next = slot.Add(l.interval)
+	l.mu.Unlock()
+
+	delay := slot.Sub(now)
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -1,6 +1,7 @@
 package kyc
 
 import (
+	"context"
 	"time"
 )
 
@@ -30,19 +31,64 @@ type ProviderStatus struct {
 
 // KYCStatus represents the standardized response from any KYC provider.
 type KYCStatus struct {
-	// Status indicates the KYC verification result.
+	// Status indicates the KYC verification result as reported by the provider.
 	Status string
 
+	// State is the canonical outcome derived from Status by the provider's ResponseMapper.
+	// It is set by the coordinator.
+	State KYCState
+
 	// OtherInfo contains additional data from provider.
 	OtherInfo map[string]interface{}
 }
 
 // KYCProvider defines the interface that all KYC providers must implement.
 type KYCProvider interface {
+	// CheckKYC performs KYC verification and returns standardized status.
+	// Implementations should abandon the call when ctx is done.
+	CheckKYC(ctx context.Context, panDetails PanDetails) (KYCStatus, error)
+}
+
+// LegacyKYCProvider is the pre-context provider interface.
+// Wrap implementations with AdaptLegacyProvider to use them with the coordinator.
+type LegacyKYCProvider interface {
 	// CheckKYC performs KYC verification and returns standardized status.
 	CheckKYC(panDetails PanDetails) (KYCStatus, error)
 }
 
+// AdaptLegacyProvider wraps a LegacyKYCProvider as a KYCProvider.
+// The legacy call runs in its own goroutine so the coordinator can stop waiting
+// when the context is done; the call itself cannot be interrupted and its
+// result is discarded once the context has expired.
+func AdaptLegacyProvider(p LegacyKYCProvider) KYCProvider {
+	return legacyAdapter{p}
+}
+
+type legacyAdapter struct {
+	provider LegacyKYCProvider
+}
+
+// CheckKYC runs the legacy check and returns early if ctx is done.
+func (a legacyAdapter) CheckKYC(ctx context.Context, panDetails PanDetails) (KYCStatus, error) {
+	type result struct {
+		status KYCStatus
+		err    error
+	}
+
+	ch := make(chan result, 1)
+	go func() {
+		status, err := a.provider.CheckKYC(panDetails)
+		ch <- result{status, err}
+	}()
+
+	select {
+	case res := <-ch:
+		return res.status, res.err
+	case <-ctx.Done():
+		return KYCStatus{}, ctx.Err()
+	}
+}
+
 // PanDetails contains the input data needed for KYC verification.
 type PanDetails struct {
 	// PAN is the 10-character Permanent Account Number.
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/remiges-tech/await"
 	"github.com/remiges-tech/await/retry"
 )
 
@@ -108,33 +109,12 @@ func getUserInfo(id string) (string, int, bool, error) {
 	return "John Doe", 30, true, nil
 }
 
-// Pattern 4: Create a generic helper
-func retryWith2Returns[T, U any](
-	ctx context.Context,
-	fn func() (T, U, error),
-	opts retry.Options,
-) (T, U, error) {
-	var result1 T
-	var result2 U
-
-	_, err := retry.Do(ctx, func(ctx context.Context) (struct{}, error) {
-		r1, r2, err := fn()
-		if err != nil {
-			return struct{}{}, err
-		}
-		result1 = r1
-		result2 = r2
-		return struct{}{}, nil
-	}, opts)
-
-	return result1, result2, err
-}
-
-func genericHelperExample() {
+// Pattern 4: Multiple outputs - use retry.Do2 / retry.Do3
+func multiOutputWithDo2() {
 	ctx := context.Background()
 
-	// Use the generic helper
-	str, num, err := retryWith2Returns(ctx, func() (string, int, error) {
+	// Do2 packs and unpacks the two values internally
+	str, num, err := retry.Do2(ctx, func(ctx context.Context) (string, int, error) {
 		// Your function that returns 2 values + error
 		return "hello", 42, nil
 	}, retry.WithMaxAttempts(3))
@@ -144,6 +124,35 @@ func genericHelperExample() {
 	} else {
 		log.Printf("Got: %s and %d", str, num)
 	}
+
+	// Do3 does the same for three values
+	name, age, active, err := retry.Do3(ctx, func(ctx context.Context) (string, int, bool, error) {
+		return getUserInfo("user123")
+	}, retry.WithMaxAttempts(3))
+
+	if err != nil {
+		log.Printf("Failed: %v", err)
+	} else {
+		log.Printf("User: %s, Age: %d, Active: %v", name, age, active)
+	}
+}
+
+// Pattern 5: Function without a context - use await.FromFunc
+func fromFuncExample() {
+	ctx := context.Background()
+
+	lookup := func() (string, error) {
+		return "ok", nil
+	}
+
+	// FromFunc adapts the function instead of writing a closure that ignores ctx
+	result, err := retry.Do(ctx, await.FromFunc(lookup), retry.WithMaxAttempts(3))
+
+	if err != nil {
+		log.Printf("Failed: %v", err)
+	} else {
+		log.Printf("Lookup: %s", result)
+	}
 }
 
 func main() {
@@ -156,6 +165,9 @@ func main() {
 	fmt.Println("\n=== Pattern 3: Multiple Outputs with Closure ===")
 	multiOutputWithClosure()
 
-	fmt.Println("\n=== Pattern 4: Generic Helper ===")
-	genericHelperExample()
+	fmt.Println("\n=== Pattern 4: Multiple Outputs with Do2/Do3 ===")
+	multiOutputWithDo2()
+
+	fmt.Println("\n=== Pattern 5: Adapting a Function Without Context ===")
+	fromFuncExample()
 }
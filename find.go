@@ -0,0 +1,32 @@
+package await
+
+import "context"
+
+// Find executes all tasks concurrently and returns the value of the first task
+// to succeed with a value satisfying predicate, cancelling the others: for
+// example, querying every shard and returning the one holding a record, which
+// Any cannot express because a shard without the record still succeeds.
+// If no task produces a matching value it returns an *AggregateError like Any,
+// in which tasks that succeeded with a non-matching value are recorded as
+// ErrNoMatch.
+func Find[T any](ctx context.Context, predicate func(T) bool, tasks ...Task[T]) (T, error) {
+	return FindWithOptions(ctx, Options{}, predicate, tasks...)
+}
+
+// FindWithOptions is like Find but applies the given execution options, with
+// the same meaning as for AnyWithOptions.
+func FindWithOptions[T any](ctx context.Context, opts Options, predicate func(T) bool, tasks ...Task[T]) (T, error) {
+	matching := make([]Task[T], len(tasks))
+	for i, task := range tasks {
+		task := task
+		matching[i] = func(ctx context.Context) (T, error) {
+			val, err := task(ctx)
+			if err == nil && !predicate(val) {
+				var zero T
+				return zero, ErrNoMatch
+			}
+			return val, err
+		}
+	}
+	return AnyWithOptions(ctx, opts, matching...)
+}
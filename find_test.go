@@ -0,0 +1,67 @@
+package await
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFind(t *testing.T) {
+	ctx := context.Background()
+
+	// shard returns a task that looks up key in records after delay.
+	shard := func(records map[string]string, delay time.Duration) Task[string] {
+		return func(ctx context.Context) (string, error) {
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return "", ctx.Err()
+			}
+			return records["user-42"], nil
+		}
+	}
+	found := func(v string) bool { return v != "" }
+
+	t.Run("returns first match", func(t *testing.T) {
+		cancelled := make(chan bool, 1)
+		slow := func(ctx context.Context) (string, error) {
+			<-ctx.Done()
+			cancelled <- CancelledBySibling(ctx)
+			return "", ctx.Err()
+		}
+
+		val, err := Find(ctx, found,
+			shard(map[string]string{}, 0),
+			shard(map[string]string{"user-42": "alice"}, 10*time.Millisecond),
+			slow,
+		)
+		if err != nil || val != "alice" {
+			t.Fatalf("expected alice, got %q, %v", val, err)
+		}
+		if !<-cancelled {
+			t.Fatal("expected remaining task to be cancelled by the match")
+		}
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		errDown := errors.New("shard down")
+		_, err := Find(ctx, found,
+			shard(map[string]string{}, 0),
+			func(ctx context.Context) (string, error) { return "", errDown },
+		)
+		var aggErr *AggregateError
+		if !errors.As(err, &aggErr) || aggErr.Len() != 2 {
+			t.Fatalf("expected AggregateError with 2 errors, got %v", err)
+		}
+		if !errors.Is(err, ErrNoMatch) || !errors.Is(err, errDown) {
+			t.Fatalf("expected ErrNoMatch and the shard error, got %v", err)
+		}
+	})
+
+	t.Run("no tasks", func(t *testing.T) {
+		if _, err := Find(ctx, found); !errors.Is(err, ErrNoTasks) {
+			t.Fatalf("expected ErrNoTasks, got %v", err)
+		}
+	})
+}
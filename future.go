@@ -0,0 +1,60 @@
+package await
+
+import (
+	"context"
+)
+
+// Future is a handle to the eventual outcome of an async operation.
+// It is completed exactly once and may be awaited by any number of goroutines.
+type Future[T any] struct {
+	done chan struct{}
+	val  T
+	err  error
+}
+
+func newFuture[T any]() *Future[T] {
+	return &Future[T]{done: make(chan struct{})}
+}
+
+// complete records the outcome and releases all waiters. Must be called exactly once.
+func (f *Future[T]) complete(val T, err error) {
+	f.val = val
+	f.err = err
+	close(f.done)
+}
+
+// Done returns a channel that is closed when the outcome is available.
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.done
+}
+
+// Await blocks until the outcome is available or ctx is done.
+// If ctx is done first, the context error is returned and the operation keeps running.
+func (f *Future[T]) Await(ctx context.Context) (T, error) {
+	select {
+	case <-f.done:
+		return f.val, f.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// Result returns the outcome as a Result and whether it is available yet.
+func (f *Future[T]) Result() (Result[T], bool) {
+	select {
+	case <-f.done:
+		return Result[T]{Value: f.val, Err: f.err}, true
+	default:
+		return Result[T]{}, false
+	}
+}
+
+// Go starts task in a new goroutine and returns a Future for its outcome.
+func Go[T any](ctx context.Context, task Task[T]) *Future[T] {
+	f := newFuture[T]()
+	go func() {
+		f.complete(task(ctx))
+	}()
+	return f
+}
@@ -0,0 +1,17 @@
+package await
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGo(t *testing.T) {
+	f := Go(context.Background(), Task[string](func(ctx context.Context) (string, error) {
+		return "done", nil
+	}))
+
+	val, err := f.Await(context.Background())
+	if err != nil || val != "done" {
+		t.Fatalf("expected {done, nil}, got {%s, %v}", val, err)
+	}
+}
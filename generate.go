@@ -0,0 +1,78 @@
+package await
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// Generate runs n tasks, creating each one on demand with gen(i) just before
+// it starts, with at most limit running at once, and passes every outcome to
+// onResult together with its index, in completion order. Neither the task
+// closures nor the results are collected, so memory stays proportional to
+// limit rather than n, which matters for task sets in the hundreds of
+// thousands. A limit <= 0 uses runtime.GOMAXPROCS(0).
+//
+// onResult is only called from the calling goroutine, one result at a time,
+// so it may update state without locking. Once ctx is done no further tasks
+// are created; tasks already running are still reported, and Generate
+// returns ctx's error. It returns ErrNoTasks if n <= 0.
+func Generate[T any](ctx context.Context, n, limit int, gen func(i int) Task[T], onResult func(i int, r Result[T])) error {
+	if n <= 0 {
+		return ErrNoTasks
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return generate(ctx, n, n, limit, nil, gen, onResult, nil)
+}
+
+// generate runs the tasks of Generate for the indices below n that skip does
+// not mark, of which there are remaining, and calls idle after a result
+// whenever no other result is waiting, if idle is non-nil.
+func generate[T any](ctx context.Context, n, remaining, limit int, skip []bool, gen func(i int) Task[T], onResult func(i int, r Result[T]), idle func()) error {
+	if limit <= 0 {
+		limit = runtime.GOMAXPROCS(0)
+	}
+	if limit > remaining {
+		limit = remaining
+	}
+
+	type indexed struct {
+		idx int
+		res Result[T]
+	}
+	results := make(chan indexed, limit)
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for w := 0; w < limit; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				i := int(next.Add(1) - 1)
+				if i >= n {
+					return
+				}
+				if skip != nil && skip[i] {
+					continue
+				}
+				val, err := gen(i)(ctx)
+				results <- indexed{i, Result[T]{Value: val, Err: err}}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	for r := range results {
+		onResult(r.idx, r.res)
+		if idle != nil && len(results) == 0 {
+			idle()
+		}
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,89 @@
+package await
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGenerate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("runs every index within the limit", func(t *testing.T) {
+		const n, limit = 1000, 8
+		var running, peak atomic.Int32
+		seen := make([]bool, n)
+		sum := 0
+
+		err := Generate(ctx, n, limit, func(i int) Task[int] {
+			return func(ctx context.Context) (int, error) {
+				cur := running.Add(1)
+				defer running.Add(-1)
+				for {
+					p := peak.Load()
+					if cur <= p || peak.CompareAndSwap(p, cur) {
+						break
+					}
+				}
+				if i%100 == 0 {
+					return 0, errors.New("failed")
+				}
+				return i, nil
+			}
+		}, func(i int, r Result[int]) {
+			if seen[i] {
+				t.Errorf("index %d reported twice", i)
+			}
+			seen[i] = true
+			sum += r.Value
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		for i, ok := range seen {
+			if !ok {
+				t.Fatalf("index %d not reported", i)
+			}
+		}
+		// 0..999 minus the failed multiples of 100
+		if want := 499500 - 4500; sum != want {
+			t.Fatalf("expected sum %d, got %d", want, sum)
+		}
+		if p := peak.Load(); p > limit {
+			t.Fatalf("expected at most %d concurrent tasks, got %d", limit, p)
+		}
+	})
+
+	t.Run("stops creating tasks on cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		var created atomic.Int32
+		err := Generate(ctx, 1_000_000, 2, func(i int) Task[int] {
+			created.Add(1)
+			return func(ctx context.Context) (int, error) {
+				time.Sleep(time.Millisecond)
+				return i, nil
+			}
+		}, func(i int, r Result[int]) {
+			if i == 10 {
+				cancel()
+			}
+		})
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+		if c := created.Load(); c > 100 {
+			t.Fatalf("expected generation to stop soon after cancellation, created %d", c)
+		}
+	})
+
+	t.Run("no tasks", func(t *testing.T) {
+		err := Generate(ctx, 0, 1, func(i int) Task[int] { return nil }, func(int, Result[int]) {})
+		if !errors.Is(err, ErrNoTasks) {
+			t.Fatalf("expected ErrNoTasks, got %v", err)
+		}
+	})
+}
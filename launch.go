@@ -0,0 +1,61 @@
+package await
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// launchOrder returns the order in which Any starts n tasks according to
+// LaunchOrder or LaunchWeights, or nil for argument order.
+func launchOrder(opts Options, n int) ([]int, error) {
+	switch {
+	case opts.LaunchOrder != nil && opts.LaunchWeights != nil:
+		return nil, fmt.Errorf("%w: LaunchOrder and LaunchWeights are mutually exclusive", ErrInvalidLaunchOrder)
+
+	case opts.LaunchOrder != nil:
+		if len(opts.LaunchOrder) != n {
+			return nil, fmt.Errorf("%w: %d entries for %d tasks", ErrInvalidLaunchOrder, len(opts.LaunchOrder), n)
+		}
+		seen := make([]bool, n)
+		for _, i := range opts.LaunchOrder {
+			if i < 0 || i >= n || seen[i] {
+				return nil, fmt.Errorf("%w: %v is not a permutation of task indices", ErrInvalidLaunchOrder, opts.LaunchOrder)
+			}
+			seen[i] = true
+		}
+		return opts.LaunchOrder, nil
+
+	case opts.LaunchWeights != nil:
+		if len(opts.LaunchWeights) != n {
+			return nil, fmt.Errorf("%w: %d weights for %d tasks", ErrInvalidLaunchOrder, len(opts.LaunchWeights), n)
+		}
+		for _, w := range opts.LaunchWeights {
+			if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+				return nil, fmt.Errorf("%w: weights must be finite and non-negative, got %v", ErrInvalidLaunchOrder, w)
+			}
+		}
+		return weightedOrder(opts.LaunchWeights), nil
+	}
+	return nil, nil
+}
+
+// weightedOrder draws a random order without replacement in which each index
+// is picked next with probability proportional to its weight
+// (Efraimidis-Spirakis). Zero-weight indices go last, in argument order.
+func weightedOrder(weights []float64) []int {
+	keys := make([]float64, len(weights))
+	order := make([]int, len(weights))
+	for i, w := range weights {
+		order[i] = i
+		keys[i] = -1
+		if w > 0 {
+			keys[i] = math.Pow(rand.Float64(), 1/w)
+		}
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return keys[order[a]] > keys[order[b]]
+	})
+	return order
+}
@@ -0,0 +1,92 @@
+package await
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLaunchOrder(t *testing.T) {
+	ctx := context.Background()
+
+	recorder := func(ran *[]int, mu *sync.Mutex, n int) []Task[int] {
+		tasks := make([]Task[int], n)
+		for i := range tasks {
+			i := i
+			tasks[i] = func(ctx context.Context) (int, error) {
+				mu.Lock()
+				*ran = append(*ran, i)
+				mu.Unlock()
+				return 0, errors.New("down")
+			}
+		}
+		return tasks
+	}
+
+	t.Run("sequential", func(t *testing.T) {
+		var mu sync.Mutex
+		var ran []int
+		_, err := AnyWithOptions(ctx, Options{Sequential: true, LaunchOrder: []int{2, 0, 1}}, recorder(&ran, &mu, 3)...)
+		if !reflect.DeepEqual(ran, []int{2, 0, 1}) {
+			t.Fatalf("expected tasks to run in order [2 0 1], got %v", ran)
+		}
+		var aggErr *AggregateError
+		if !errors.As(err, &aggErr) || !reflect.DeepEqual(aggErr.Indices, []int{2, 0, 1}) {
+			t.Fatalf("expected errors indexed by argument position, got %v", err)
+		}
+	})
+
+	t.Run("staggered preferred task wins", func(t *testing.T) {
+		var started atomic.Int32
+		task := func(v int) Task[int] {
+			return func(ctx context.Context) (int, error) {
+				started.Add(1)
+				return v, nil
+			}
+		}
+		val, err := AnyWithOptions(ctx, Options{StaggerDelay: 50 * time.Millisecond, LaunchOrder: []int{1, 0}},
+			task(1), task(2))
+		if err != nil || val != 2 {
+			t.Fatalf("expected preferred task to win with 2, got %d, %v", val, err)
+		}
+		if n := started.Load(); n != 1 {
+			t.Fatalf("expected only the preferred task to start, got %d", n)
+		}
+	})
+
+	t.Run("weights", func(t *testing.T) {
+		var mu sync.Mutex
+		var ran []int
+		AnyWithOptions(ctx, Options{Sequential: true, LaunchWeights: []float64{0, 1, 0}}, recorder(&ran, &mu, 3)...)
+		if !reflect.DeepEqual(ran, []int{1, 0, 2}) {
+			t.Fatalf("expected the only weighted task first, then zero weights in order, got %v", ran)
+		}
+
+		first := make([]int, 2)
+		for i := 0; i < 1000; i++ {
+			first[weightedOrder([]float64{9, 1})[0]]++
+		}
+		if first[0] < 800 || first[1] == 0 {
+			t.Fatalf("expected roughly 90/10 split of first picks, got %v", first)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		task := func(ctx context.Context) (int, error) { return 1, nil }
+		for name, opts := range map[string]Options{
+			"short":     {LaunchOrder: []int{0}},
+			"duplicate": {LaunchOrder: []int{0, 0}},
+			"range":     {LaunchOrder: []int{0, 2}},
+			"weights":   {LaunchWeights: []float64{1, -1}},
+			"both":      {LaunchOrder: []int{0, 1}, LaunchWeights: []float64{1, 1}},
+		} {
+			if _, err := AnyWithOptions(ctx, opts, task, task); !errors.Is(err, ErrInvalidLaunchOrder) {
+				t.Errorf("%s: expected ErrInvalidLaunchOrder, got %v", name, err)
+			}
+		}
+	})
+}
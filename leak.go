@@ -0,0 +1,72 @@
+package await
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// LeakedTask describes a task from an Any or Race call that was still running
+// well after the call returned, typically because it ignores its context.
+type LeakedTask struct {
+	Call    string        // "Any" or "Race"
+	Index   int           // Position of the task in the argument list
+	Tasks   int           // Number of tasks passed to the call
+	Elapsed time.Duration // Time since the call returned
+}
+
+type leakDetector struct {
+	after  time.Duration
+	report func(LeakedTask)
+}
+
+var leaks atomic.Pointer[leakDetector]
+
+// goroutines counts the goroutines started by All, Any and Race that have not
+// yet exited: task runners, staggered launchers and LoserGrace timers. Every
+// path through those calls must bring it back down once the tasks return, and
+// the tests check that it does.
+var goroutines atomic.Int64
+
+// SetLeakDetector enables a debug mode in which Any and Race keep track of the
+// tasks they started and call report for each one still running after the
+// call has been returned for longer than after. Calls already in flight are
+// not affected. Pass a nil report to disable detection again.
+//
+// With Options.LoserGrace, set after longer than the grace period so losers
+// that are allowed to run are not reported. Detection adds a lock-protected
+// flag per task and a timer per call, so it is meant for tests and debugging.
+func SetLeakDetector(after time.Duration, report func(LeakedTask)) {
+	if report == nil {
+		leaks.Store(nil)
+		return
+	}
+	leaks.Store(&leakDetector{after: after, report: report})
+}
+
+// watchLeaks reports the tasks of a settled call that are still running once
+// the detector's threshold has passed.
+func (st *firstState[T]) watchLeaks(call string) {
+	if st.leaks == nil {
+		return
+	}
+	returned := time.Now()
+	time.AfterFunc(st.leaks.after, func() {
+		st.mu.Lock()
+		var leaked []LeakedTask
+		for i, running := range st.running {
+			if running {
+				leaked = append(leaked, LeakedTask{
+					Call:    call,
+					Index:   i,
+					Tasks:   len(st.running),
+					Elapsed: time.Since(returned),
+				})
+			}
+		}
+		st.mu.Unlock()
+
+		for _, task := range leaked {
+			st.leaks.report(task)
+		}
+	})
+}
@@ -0,0 +1,116 @@
+package await
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSetLeakDetector(t *testing.T) {
+	leaked := make(chan LeakedTask, 4)
+	SetLeakDetector(20*time.Millisecond, func(task LeakedTask) { leaked <- task })
+	t.Cleanup(func() { SetLeakDetector(0, nil) })
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+
+	val, err := Any(context.Background(),
+		func(ctx context.Context) (int, error) {
+			<-started
+			return 1, nil
+		},
+		func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		},
+		func(ctx context.Context) (int, error) {
+			close(started)
+			<-release // ignores cancellation
+			return 0, nil
+		},
+	)
+	if err != nil || val != 1 {
+		t.Fatalf("expected 1, got %d, %v", val, err)
+	}
+
+	select {
+	case task := <-leaked:
+		if task.Call != "Any" || task.Index != 2 || task.Tasks != 3 {
+			t.Fatalf("expected task 2 of Any to leak, got %+v", task)
+		}
+		if task.Elapsed < 20*time.Millisecond {
+			t.Fatalf("expected elapsed >= threshold, got %v", task.Elapsed)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected leaked task to be reported")
+	}
+
+	select {
+	case task := <-leaked:
+		t.Fatalf("expected a single leak, also got %+v", task)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestSetLeakDetectorRaceWellBehaved(t *testing.T) {
+	leaked := make(chan LeakedTask, 1)
+	SetLeakDetector(10*time.Millisecond, func(task LeakedTask) { leaked <- task })
+	t.Cleanup(func() { SetLeakDetector(0, nil) })
+
+	Race(context.Background(),
+		func(ctx context.Context) (int, error) { return 1, nil },
+		func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		},
+	)
+
+	select {
+	case task := <-leaked:
+		t.Fatalf("expected no leaks, got %+v", task)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestNoGoroutineLeaks(t *testing.T) {
+	errFail := errors.New("fail")
+	ok := func(ctx context.Context) (int, error) { return 1, nil }
+	fail := func(ctx context.Context) (int, error) { return 0, errFail }
+	blocking := func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	}
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	calls := map[string]func(){
+		"Any success":   func() { _, _ = Any(context.Background(), blocking, ok, blocking) },
+		"Any all fail":  func() { _, _ = Any(context.Background(), fail, fail, fail) },
+		"Any cancelled": func() { _, _ = Any(cancelled, blocking, blocking) },
+		"Any staggered skip": func() {
+			_, _ = AnyWithOptions(context.Background(), Options{StaggerDelay: time.Hour}, ok, blocking, blocking)
+		},
+		"Any loser grace": func() {
+			_, _ = AnyWithOptions(context.Background(), Options{LoserGrace: time.Millisecond}, ok, blocking)
+		},
+		"Any cancelled mid-collection": func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			time.AfterFunc(time.Millisecond, cancel)
+			_, _ = Any(ctx, blocking, blocking, fail)
+		},
+		"Race": func() { _, _ = Race(context.Background(), blocking, fail) },
+		"All":  func() { _, _ = All(context.Background(), ok, fail, ok) },
+	}
+
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			// Tasks leaked on purpose by other tests may still be running, so
+			// only require the count to fall back to where it started.
+			before := goroutines.Load()
+			call()
+			waitUntil(t, func() bool { return goroutines.Load() <= before })
+		})
+	}
+}
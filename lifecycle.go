@@ -0,0 +1,170 @@
+package await
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HookGracePeriod bounds the cleanup hooks of a Lifecycle whose Shutdown
+// context expired while waiting for tasks, so the hooks still get a chance
+// to release resources.
+const HookGracePeriod = 5 * time.Second
+
+// Lifecycle coordinates graceful shutdown of long-running service tasks.
+// Tasks are started with Go and receive a context that is cancelled by Shutdown;
+// cleanup hooks registered with OnShutdown run after the tasks have stopped.
+type Lifecycle struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu      sync.Mutex
+	running map[string]int
+	errs    []error
+	hooks   []shutdownHook
+	stopped bool
+
+	wg sync.WaitGroup
+}
+
+type shutdownHook struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+// NewLifecycle creates a Lifecycle whose tasks run under a context derived from ctx.
+func NewLifecycle(ctx context.Context) *Lifecycle {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Lifecycle{
+		ctx:     ctx,
+		cancel:  cancel,
+		running: make(map[string]int),
+	}
+}
+
+// Go starts a named long-running task. The task should return once its context is done.
+// Errors other than context cancellation are reported by Shutdown.
+// Returns ErrLifecycleStopped if Shutdown has already been called.
+func (l *Lifecycle) Go(name string, fn func(ctx context.Context) error) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.stopped {
+		return ErrLifecycleStopped
+	}
+
+	l.running[name]++
+	l.wg.Add(1)
+	go func() {
+		defer l.wg.Done()
+		err := fn(l.ctx)
+
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if l.running[name]--; l.running[name] == 0 {
+			delete(l.running, name)
+		}
+		if err != nil && !errors.Is(err, context.Canceled) {
+			l.errs = append(l.errs, fmt.Errorf("task %s: %w", name, err))
+		}
+	}()
+	return nil
+}
+
+// OnShutdown registers a cleanup hook. Hooks run after tasks have stopped,
+// in reverse registration order, and receive the context passed to Shutdown,
+// or one bounded by HookGracePeriod if that context has already expired.
+func (l *Lifecycle) OnShutdown(name string, fn func(ctx context.Context) error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.hooks = append(l.hooks, shutdownHook{name: name, fn: fn})
+}
+
+// Running returns the sorted names of tasks that have not yet returned.
+func (l *Lifecycle) Running() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.runningLocked()
+}
+
+func (l *Lifecycle) runningLocked() []string {
+	names := make([]string, 0, len(l.running))
+	for name := range l.running {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Shutdown cancels all tasks, waits for them to return until ctx is done,
+// then runs the cleanup hooks with what is left of ctx. If ctx expired while
+// waiting, the hooks get a fresh context bounded by HookGracePeriod. Returns
+// a ShutdownError listing tasks that failed to stop in time and any task or
+// hook errors, or nil on a clean shutdown.
+func (l *Lifecycle) Shutdown(ctx context.Context) error {
+	l.mu.Lock()
+	l.stopped = true
+	hooks := l.hooks
+	l.hooks = nil
+	l.mu.Unlock()
+
+	l.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		l.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+
+	l.mu.Lock()
+	unstopped := l.runningLocked()
+	errs := append([]error(nil), l.errs...)
+	l.mu.Unlock()
+
+	if ctx.Err() != nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(context.WithoutCancel(ctx), HookGracePeriod)
+		defer cancel()
+	}
+	for i := len(hooks) - 1; i >= 0; i-- {
+		if err := hooks[i].fn(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("cleanup %s: %w", hooks[i].name, err))
+		}
+	}
+
+	if len(unstopped) == 0 && len(errs) == 0 {
+		return nil
+	}
+	return &ShutdownError{Unstopped: unstopped, Errors: errs}
+}
+
+// ShutdownError reports an unclean Lifecycle shutdown.
+type ShutdownError struct {
+	Unstopped []string // Names of tasks still running when the shutdown deadline passed
+	Errors    []error  // Errors returned by tasks and cleanup hooks
+}
+
+// Error returns a message listing unstopped tasks and collected errors.
+func (e *ShutdownError) Error() string {
+	var parts []string
+	if len(e.Unstopped) > 0 {
+		parts = append(parts, fmt.Sprintf("tasks did not stop: [%s]", strings.Join(e.Unstopped, ", ")))
+	}
+	for _, err := range e.Errors {
+		parts = append(parts, err.Error())
+	}
+	return fmt.Sprintf("shutdown incomplete: %s", strings.Join(parts, "; "))
+}
+
+// Unwrap returns the collected errors for use with errors.Is and errors.As.
+func (e *ShutdownError) Unwrap() []error {
+	return e.Errors
+}
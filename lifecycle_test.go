@@ -0,0 +1,121 @@
+package await
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLifecycle(t *testing.T) {
+	t.Run("clean shutdown", func(t *testing.T) {
+		lc := NewLifecycle(context.Background())
+
+		var order []string
+		lc.OnShutdown("db", func(ctx context.Context) error {
+			order = append(order, "db")
+			return nil
+		})
+		lc.OnShutdown("cache", func(ctx context.Context) error {
+			order = append(order, "cache")
+			return nil
+		})
+
+		for _, name := range []string{"server", "worker"} {
+			if err := lc.Go(name, func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			}); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		}
+
+		if running := lc.Running(); len(running) != 2 {
+			t.Fatalf("expected 2 running tasks, got %v", running)
+		}
+
+		if err := lc.Shutdown(context.Background()); err != nil {
+			t.Fatalf("expected clean shutdown, got %v", err)
+		}
+		if len(order) != 2 || order[0] != "cache" || order[1] != "db" {
+			t.Fatalf("expected hooks in reverse order [cache db], got %v", order)
+		}
+	})
+
+	t.Run("reports tasks that fail to stop", func(t *testing.T) {
+		lc := NewLifecycle(context.Background())
+		release := make(chan struct{})
+		defer close(release)
+
+		lc.Go("stubborn", func(ctx context.Context) error {
+			<-release
+			return nil
+		})
+		lc.Go("polite", func(ctx context.Context) error {
+			<-ctx.Done()
+			return nil
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		err := lc.Shutdown(ctx)
+		var shutdownErr *ShutdownError
+		if !errors.As(err, &shutdownErr) {
+			t.Fatalf("expected ShutdownError, got %v", err)
+		}
+		if len(shutdownErr.Unstopped) != 1 || shutdownErr.Unstopped[0] != "stubborn" {
+			t.Fatalf("expected [stubborn] unstopped, got %v", shutdownErr.Unstopped)
+		}
+	})
+
+	t.Run("hooks run after a slow stop", func(t *testing.T) {
+		lc := NewLifecycle(context.Background())
+		release := make(chan struct{})
+		defer close(release)
+
+		lc.Go("slow", func(ctx context.Context) error {
+			<-release
+			return nil
+		})
+		var hookErr error
+		var hasDeadline bool
+		lc.OnShutdown("flush", func(ctx context.Context) error {
+			hookErr = ctx.Err()
+			_, hasDeadline = ctx.Deadline()
+			return nil
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		lc.Shutdown(ctx)
+		if hookErr != nil || !hasDeadline {
+			t.Fatalf("expected hook to get a live bounded context, got err %v, deadline %v", hookErr, hasDeadline)
+		}
+	})
+
+	t.Run("collects task and hook errors", func(t *testing.T) {
+		lc := NewLifecycle(context.Background())
+		taskErr := errors.New("listener crashed")
+		hookErr := errors.New("flush failed")
+
+		lc.Go("server", func(ctx context.Context) error { return taskErr })
+		lc.OnShutdown("flush", func(ctx context.Context) error { return hookErr })
+
+		err := lc.Shutdown(context.Background())
+		if !errors.Is(err, taskErr) || !errors.Is(err, hookErr) {
+			t.Fatalf("expected task and hook errors, got %v", err)
+		}
+	})
+
+	t.Run("go after shutdown", func(t *testing.T) {
+		lc := NewLifecycle(context.Background())
+		lc.Shutdown(context.Background())
+
+		err := lc.Go("late", func(ctx context.Context) error { return nil })
+		if err != ErrLifecycleStopped {
+			t.Fatalf("expected ErrLifecycleStopped, got %v", err)
+		}
+	})
+}
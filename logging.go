@@ -0,0 +1,78 @@
+package await
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+)
+
+// Attribute keys used for structured log events emitted through SetLogger.
+const (
+	LogKeyTasks    = "tasks"
+	LogKeyFailures = "failures"
+	LogKeyError    = "error"
+	LogKeyFailed   = "failed_tasks"
+)
+
+var logger atomic.Pointer[slog.Logger]
+
+// SetLogger sets the package-level logger used to report task failures from All
+// and Reduce and aggregate failures from Any, identifying tasks by their Named
+// names.
+// Logging is disabled by default; pass nil to disable it again.
+func SetLogger(l *slog.Logger) {
+	logger.Store(l)
+}
+
+func logAggregateFailure(ctx context.Context, tasks int, err *AggregateError, failed []*taskInfo) {
+	l := logger.Load()
+	if l == nil {
+		return
+	}
+	l.LogAttrs(ctx, slog.LevelWarn, "all tasks failed",
+		slog.Int(LogKeyTasks, tasks),
+		slog.Int(LogKeyFailures, len(err.Errors)),
+		slog.Any(LogKeyFailed, taskLabels(failed)),
+		slog.String(LogKeyError, err.Error()),
+	)
+}
+
+func logAllFailures[T any](ctx context.Context, results []Result[T], infos []*taskInfo) {
+	l := logger.Load()
+	if l == nil {
+		return
+	}
+
+	var failed []*taskInfo
+	for i, r := range results {
+		if r.Err != nil {
+			failed = append(failed, infos[i])
+		}
+	}
+	logTaskFailures(ctx, l, len(results), failed)
+}
+
+// logTaskFailures reports the failed tasks of a call that ran tasks tasks,
+// such as All or Reduce, if any failed.
+func logTaskFailures(ctx context.Context, l *slog.Logger, tasks int, failed []*taskInfo) {
+	if len(failed) == 0 {
+		return
+	}
+	l.LogAttrs(ctx, slog.LevelWarn, "tasks failed",
+		slog.Int(LogKeyTasks, tasks),
+		slog.Int(LogKeyFailures, len(failed)),
+		slog.Any(LogKeyFailed, taskLabels(failed)),
+	)
+}
+
+// taskLabels returns the labels of tracked tasks. Entries are nil if the logger
+// was installed while the tasks were already running.
+func taskLabels(infos []*taskInfo) []string {
+	labels := make([]string, 0, len(infos))
+	for _, info := range infos {
+		if info != nil {
+			labels = append(labels, info.label())
+		}
+	}
+	return labels
+}
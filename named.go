@@ -0,0 +1,134 @@
+package await
+
+import (
+	"context"
+	"runtime/pprof"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// taskInfo carries a task's identity through its context.
+type taskInfo struct {
+	index int
+	name  string
+
+	// Recorded with Options.Timing.
+	started  time.Time
+	duration time.Duration
+	attempt  atomic.Int32
+
+	// claimed is set by the first Named wrapper to run with this info, which
+	// names the tracked task. parent links the copy each Named wrapper gives
+	// its own task back to the tracked info.
+	claimed atomic.Bool
+	parent  *taskInfo
+}
+
+type taskInfoKey struct{}
+
+// label returns the task's name, or "task N" for unnamed tasks.
+func (i *taskInfo) label() string {
+	if i.name != "" {
+		return i.name
+	}
+	return "task " + strconv.Itoa(i.index)
+}
+
+// trackingEnabled reports whether task identity is needed, either for error
+// wrapping, timing, log events, event bus subscribers or stats.
+func trackingEnabled(opts Options) bool {
+	return opts.TaskErrors || opts.Timing || logger.Load() != nil || instrumented()
+}
+
+func taskInfoFrom(ctx context.Context) *taskInfo {
+	info, _ := ctx.Value(taskInfoKey{}).(*taskInfo)
+	return info
+}
+
+// Named attaches a human-readable name to a task. The name is available to the
+// task through TaskName, is reported in TaskError when error wrapping is enabled,
+// identifies the task in log events emitted through SetLogger, and lets a
+// CancelGroup cancel the task by name.
+//
+// While the task runs, its goroutine carries the pprof label
+// await_task=name, so CPU and goroutine profiles of All, Any, Race and Pool
+// fan-outs attribute time to the task. Goroutines the task starts inherit the
+// label.
+func Named[T any](name string, task Task[T]) Task[T] {
+	return func(ctx context.Context) (T, error) {
+		// The task runs with its own copy of the tracked info, so Named tasks
+		// nested inside it never write to info shared with their siblings.
+		cp := &taskInfo{index: -1, name: name}
+		cp.claimed.Store(true)
+		if info := taskInfoFrom(ctx); info != nil {
+			if info.claimed.CompareAndSwap(false, true) {
+				info.name = name
+			}
+			cp.index, cp.parent = info.index, info
+		}
+		ctx = context.WithValue(ctx, taskInfoKey{}, cp)
+		if g := cancelGroupFrom(ctx); g != nil {
+			var done func()
+			ctx, done = g.register(ctx, name)
+			defer done()
+		}
+
+		var val T
+		var err error
+		pprof.Do(ctx, pprof.Labels(pprofTaskLabel, name), func(ctx context.Context) {
+			val, err = task(ctx)
+		})
+		return val, err
+	}
+}
+
+// pprofTaskLabel is the pprof label key set on the goroutines of Named tasks.
+const pprofTaskLabel = "await_task"
+
+// TaskName returns the name given to the running task with Named, or "" if it has none.
+func TaskName(ctx context.Context) string {
+	if info := taskInfoFrom(ctx); info != nil {
+		return info.name
+	}
+	return ""
+}
+
+// runTask executes a task, attaching its identity when info is non-nil and
+// wrapping its error according to opts.
+func runTask[T any](ctx context.Context, opts Options, info *taskInfo, task Task[T]) (T, error) {
+	if opts.Subtasks {
+		task = withSubtasks(task)
+	}
+	if info == nil {
+		val, err := task(ctx)
+		return validateResult(opts.Validate, val, err)
+	}
+
+	if statsEnabled.Load() {
+		inFlight.Add(1)
+		defer inFlight.Add(-1)
+	}
+
+	publishing := Subscribed()
+	var started time.Time
+	if opts.Timing || publishing {
+		started = time.Now()
+		info.started = started
+	}
+	if publishing {
+		Publish(TaskStarted{Time: started, Index: info.index})
+	}
+	val, err := task(context.WithValue(ctx, taskInfoKey{}, info))
+	if opts.Timing || publishing {
+		info.duration = time.Since(started)
+	}
+	val, err = validateResult(opts.Validate, val, err)
+	if publishing {
+		Publish(TaskFinished{Time: time.Now(), Index: info.index, Name: info.name, Duration: info.duration, Err: err})
+	}
+	if err != nil && opts.TaskErrors {
+		err = &TaskError{Index: info.index, Name: info.name, Err: err}
+	}
+	return val, err
+}
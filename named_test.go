@@ -0,0 +1,199 @@
+package await
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime/pprof"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTaskErrors(t *testing.T) {
+	opts := Options{TaskErrors: true}
+
+	t.Run("All wraps errors with index and name", func(t *testing.T) {
+		ok := Task[int](func(ctx context.Context) (int, error) { return 1, nil })
+		fail := Named("fetch-user", Task[int](func(ctx context.Context) (int, error) {
+			return 0, errors.New("not found")
+		}))
+		anon := Task[int](func(ctx context.Context) (int, error) {
+			return 0, errors.New("boom")
+		})
+
+		results, err := AllWithOptions(context.Background(), opts, ok, fail, anon)
+		if err != nil {
+			t.Fatalf("expected no function error, got %v", err)
+		}
+		if results[0].Err != nil {
+			t.Fatalf("expected results[0] to succeed, got %v", results[0].Err)
+		}
+
+		var taskErr *TaskError
+		if !errors.As(results[1].Err, &taskErr) {
+			t.Fatalf("expected TaskError, got %T", results[1].Err)
+		}
+		if taskErr.Index != 1 || taskErr.Name != "fetch-user" {
+			t.Fatalf("expected index 1 named fetch-user, got %d/%q", taskErr.Index, taskErr.Name)
+		}
+		if results[1].Err.Error() != "task 1 (fetch-user): not found" {
+			t.Fatalf("unexpected message %q", results[1].Err.Error())
+		}
+		if results[2].Err.Error() != "task 2: boom" {
+			t.Fatalf("unexpected message %q", results[2].Err.Error())
+		}
+	})
+
+	t.Run("timeout classification", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		slow := Named("slow", Task[int](func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		}))
+
+		_, err := RaceWithOptions(ctx, opts, slow)
+		var taskErr *TaskError
+		if !errors.As(err, &taskErr) || !taskErr.Timeout() || taskErr.Canceled() {
+			t.Fatalf("expected timeout TaskError, got %v", err)
+		}
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected error to unwrap to DeadlineExceeded, got %v", err)
+		}
+	})
+
+	t.Run("Any aggregates TaskErrors", func(t *testing.T) {
+		fail := Task[int](func(ctx context.Context) (int, error) { return 0, errors.New("down") })
+
+		_, err := AnyWithOptions(context.Background(), opts, fail, Named("b", fail))
+		var taskErr *TaskError
+		if !errors.As(err, &taskErr) {
+			t.Fatalf("expected AggregateError containing TaskError, got %v", err)
+		}
+	})
+
+	t.Run("nested Named tasks keep their own names", func(t *testing.T) {
+		outer := Named("outer", Task[int](func(ctx context.Context) (int, error) {
+			var wg sync.WaitGroup
+			names := make([]string, 8)
+			for i := range names {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					name := "inner-" + strconv.Itoa(i)
+					Named(name, Task[int](func(ctx context.Context) (int, error) {
+						names[i] = TaskName(ctx)
+						return 0, nil
+					}))(ctx)
+				}(i)
+			}
+			wg.Wait()
+			for i, name := range names {
+				if want := "inner-" + strconv.Itoa(i); name != want {
+					return 0, fmt.Errorf("expected %q, got %q", want, name)
+				}
+			}
+			if name := TaskName(ctx); name != "outer" {
+				return 0, fmt.Errorf("expected outer, got %q", name)
+			}
+			return 0, errors.New("down")
+		}))
+
+		results, _ := AllWithOptions(context.Background(), opts, outer)
+		var taskErr *TaskError
+		if !errors.As(results[0].Err, &taskErr) {
+			t.Fatalf("expected TaskError, got %v", results[0].Err)
+		}
+		if taskErr.Name != "outer" || taskErr.Err.Error() != "down" {
+			t.Fatalf("expected outer task to fail with down, got %v", taskErr)
+		}
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		fail := Named("x", Task[int](func(ctx context.Context) (int, error) { return 0, errors.New("down") }))
+		results, _ := All(context.Background(), fail)
+		var taskErr *TaskError
+		if errors.As(results[0].Err, &taskErr) {
+			t.Fatal("expected plain error without TaskErrors option")
+		}
+	})
+}
+
+func TestTaskName(t *testing.T) {
+	var seen string
+	task := Named("lookup", Task[int](func(ctx context.Context) (int, error) {
+		seen = TaskName(ctx)
+		return 0, nil
+	}))
+
+	if _, err := task(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if seen != "lookup" {
+		t.Fatalf("expected name 'lookup', got %q", seen)
+	}
+	if TaskName(context.Background()) != "" {
+		t.Fatal("expected empty name outside a named task")
+	}
+}
+
+func TestNamedPprofLabels(t *testing.T) {
+	labels := make(chan string, 2)
+	task := func(name string) Task[int] {
+		return Named(name, func(ctx context.Context) (int, error) {
+			label, _ := pprof.Label(ctx, "await_task")
+			labels <- label
+			return 0, nil
+		})
+	}
+
+	if _, err := All(context.Background(), task("cvl"), task("ndml")); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	got := map[string]bool{<-labels: true, <-labels: true}
+	if !got["cvl"] || !got["ndml"] {
+		t.Fatalf("expected await_task labels cvl and ndml, got %v", got)
+	}
+}
+
+func TestNamedLogging(t *testing.T) {
+	var buf bytes.Buffer
+	SetLogger(slog.New(slog.NewJSONHandler(&buf, nil)))
+	defer SetLogger(nil)
+
+	fail := Task[int](func(ctx context.Context) (int, error) { return 0, errors.New("down") })
+	ok := Task[int](func(ctx context.Context) (int, error) { return 1, nil })
+
+	t.Run("All reports failed task names", func(t *testing.T) {
+		buf.Reset()
+		All(context.Background(), Named("users", ok), Named("orders", fail), fail)
+
+		out := buf.String()
+		if !strings.Contains(out, `"msg":"tasks failed"`) || !strings.Contains(out, `"failed_tasks":["orders","task 2"]`) {
+			t.Fatalf("expected failed task labels, got %s", out)
+		}
+	})
+
+	t.Run("Any reports failed task names", func(t *testing.T) {
+		buf.Reset()
+		Any(context.Background(), Named("primary", fail))
+
+		if !strings.Contains(buf.String(), `"failed_tasks":["primary"]`) {
+			t.Fatalf("expected failed task labels, got %s", buf.String())
+		}
+	})
+
+	t.Run("All logs nothing when every task succeeds", func(t *testing.T) {
+		buf.Reset()
+		All(context.Background(), ok)
+		if buf.Len() != 0 {
+			t.Fatalf("expected no log output, got %s", buf.String())
+		}
+	})
+}
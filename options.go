@@ -0,0 +1,91 @@
+package await
+
+import "time"
+
+// Options configures execution behavior for AllWithOptions, AnyWithOptions and RaceWithOptions.
+// The zero value matches the behavior of All, Any and Race.
+type Options struct {
+	TaskErrors bool // Wrap task errors in *TaskError carrying the task's index and name
+	Sequential bool // Run tasks one at a time, in order, on the calling goroutine
+	ErrorLimit int  // AggregateError.Limit for errors returned by Any (0 = DefaultAggregateErrorLimit, < 0 = no limit)
+	Timing     bool // Record when each task ran in Result.Timing (All, Reduce) and AnyReport.Timing (AnyWithReport)
+
+	// MaxConcurrency limits how many tasks All runs at once; the rest wait in
+	// argument order for a running task to finish (0 = no limit). Tasks still
+	// waiting when ctx is done are not started and record an error wrapping
+	// both ErrSkipped and the context's cause. Ignored by Any and Race.
+	MaxConcurrency int
+
+	// Deadlines gives each task of All its own deadline, applied to the task's
+	// context; a zero time means none. One entry per task. With
+	// MaxConcurrency, waiting tasks start earliest deadline first, then tasks
+	// without a deadline in argument order, so time-critical tasks are not
+	// starved behind batch work. Ignored by Any and Race.
+	Deadlines []time.Time
+
+	// AbortAfterFailures cancels the remaining tasks of All once this many
+	// have failed (0 = never), for batch jobs where widespread failure makes
+	// the rest pointless. Running tasks see their context cancelled with a
+	// cause wrapping ErrTooManyFailures, and tasks not yet started record an
+	// error wrapping ErrSkipped and that cause. All still returns every
+	// result, together with the cause as its error. Ignored by Any and Race.
+	AbortAfterFailures int
+
+	// Subtasks lets each task start subtasks with SpawnerFromContext. A task
+	// is only reported done once its subtasks have finished, so All does not
+	// return before them; a failing subtask cancels its task and fails it.
+	Subtasks bool
+
+	// Admission, if set, is consulted once before a call starts any task;
+	// while it reports the process overloaded, every task fails with an error
+	// wrapping ErrOverloaded instead of running.
+	Admission *Admission
+
+	// Validate, if set, checks the value of every task that succeeds; a non-nil
+	// error turns the result into a failure wrapping ErrInvalidResult, so Any
+	// moves on to another task. It receives the task's T; build it with
+	// ValidateAs to avoid the type assertion. Called from the task's goroutine.
+	Validate func(value any) error
+
+	// StaggerDelay starts the tasks of Any and Race one at a time, each this long
+	// after the previous one, instead of all at once: the "backup request"
+	// pattern. In Any a failure starts the next task immediately. Tasks not yet
+	// started when the call settles never run. Ignored by All.
+	StaggerDelay time.Duration
+
+	// LaunchOrder starts the tasks of Any in this order of task indices rather
+	// than argument order; it must be a permutation of 0..len(tasks)-1.
+	// Combined with StaggerDelay or Sequential, preferred tasks get a head start.
+	LaunchOrder []int
+	// LaunchWeights starts the tasks of Any in a random order where each task
+	// is picked next with probability proportional to its weight; zero-weight
+	// tasks start last. One weight per task; exclusive with LaunchOrder.
+	LaunchWeights []float64
+
+	// LoserGrace lets the tasks still running when Any succeeds continue for up
+	// to this long before they are cancelled, instead of cancelling them at
+	// once. Their results are discarded. Ignored by All and Race.
+	LoserGrace time.Duration
+	// OnLoser, if set, is called with the index and error of each task that
+	// finishes after Any has already returned a winner. It is called from the
+	// task's goroutine, serialized with the other OnLoser calls.
+	OnLoser func(index int, err error)
+
+	// DedupErrors merges matching task errors into one AggregateError entry,
+	// keeping the first and counting the rest in AggregateError.Repeats, so
+	// many tasks failing the same way are reported once. Errors match when
+	// SameError reports true or, if it is nil, when either errors.Is the
+	// other. *TaskError wrappers are removed before comparing.
+	DedupErrors bool
+	SameError   func(a, b error) bool
+
+	// OnMismatch cross-checks Any's winner against the tasks that succeed
+	// after it, such as duplicate providers of the same data: it is called
+	// with both task indices and values whenever a value differs from the
+	// winner's, by SameValue or, if that is nil, reflect.DeepEqual. Only
+	// tasks that finish after the winner are checked, so combine it with
+	// LoserGrace to let them run. Called from the task's goroutine,
+	// serialized with OnLoser. Ignored by All and Race.
+	OnMismatch func(winner, index int, want, got any)
+	SameValue  func(a, b any) bool
+}
@@ -0,0 +1,73 @@
+package await
+
+import "context"
+
+// PaginateOptions configures Paginate.
+type PaginateOptions struct {
+	Lookahead int    // Pages fetched ahead of the consumer (0 = 1)
+	Cursor    string // Cursor of the first page ("" = start from the beginning)
+}
+
+// Paginate streams the items of a cursor-paginated API in order. fetchPage
+// returns the items of the page at cursor and the cursor of the next page,
+// or "" after the last page. Pages are fetched in the background while the
+// consumer is still receiving items of earlier pages, keeping up to
+// opts.Lookahead fetched pages buffered, so the consumer rarely waits on the
+// network. Since every cursor comes from the previous page, pages are
+// fetched one at a time.
+//
+// The returned channel is closed after the last item. If a fetch fails, its
+// error is delivered as a final Result with Err set and no more pages are
+// fetched. Once ctx is done, undelivered items are dropped and the channel
+// is closed, so a consumer that stops receiving early must cancel ctx to let
+// the fetching goroutines exit.
+func Paginate[T any](ctx context.Context, fetchPage func(ctx context.Context, cursor string) ([]T, string, error), opts PaginateOptions) <-chan Result[T] {
+	lookahead := opts.Lookahead
+	if lookahead <= 0 {
+		lookahead = 1
+	}
+
+	type page struct {
+		items []T
+		err   error
+	}
+	pages := make(chan page, lookahead)
+	go func() {
+		defer close(pages)
+		cursor := opts.Cursor
+		for {
+			items, next, err := fetchPage(ctx, cursor)
+			select {
+			case pages <- page{items: items, err: err}:
+			case <-ctx.Done():
+				return
+			}
+			if err != nil || next == "" {
+				return
+			}
+			cursor = next
+		}
+	}()
+
+	out := make(chan Result[T])
+	go func() {
+		defer close(out)
+		for p := range pages {
+			if p.err != nil {
+				select {
+				case out <- Result[T]{Err: p.err}:
+				case <-ctx.Done():
+				}
+				return
+			}
+			for _, item := range p.items {
+				select {
+				case out <- Result[T]{Value: item}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out
+}
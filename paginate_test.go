@@ -0,0 +1,99 @@
+package await
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// pagedAPI serves 0..total-1 in pages of size, using the next item as cursor.
+func pagedAPI(total, size int, fetched *atomic.Int32) func(ctx context.Context, cursor string) ([]int, string, error) {
+	return func(ctx context.Context, cursor string) ([]int, string, error) {
+		fetched.Add(1)
+		start := 0
+		if cursor != "" {
+			start, _ = strconv.Atoi(cursor)
+		}
+		var items []int
+		for i := start; i < total && i < start+size; i++ {
+			items = append(items, i)
+		}
+		next := ""
+		if start+size < total {
+			next = strconv.Itoa(start + size)
+		}
+		return items, next, nil
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("delivers items in order", func(t *testing.T) {
+		var fetched atomic.Int32
+		want := 0
+		for r := range Paginate(ctx, pagedAPI(95, 10, &fetched), PaginateOptions{Lookahead: 3}) {
+			if r.Err != nil || r.Value != want {
+				t.Fatalf("expected %d, got %d, %v", want, r.Value, r.Err)
+			}
+			want++
+		}
+		if want != 95 || fetched.Load() != 10 {
+			t.Fatalf("expected 95 items from 10 pages, got %d from %d", want, fetched.Load())
+		}
+	})
+
+	t.Run("bounds lookahead", func(t *testing.T) {
+		var fetched atomic.Int32
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		items := Paginate(ctx, pagedAPI(1000, 10, &fetched), PaginateOptions{Lookahead: 2})
+
+		<-items
+		waitUntil(t, func() bool { return fetched.Load() >= 4 })
+		time.Sleep(20 * time.Millisecond)
+		// The page being delivered, two buffered and one waiting to be buffered.
+		if n := fetched.Load(); n != 4 {
+			t.Fatalf("expected 4 pages fetched ahead of a stalled consumer, got %d", n)
+		}
+	})
+
+	t.Run("starts at cursor", func(t *testing.T) {
+		var fetched atomic.Int32
+		var got []int
+		for r := range Paginate(ctx, pagedAPI(30, 10, &fetched), PaginateOptions{Cursor: "20"}) {
+			got = append(got, r.Value)
+		}
+		if len(got) != 10 || got[0] != 20 {
+			t.Fatalf("expected items 20..29, got %v", got)
+		}
+	})
+
+	t.Run("stops at the first error", func(t *testing.T) {
+		errPage := errors.New("page failed")
+		var fetched atomic.Int32
+		api := pagedAPI(100, 10, &fetched)
+		failing := func(ctx context.Context, cursor string) ([]int, string, error) {
+			if cursor == "20" {
+				return nil, "", errPage
+			}
+			return api(ctx, cursor)
+		}
+
+		var values int
+		var err error
+		for r := range Paginate(ctx, failing, PaginateOptions{}) {
+			if r.Err != nil {
+				err = r.Err
+				continue
+			}
+			values++
+		}
+		if values != 20 || !errors.Is(err, errPage) {
+			t.Fatalf("expected 20 items then the page error, got %d, %v", values, err)
+		}
+	})
+}
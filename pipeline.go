@@ -0,0 +1,124 @@
+package await
+
+import (
+	"context"
+	"sync"
+)
+
+// Stage is a streaming step of a pipeline: it consumes inputs from in and
+// emits one Result per input. The returned channel is closed once in is
+// closed (or ctx is done) and the stage has finished its work.
+type Stage[I, O any] func(ctx context.Context, in <-chan I) <-chan Result[O]
+
+// NewStage returns a Stage that applies fn to its inputs on workers goroutines.
+// See FanOut.
+func NewStage[I, O any](workers int, fn func(ctx context.Context, item I) (O, error)) Stage[I, O] {
+	return func(ctx context.Context, in <-chan I) <-chan Result[O] {
+		return FanOut(ctx, in, workers, fn)
+	}
+}
+
+// Pipe connects two stages. Successful results of first are fed to second;
+// failed results bypass second and are delivered unchanged, so every error
+// in the pipeline reaches the final consumer. Output is unordered.
+func Pipe[A, B, C any](first Stage[A, B], second Stage[B, C]) Stage[A, C] {
+	return func(ctx context.Context, in <-chan A) <-chan Result[C] {
+		values := make(chan B)
+		failures := make(chan Result[C])
+
+		go func() {
+			defer close(values)
+			defer close(failures)
+			for r := range first(ctx, in) {
+				if r.Err != nil {
+					select {
+					case failures <- Result[C]{Err: r.Err}:
+					case <-ctx.Done():
+					}
+					continue
+				}
+				select {
+				case values <- r.Value:
+				case <-ctx.Done():
+				}
+			}
+		}()
+
+		return FanIn(ctx, second(ctx, values), failures)
+	}
+}
+
+// FanOut applies fn to every item received from in using workers goroutines
+// and delivers each outcome on the returned channel in completion order.
+// A workers value <= 0 runs a single worker. The returned channel is closed
+// once in is closed (or ctx is done) and every worker has returned. After ctx
+// is done no new items are read, and results nobody receives are dropped.
+func FanOut[I, O any](ctx context.Context, in <-chan I, workers int, fn func(ctx context.Context, item I) (O, error)) <-chan Result[O] {
+	if workers <= 0 {
+		workers = 1
+	}
+	out := make(chan Result[O])
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for {
+				var item I
+				var ok bool
+				select {
+				case <-ctx.Done():
+					return
+				case item, ok = <-in:
+					if !ok {
+						return
+					}
+				}
+
+				val, err := fn(ctx, item)
+				select {
+				case out <- Result[O]{Value: val, Err: err}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
+
+// FanIn merges result channels into one, delivering results as they arrive.
+// The returned channel is closed once every input channel is closed, or ctx
+// is done and the forwarding goroutines have stopped.
+func FanIn[T any](ctx context.Context, chs ...<-chan Result[T]) <-chan Result[T] {
+	out := make(chan Result[T])
+
+	var wg sync.WaitGroup
+	wg.Add(len(chs))
+	for _, ch := range chs {
+		go func(ch <-chan Result[T]) {
+			defer wg.Done()
+			for r := range ch {
+				select {
+				case out <- r:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(ch)
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
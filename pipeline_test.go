@@ -0,0 +1,117 @@
+package await
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func feed[T any](items ...T) <-chan T {
+	ch := make(chan T, len(items))
+	for _, item := range items {
+		ch <- item
+	}
+	close(ch)
+	return ch
+}
+
+func TestFanOut(t *testing.T) {
+	t.Run("processes every item", func(t *testing.T) {
+		var running, maxRunning atomic.Int32
+		out := FanOut(context.Background(), feed(1, 2, 3, 4, 5, 6), 3, func(ctx context.Context, n int) (int, error) {
+			cur := running.Add(1)
+			for {
+				m := maxRunning.Load()
+				if cur <= m || maxRunning.CompareAndSwap(m, cur) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			running.Add(-1)
+			return n * n, nil
+		})
+
+		sum := 0
+		for r := range out {
+			if r.Err != nil {
+				t.Fatalf("expected no error, got %v", r.Err)
+			}
+			sum += r.Value
+		}
+		if sum != 91 {
+			t.Fatalf("expected sum of squares 91, got %d", sum)
+		}
+		if m := maxRunning.Load(); m > 3 {
+			t.Fatalf("expected at most 3 workers, got %d", m)
+		}
+	})
+
+	t.Run("stops on cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		in := make(chan int) // never closed
+		out := FanOut(ctx, in, 2, func(ctx context.Context, n int) (int, error) { return n, nil })
+		cancel()
+
+		select {
+		case _, ok := <-out:
+			if ok {
+				t.Fatal("expected no results")
+			}
+		case <-time.After(time.Second):
+			t.Fatal("expected output to close after cancellation")
+		}
+	})
+}
+
+func TestFanIn(t *testing.T) {
+	a := feed(Result[int]{Value: 1}, Result[int]{Value: 2})
+	b := feed(Result[int]{Err: errors.New("failed")})
+
+	var values []int
+	failures := 0
+	for r := range FanIn(context.Background(), a, b) {
+		if r.Err != nil {
+			failures++
+			continue
+		}
+		values = append(values, r.Value)
+	}
+	sort.Ints(values)
+	if len(values) != 2 || values[0] != 1 || values[1] != 2 || failures != 1 {
+		t.Fatalf("expected [1 2] and 1 failure, got %v and %d", values, failures)
+	}
+}
+
+func TestPipe(t *testing.T) {
+	errOdd := errors.New("odd")
+	parse := NewStage(2, func(ctx context.Context, s string) (int, error) {
+		return strconv.Atoi(s)
+	})
+	evens := NewStage(2, func(ctx context.Context, n int) (int, error) {
+		if n%2 != 0 {
+			return 0, errOdd
+		}
+		return n * 10, nil
+	})
+
+	var values []int
+	var errs []error
+	for r := range Pipe(parse, evens)(context.Background(), feed("2", "x", "3", "4")) {
+		if r.Err != nil {
+			errs = append(errs, r.Err)
+			continue
+		}
+		values = append(values, r.Value)
+	}
+	sort.Ints(values)
+	if len(values) != 2 || values[0] != 20 || values[1] != 40 {
+		t.Fatalf("expected [20 40], got %v", values)
+	}
+	if len(errs) != 2 {
+		t.Fatalf("expected parse and odd errors, got %v", errs)
+	}
+}
@@ -0,0 +1,109 @@
+// Package poll repeatedly checks the status of a long-running operation until
+// it reports completion, such as a cloud API "operation" resource. Unlike
+// retry, which stops at the first success, Poll keeps going while the
+// operation is pending and only stops when check reports it is done, fails,
+// or the time budget runs out.
+package poll
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/remiges-tech/await/retry"
+)
+
+var (
+	// ErrInvalidConfig is returned when a Config has no usable interval or an
+	// out-of-range jitter.
+	ErrInvalidConfig = errors.New("invalid poll configuration")
+
+	// ErrTimeout is returned when MaxDuration passes before the operation is done.
+	ErrTimeout = errors.New("poll timed out")
+)
+
+// Config controls the pacing and duration of a Poll.
+type Config struct {
+	Interval        time.Duration    // Fixed delay between checks, used when Strategy is nil
+	Strategy        retry.Strategy   // Optional backoff between checks; NextDelay receives the 1-based check number
+	MaxDuration     time.Duration    // Overall time budget (0 = until ctx is done)
+	Jitter          float64          // Randomizes each delay by up to ±Jitter of its length (0 to 1)
+	ContinueOnError func(error) bool // Optional: keep polling after check errors it accepts
+}
+
+// Validate reports whether the config can be used with Poll.
+func (c Config) Validate() error {
+	if c.Strategy == nil && c.Interval <= 0 {
+		return fmt.Errorf("%w: interval must be positive when no strategy is set", ErrInvalidConfig)
+	}
+	if c.Jitter < 0 || c.Jitter > 1 {
+		return fmt.Errorf("%w: jitter must be between 0 and 1, got %v", ErrInvalidConfig, c.Jitter)
+	}
+	return nil
+}
+
+// Poll calls check immediately and then after each delay until check reports
+// done, returns an error not accepted by ContinueOnError, or the time budget
+// runs out. It returns the value from the last check that produced one, so
+// callers can report progress even on failure. When MaxDuration passes, the
+// error wraps ErrTimeout; when ctx is done first, it is ctx's error.
+func Poll[T any](ctx context.Context, config Config, check func(ctx context.Context) (T, bool, error)) (T, error) {
+	var last T
+	if err := config.Validate(); err != nil {
+		return last, err
+	}
+
+	pollCtx := ctx
+	if config.MaxDuration > 0 {
+		var cancel context.CancelFunc
+		pollCtx, cancel = context.WithTimeout(ctx, config.MaxDuration)
+		defer cancel()
+	}
+
+	for attempt := 1; ; attempt++ {
+		val, done, err := check(pollCtx)
+		switch {
+		case err == nil:
+			last = val
+			if done {
+				return last, nil
+			}
+		case pollCtx.Err() != nil:
+			return last, budgetErr(ctx, config, attempt)
+		case config.ContinueOnError == nil || !config.ContinueOnError(err):
+			return last, err
+		}
+
+		timer := time.NewTimer(config.delay(attempt))
+		select {
+		case <-pollCtx.Done():
+			timer.Stop()
+			return last, budgetErr(ctx, config, attempt)
+		case <-timer.C:
+		}
+	}
+}
+
+// budgetErr explains why polling stopped once the poll context is done:
+// the caller's context error, or ErrTimeout if MaxDuration ran out.
+func budgetErr(ctx context.Context, config Config, checks int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return fmt.Errorf("%w after %v (%d checks)", ErrTimeout, config.MaxDuration, checks)
+}
+
+// delay returns the jittered wait before the check following attempt.
+func (c Config) delay(attempt int) time.Duration {
+	d := c.Interval
+	if c.Strategy != nil {
+		d = c.Strategy.NextDelay(attempt)
+	}
+	if c.Jitter > 0 && d > 0 {
+		spread := float64(d) * c.Jitter
+		d += time.Duration(spread * (2*rand.Float64() - 1))
+	}
+	return d
+}
@@ -0,0 +1,108 @@
+package poll
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/remiges-tech/await/retry"
+)
+
+func TestPoll(t *testing.T) {
+	t.Run("keeps going while pending", func(t *testing.T) {
+		checks := 0
+		progress, err := Poll(context.Background(), Config{Interval: time.Millisecond}, func(ctx context.Context) (int, bool, error) {
+			checks++
+			return checks * 25, checks == 4, nil
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if progress != 100 || checks != 4 {
+			t.Fatalf("expected 100%% after 4 checks, got %d after %d", progress, checks)
+		}
+	})
+
+	t.Run("stops on error", func(t *testing.T) {
+		errFailed := errors.New("operation failed")
+		checks := 0
+		last, err := Poll(context.Background(), Config{Interval: time.Millisecond}, func(ctx context.Context) (int, bool, error) {
+			checks++
+			if checks == 2 {
+				return 0, false, errFailed
+			}
+			return checks, false, nil
+		})
+		if err != errFailed {
+			t.Fatalf("expected operation error, got %v", err)
+		}
+		if last != 1 {
+			t.Fatalf("expected last value from check 1, got %d", last)
+		}
+	})
+
+	t.Run("continues on accepted errors", func(t *testing.T) {
+		errThrottled := errors.New("throttled")
+		checks := 0
+		_, err := Poll(context.Background(), Config{
+			Interval:        time.Millisecond,
+			ContinueOnError: func(err error) bool { return errors.Is(err, errThrottled) },
+		}, func(ctx context.Context) (int, bool, error) {
+			checks++
+			if checks < 3 {
+				return 0, false, errThrottled
+			}
+			return 1, true, nil
+		})
+		if err != nil || checks != 3 {
+			t.Fatalf("expected success after 3 checks, got %v after %d", err, checks)
+		}
+	})
+
+	t.Run("max duration", func(t *testing.T) {
+		_, err := Poll(context.Background(), Config{
+			Interval:    5 * time.Millisecond,
+			MaxDuration: 30 * time.Millisecond,
+		}, func(ctx context.Context) (int, bool, error) {
+			return 0, false, nil
+		})
+		if !errors.Is(err, ErrTimeout) {
+			t.Fatalf("expected ErrTimeout, got %v", err)
+		}
+	})
+
+	t.Run("parent cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		_, err := Poll(ctx, Config{Interval: time.Millisecond, MaxDuration: time.Minute}, func(ctx context.Context) (int, bool, error) {
+			cancel()
+			return 0, false, nil
+		})
+		if err != context.Canceled {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		check := func(ctx context.Context) (int, bool, error) { return 0, true, nil }
+		for _, config := range []Config{{}, {Interval: time.Second, Jitter: 1.5}} {
+			if _, err := Poll(context.Background(), config, check); !errors.Is(err, ErrInvalidConfig) {
+				t.Errorf("expected ErrInvalidConfig for %+v, got %v", config, err)
+			}
+		}
+	})
+}
+
+func TestDelay(t *testing.T) {
+	backoff := Config{Strategy: &retry.ExponentialBackoff{InitialDelay: 10 * time.Millisecond, Multiplier: 2}}
+	if d := backoff.delay(3); d != 40*time.Millisecond {
+		t.Errorf("expected strategy delay 40ms, got %v", d)
+	}
+
+	jittered := Config{Interval: 100 * time.Millisecond, Jitter: 0.2}
+	for i := 0; i < 100; i++ {
+		if d := jittered.delay(1); d < 80*time.Millisecond || d > 120*time.Millisecond {
+			t.Fatalf("expected delay within ±20%%, got %v", d)
+		}
+	}
+}
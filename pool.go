@@ -0,0 +1,214 @@
+package await
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+	"time"
+)
+
+// Priority orders queued tasks in a Pool. Higher priorities are started first;
+// tasks with equal priority are started in submission order.
+type Priority int
+
+const (
+	PriorityLow    Priority = -1 // Bulk background work
+	PriorityNormal Priority = 0  // Default priority
+	PriorityHigh   Priority = 1  // Latency-sensitive work
+)
+
+// SubmitOption configures a single Pool.Submit call.
+type SubmitOption func(*submitConfig)
+
+type submitConfig struct {
+	priority Priority
+}
+
+// WithPriority sets the priority of a submitted task.
+func WithPriority(p Priority) SubmitOption {
+	return func(c *submitConfig) {
+		c.priority = p
+	}
+}
+
+// Pool executes submitted tasks on a fixed number of worker goroutines.
+// When all workers are busy, tasks wait in a priority queue so that
+// latency-sensitive tasks jump ahead of queued background work.
+type Pool[T any] struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu     sync.Mutex
+	cond   *sync.Cond
+	queue  poolQueue[T]
+	seq    uint64
+	closed bool
+
+	wg sync.WaitGroup
+}
+
+// NewPool starts a pool with the given number of workers (at least 1).
+// Tasks receive a context derived from ctx; when ctx is done, queued tasks
+// complete with the context error without being started.
+func NewPool[T any](ctx context.Context, workers int) *Pool[T] {
+	if workers < 1 {
+		workers = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	p := &Pool[T]{ctx: ctx, cancel: cancel}
+	p.cond = sync.NewCond(&p.mu)
+
+	context.AfterFunc(ctx, func() {
+		p.mu.Lock()
+		p.cond.Broadcast()
+		p.mu.Unlock()
+	})
+
+	for i := 0; i < workers; i++ {
+		p.wg.Add(1)
+		go p.worker()
+	}
+	return p
+}
+
+// Submit queues a task and returns a Future for its outcome.
+// Submitting to a closed pool returns a Future that fails with ErrPoolClosed,
+// and submitting once the pool's context is done one that fails with the
+// context error.
+func (p *Pool[T]) Submit(task Task[T], opts ...SubmitOption) *Future[T] {
+	cfg := submitConfig{priority: PriorityNormal}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	f := newFuture[T]()
+
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		var zero T
+		f.complete(zero, ErrPoolClosed)
+		return f
+	}
+	if err := p.ctx.Err(); err != nil {
+		// The workers have exited or are about to, so nothing would run it.
+		p.mu.Unlock()
+		var zero T
+		f.complete(zero, err)
+		return f
+	}
+	p.seq++
+	item := &poolItem[T]{task: task, future: f, priority: cfg.priority, seq: p.seq}
+	if statsEnabled.Load() {
+		item.counted = true
+		poolQueued.Add(1)
+	}
+	heap.Push(&p.queue, item)
+	pending := p.queue.Len()
+	p.cond.Signal()
+	p.mu.Unlock()
+
+	if Subscribed() {
+		Publish(TaskQueued{Time: time.Now(), Priority: cfg.priority, Pending: pending})
+	}
+
+	return f
+}
+
+// Close stops accepting new tasks and waits for queued and running tasks to finish.
+func (p *Pool[T]) Close() {
+	p.mu.Lock()
+	p.closed = true
+	p.cond.Broadcast()
+	p.mu.Unlock()
+
+	p.wg.Wait()
+	p.cancel()
+}
+
+// Shutdown cancels running tasks, fails queued tasks with context.Canceled,
+// and waits for workers to exit.
+func (p *Pool[T]) Shutdown() {
+	p.mu.Lock()
+	p.closed = true
+	p.mu.Unlock()
+
+	p.cancel()
+	p.wg.Wait()
+}
+
+// Pending returns the number of tasks waiting for a worker.
+func (p *Pool[T]) Pending() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.queue.Len()
+}
+
+func (p *Pool[T]) worker() {
+	defer p.wg.Done()
+
+	for {
+		p.mu.Lock()
+		for p.queue.Len() == 0 && !p.closed && p.ctx.Err() == nil {
+			p.cond.Wait()
+		}
+		if p.queue.Len() == 0 {
+			p.mu.Unlock()
+			return
+		}
+		item := heap.Pop(&p.queue).(*poolItem[T])
+		p.mu.Unlock()
+		if item.counted {
+			poolQueued.Add(-1)
+		}
+
+		err := p.ctx.Err()
+		if err != nil {
+			var zero T
+			item.future.complete(zero, err)
+			continue
+		}
+
+		var val T
+		if instrumented() {
+			val, err = runTask(p.ctx, Options{}, &taskInfo{index: -1}, item.task)
+		} else {
+			val, err = item.task(p.ctx)
+		}
+		item.future.complete(val, err)
+	}
+}
+
+type poolItem[T any] struct {
+	task     Task[T]
+	future   *Future[T]
+	priority Priority
+	seq      uint64
+	counted  bool // Included in Stats().PoolQueued
+}
+
+// poolQueue implements heap.Interface ordered by priority, then submission order.
+type poolQueue[T any] []*poolItem[T]
+
+func (q poolQueue[T]) Len() int { return len(q) }
+
+func (q poolQueue[T]) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority > q[j].priority
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q poolQueue[T]) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *poolQueue[T]) Push(x any) { *q = append(*q, x.(*poolItem[T])) }
+
+func (q *poolQueue[T]) Pop() any {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return item
+}
@@ -0,0 +1,151 @@
+package await
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPool(t *testing.T) {
+	t.Run("executes submitted tasks", func(t *testing.T) {
+		p := NewPool[int](context.Background(), 2)
+		defer p.Close()
+
+		f1 := p.Submit(func(ctx context.Context) (int, error) { return 1, nil })
+		f2 := p.Submit(func(ctx context.Context) (int, error) { return 0, errors.New("failed") })
+
+		val, err := f1.Await(context.Background())
+		if err != nil || val != 1 {
+			t.Fatalf("expected {1, nil}, got {%d, %v}", val, err)
+		}
+		_, err = f2.Await(context.Background())
+		if err == nil || err.Error() != "failed" {
+			t.Fatalf("expected 'failed', got %v", err)
+		}
+	})
+
+	t.Run("high priority runs before queued low priority", func(t *testing.T) {
+		p := NewPool[string](context.Background(), 1)
+		defer p.Close()
+
+		release := make(chan struct{})
+		started := make(chan struct{})
+		p.Submit(func(ctx context.Context) (string, error) {
+			close(started)
+			<-release
+			return "blocker", nil
+		})
+		<-started
+
+		var mu sync.Mutex
+		var order []string
+		record := func(name string) Task[string] {
+			return func(ctx context.Context) (string, error) {
+				mu.Lock()
+				order = append(order, name)
+				mu.Unlock()
+				return name, nil
+			}
+		}
+
+		low1 := p.Submit(record("low1"), WithPriority(PriorityLow))
+		normal := p.Submit(record("normal"))
+		low2 := p.Submit(record("low2"), WithPriority(PriorityLow))
+		high := p.Submit(record("high"), WithPriority(PriorityHigh))
+
+		if p.Pending() != 4 {
+			t.Fatalf("expected 4 pending tasks, got %d", p.Pending())
+		}
+		close(release)
+
+		for _, f := range []*Future[string]{low1, normal, low2, high} {
+			if _, err := f.Await(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+
+		expected := []string{"high", "normal", "low1", "low2"}
+		for i, name := range expected {
+			if order[i] != name {
+				t.Fatalf("expected order %v, got %v", expected, order)
+			}
+		}
+	})
+
+	t.Run("submit after close", func(t *testing.T) {
+		p := NewPool[int](context.Background(), 1)
+		p.Close()
+
+		_, err := p.Submit(func(ctx context.Context) (int, error) { return 1, nil }).Await(context.Background())
+		if err != ErrPoolClosed {
+			t.Fatalf("expected ErrPoolClosed, got %v", err)
+		}
+	})
+
+	t.Run("submit after cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		p := NewPool[int](ctx, 1)
+		cancel()
+		time.Sleep(20 * time.Millisecond) // let the idle worker exit
+
+		f := p.Submit(func(ctx context.Context) (int, error) { return 1, nil })
+		select {
+		case <-f.Done():
+		case <-time.After(time.Second):
+			t.Fatal("expected the future to complete")
+		}
+		if res, _ := f.Result(); res.Err != context.Canceled {
+			t.Fatalf("expected context.Canceled, got %v", res.Err)
+		}
+	})
+
+	t.Run("shutdown fails queued tasks", func(t *testing.T) {
+		p := NewPool[int](context.Background(), 1)
+
+		started := make(chan struct{})
+		running := p.Submit(func(ctx context.Context) (int, error) {
+			close(started)
+			<-ctx.Done()
+			return 0, ctx.Err()
+		})
+		<-started
+		queued := p.Submit(func(ctx context.Context) (int, error) { return 1, nil })
+
+		p.Shutdown()
+
+		if _, err := running.Await(context.Background()); err != context.Canceled {
+			t.Fatalf("expected context.Canceled for running task, got %v", err)
+		}
+		if _, err := queued.Await(context.Background()); err != context.Canceled {
+			t.Fatalf("expected context.Canceled for queued task, got %v", err)
+		}
+	})
+}
+
+func TestFuture(t *testing.T) {
+	t.Run("await respects context", func(t *testing.T) {
+		f := newFuture[int]()
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		if _, err := f.Await(ctx); err != context.DeadlineExceeded {
+			t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+		}
+		if _, ok := f.Result(); ok {
+			t.Fatal("expected result to be unavailable")
+		}
+	})
+
+	t.Run("result after completion", func(t *testing.T) {
+		f := newFuture[int]()
+		f.complete(7, nil)
+
+		<-f.Done()
+		res, ok := f.Result()
+		if !ok || res.Value != 7 || res.Err != nil {
+			t.Fatalf("expected {7, nil}, got %v (ok=%v)", res, ok)
+		}
+	})
+}
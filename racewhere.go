@@ -0,0 +1,97 @@
+package await
+
+import "context"
+
+// RaceWhere executes all tasks concurrently and returns the outcome of the
+// first task to complete whose value and error satisfy accept, cancelling the
+// others: for example, the first answer with enough confidence, even if a
+// less confident one arrives earlier. Rejected outcomes are skipped and the
+// call keeps waiting. Unlike Find, accept sees errors too, so a definitive
+// failure can settle the call like in Race.
+//
+// accept is called from the calling goroutine, one outcome at a time. If no
+// outcome is accepted, an *AggregateError is returned in which rejected
+// successes are recorded as ErrNoMatch.
+func RaceWhere[T any](ctx context.Context, accept func(T, error) bool, tasks ...Task[T]) (T, error) {
+	return RaceWhereWithOptions(ctx, Options{}, accept, tasks...)
+}
+
+// RaceWhereWithOptions is like RaceWhere but applies the given execution
+// options. With Sequential, tasks run in order until one is accepted.
+func RaceWhereWithOptions[T any](ctx context.Context, opts Options, accept func(T, error) bool, tasks ...Task[T]) (T, error) {
+	var zero T
+	if len(tasks) == 0 {
+		return zero, ErrNoTasks
+	}
+	tasks = admit(opts, tasks)
+
+	r := &rejections{tracking: trackingEnabled(opts)}
+
+	if opts.Sequential {
+		for i, task := range tasks {
+			info := r.info(i)
+			val, err := runSingle(ctx, opts, info, task)
+			if accept(val, err) {
+				return val, err
+			}
+			r.add(i, info, err)
+		}
+	} else {
+		ctx, cancel := context.WithCancelCause(ctx)
+		defer cancel(nil)
+
+		type outcome struct {
+			idx  int
+			info *taskInfo
+			val  T
+			err  error
+		}
+		// Buffered so tasks finishing after the call settles never block.
+		outcomes := make(chan outcome, len(tasks))
+		for i, t := range tasks {
+			go func(idx int, task Task[T]) {
+				info := r.info(idx)
+				val, err := runSingle(ctx, opts, info, task)
+				outcomes <- outcome{idx, info, val, err}
+			}(i, t)
+		}
+		for range tasks {
+			o := <-outcomes
+			if accept(o.val, o.err) {
+				cancel(ErrSiblingCompleted) // Cancel remaining
+				return o.val, o.err
+			}
+			r.add(o.idx, o.info, o.err)
+		}
+	}
+
+	aggErr := newAggregateError(opts, r.errs, r.indices, r.failed)
+	logAggregateFailure(ctx, len(tasks), aggErr, r.failed)
+	return zero, aggErr
+}
+
+// rejections collects the outcomes a RaceWhere call did not accept.
+type rejections struct {
+	tracking bool
+	errs     []error
+	indices  []int
+	failed   []*taskInfo
+}
+
+func (r *rejections) info(idx int) *taskInfo {
+	if !r.tracking {
+		return nil
+	}
+	return &taskInfo{index: idx}
+}
+
+func (r *rejections) add(idx int, info *taskInfo, err error) {
+	if err == nil {
+		err = ErrNoMatch
+	}
+	r.errs = append(r.errs, err)
+	r.indices = append(r.indices, idx)
+	if info != nil {
+		r.failed = append(r.failed, info)
+	}
+}
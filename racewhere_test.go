@@ -0,0 +1,97 @@
+package await
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRaceWhere(t *testing.T) {
+	ctx := context.Background()
+
+	type answer struct {
+		text       string
+		confidence float64
+	}
+	reply := func(a answer, d time.Duration) Task[answer] {
+		return func(ctx context.Context) (answer, error) {
+			select {
+			case <-time.After(d):
+				return a, nil
+			case <-ctx.Done():
+				return answer{}, ctx.Err()
+			}
+		}
+	}
+	confident := func(a answer, err error) bool { return err == nil && a.confidence > 0.9 }
+
+	t.Run("skips early low-quality result", func(t *testing.T) {
+		cancelled := make(chan bool, 1)
+		straggler := func(ctx context.Context) (answer, error) {
+			<-ctx.Done()
+			cancelled <- CancelledBySibling(ctx)
+			return answer{}, ctx.Err()
+		}
+
+		got, err := RaceWhere(ctx, confident,
+			reply(answer{"fast", 0.5}, 0),
+			reply(answer{"good", 0.95}, 10*time.Millisecond),
+			straggler,
+		)
+		if err != nil || got.text != "good" {
+			t.Fatalf("expected the confident answer, got %+v, %v", got, err)
+		}
+		if !<-cancelled {
+			t.Fatal("expected remaining task to be cancelled")
+		}
+	})
+
+	t.Run("accepted error settles the call", func(t *testing.T) {
+		errNotFound := errors.New("not found")
+		_, err := RaceWhere(ctx, func(a answer, err error) bool {
+			return err == nil || errors.Is(err, errNotFound)
+		},
+			func(ctx context.Context) (answer, error) { return answer{}, errNotFound },
+			reply(answer{"slow", 1}, time.Second),
+		)
+		if !errors.Is(err, errNotFound) {
+			t.Fatalf("expected accepted errNotFound, got %v", err)
+		}
+	})
+
+	t.Run("nothing accepted", func(t *testing.T) {
+		errDown := errors.New("down")
+		_, err := RaceWhere(ctx, confident,
+			reply(answer{"meh", 0.2}, 0),
+			func(ctx context.Context) (answer, error) { return answer{}, errDown },
+		)
+		var aggErr *AggregateError
+		if !errors.As(err, &aggErr) || aggErr.Len() != 2 {
+			t.Fatalf("expected AggregateError with 2 errors, got %v", err)
+		}
+		if !errors.Is(err, ErrNoMatch) || !errors.Is(err, errDown) {
+			t.Fatalf("expected ErrNoMatch and errDown, got %v", err)
+		}
+	})
+
+	t.Run("sequential", func(t *testing.T) {
+		ran := 0
+		task := func(c float64) Task[answer] {
+			return func(ctx context.Context) (answer, error) {
+				ran++
+				return answer{confidence: c}, nil
+			}
+		}
+		got, err := RaceWhereWithOptions(ctx, Options{Sequential: true}, confident, task(0.1), task(0.99), task(1))
+		if err != nil || got.confidence != 0.99 || ran != 2 {
+			t.Fatalf("expected second task accepted after 2 runs, got %+v, %v, %d runs", got, err, ran)
+		}
+	})
+
+	t.Run("no tasks", func(t *testing.T) {
+		if _, err := RaceWhere(ctx, confident); !errors.Is(err, ErrNoTasks) {
+			t.Fatalf("expected ErrNoTasks, got %v", err)
+		}
+	})
+}
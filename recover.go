@@ -0,0 +1,25 @@
+package await
+
+import "context"
+
+// Recover wraps task so that a failure is passed to handler, whose return
+// values replace the task's. Returning a nil error from handler turns the
+// failure into a success; returning an error (the original or a new one)
+// keeps the task failed. Successful runs are passed through unchanged.
+func Recover[T any](task Task[T], handler func(err error) (T, error)) Task[T] {
+	return func(ctx context.Context) (T, error) {
+		val, err := task(ctx)
+		if err != nil {
+			return handler(err)
+		}
+		return val, nil
+	}
+}
+
+// WithFallbackValue wraps task so that any failure yields fallback with a nil
+// error. Use Recover to fall back only for some errors.
+func WithFallbackValue[T any](task Task[T], fallback T) Task[T] {
+	return Recover(task, func(error) (T, error) {
+		return fallback, nil
+	})
+}
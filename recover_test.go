@@ -0,0 +1,49 @@
+package await
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestRecover(t *testing.T) {
+	ctx := context.Background()
+	errNotFound := errors.New("not found")
+	errDown := errors.New("down")
+
+	handler := func(err error) (string, error) {
+		if errors.Is(err, errNotFound) {
+			return "anonymous", nil
+		}
+		return "", err
+	}
+
+	results, err := All(ctx,
+		Recover(func(ctx context.Context) (string, error) { return "alice", nil }, handler),
+		Recover(func(ctx context.Context) (string, error) { return "", errNotFound }, handler),
+		Recover(func(ctx context.Context) (string, error) { return "", errDown }, handler),
+	)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if results[0].Value != "alice" || results[0].Err != nil {
+		t.Errorf("expected success to pass through, got %+v", results[0])
+	}
+	if results[1].Value != "anonymous" || results[1].Err != nil {
+		t.Errorf("expected recovered value, got %+v", results[1])
+	}
+	if !errors.Is(results[2].Err, errDown) {
+		t.Errorf("expected unrecovered error, got %+v", results[2])
+	}
+}
+
+func TestWithFallbackValue(t *testing.T) {
+	task := WithFallbackValue(func(ctx context.Context) (int, error) {
+		return 0, errors.New("failed")
+	}, 42)
+
+	val, err := task(context.Background())
+	if err != nil || val != 42 {
+		t.Fatalf("expected fallback 42, got %d, %v", val, err)
+	}
+}
@@ -0,0 +1,75 @@
+package await
+
+import "context"
+
+// Reduce executes all tasks concurrently and folds each outcome into an
+// accumulator as it completes, starting from init, and returns the final
+// accumulator. Results are folded in completion order (task order with
+// Options.Sequential) and never collected into a slice, so a large fan-out
+// that only needs an aggregate, such as a sum or a count of failures, holds
+// one result at a time.
+//
+// reducer is only called from the calling goroutine, one result at a time,
+// so it may update the accumulator without locking. Task errors are passed to
+// reducer in Result.Err; like All, Reduce only returns an error for
+// operational issues such as an empty task list or ctx being done before
+// execution. With Options.Timing, each Result carries the task's Timing.
+func Reduce[T, A any](ctx context.Context, tasks []Task[T], init A, reducer func(A, Result[T]) A, opts Options) (A, error) {
+	if len(tasks) == 0 {
+		return init, ErrNoTasks
+	}
+	if ctx.Err() != nil {
+		return init, ctx.Err()
+	}
+	tasks = admit(opts, tasks)
+
+	tracking := trackingEnabled(opts)
+	var failed []*taskInfo // Tracked tasks that failed, for logging
+	fold := func(acc A, info *taskInfo, val T, err error) A {
+		if err != nil && info != nil {
+			failed = append(failed, info)
+		}
+		return reducer(acc, Result[T]{Value: val, Err: err, Timing: info.timing()})
+	}
+
+	acc := init
+	if opts.Sequential || len(tasks) == 1 {
+		for i, task := range tasks {
+			info := reduceInfo(tracking, i)
+			val, err := runSingle(ctx, opts, info, task)
+			acc = fold(acc, info, val, err)
+		}
+	} else {
+		type outcome struct {
+			info *taskInfo
+			val  T
+			err  error
+		}
+		outcomes := make(chan outcome)
+		for i, t := range tasks {
+			go func(idx int, task Task[T]) {
+				info := reduceInfo(tracking, idx)
+				val, err := runSingle(ctx, opts, info, task)
+				outcomes <- outcome{info, val, err}
+			}(i, t)
+		}
+		for range tasks {
+			o := <-outcomes
+			acc = fold(acc, o.info, o.val, o.err)
+		}
+	}
+
+	if l := logger.Load(); l != nil {
+		logTaskFailures(ctx, l, len(tasks), failed)
+	}
+	return acc, nil
+}
+
+// reduceInfo returns the tracking info for task idx, or nil when identity is
+// not tracked.
+func reduceInfo(tracking bool, idx int) *taskInfo {
+	if !tracking {
+		return nil
+	}
+	return &taskInfo{index: idx}
+}
@@ -0,0 +1,115 @@
+package await
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReduce(t *testing.T) {
+	ctx := context.Background()
+
+	type tally struct {
+		sum      int
+		failures int
+		order    []int
+	}
+	reducer := func(acc tally, r Result[int]) tally {
+		if r.Err != nil {
+			acc.failures++
+			return acc
+		}
+		acc.sum += r.Value
+		acc.order = append(acc.order, r.Value)
+		return acc
+	}
+
+	value := func(v int) Task[int] {
+		return func(ctx context.Context) (int, error) { return v, nil }
+	}
+	fail := func(ctx context.Context) (int, error) { return 0, errors.New("failed") }
+
+	t.Run("concurrent", func(t *testing.T) {
+		tasks := make([]Task[int], 0, 101)
+		for i := 1; i <= 100; i++ {
+			tasks = append(tasks, value(i))
+		}
+		tasks = append(tasks, fail)
+
+		got, err := Reduce(ctx, tasks, tally{}, reducer, Options{})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if got.sum != 5050 || got.failures != 1 {
+			t.Fatalf("expected sum 5050 with 1 failure, got %d with %d", got.sum, got.failures)
+		}
+	})
+
+	t.Run("sequential folds in task order", func(t *testing.T) {
+		got, err := Reduce(ctx, []Task[int]{value(3), fail, value(1), value(2)}, tally{}, reducer, Options{Sequential: true})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(got.order) != 3 || got.order[0] != 3 || got.order[1] != 1 || got.order[2] != 2 {
+			t.Fatalf("expected order [3 1 2], got %v", got.order)
+		}
+	})
+
+	t.Run("task errors", func(t *testing.T) {
+		var te *TaskError
+		Reduce(ctx, []Task[int]{value(1), fail}, 0, func(acc int, r Result[int]) int {
+			if r.Err != nil && !errors.As(r.Err, &te) {
+				t.Errorf("expected TaskError, got %v", r.Err)
+			}
+			return acc
+		}, Options{TaskErrors: true})
+		if te == nil || te.Index != 1 {
+			t.Fatalf("expected TaskError for task 1, got %v", te)
+		}
+	})
+
+	t.Run("timing", func(t *testing.T) {
+		slow := func(ctx context.Context) (int, error) {
+			time.Sleep(5 * time.Millisecond)
+			return 1, nil
+		}
+		for _, opts := range []Options{{Timing: true}, {Timing: true, Sequential: true}} {
+			Reduce(ctx, []Task[int]{slow, slow}, 0, func(acc int, r Result[int]) int {
+				if r.Timing.Started.IsZero() || r.Timing.Duration < 5*time.Millisecond {
+					t.Errorf("%+v: expected recorded timing, got %+v", opts, r.Timing)
+				}
+				return acc
+			}, opts)
+		}
+	})
+
+	t.Run("logs failed tasks", func(t *testing.T) {
+		var buf bytes.Buffer
+		SetLogger(slog.New(slog.NewJSONHandler(&buf, nil)))
+		defer SetLogger(nil)
+
+		Reduce(ctx, []Task[int]{value(1), Named("orders", fail)}, 0, func(acc int, r Result[int]) int { return acc }, Options{})
+		if out := buf.String(); !strings.Contains(out, `"msg":"tasks failed"`) || !strings.Contains(out, `"failed_tasks":["orders"]`) {
+			t.Fatalf("expected failure event naming orders, got %s", out)
+		}
+	})
+
+	t.Run("no tasks", func(t *testing.T) {
+		got, err := Reduce(ctx, nil, 7, func(acc int, r Result[int]) int { return acc + 1 }, Options{})
+		if !errors.Is(err, ErrNoTasks) || got != 7 {
+			t.Fatalf("expected init with ErrNoTasks, got %d, %v", got, err)
+		}
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		if _, err := Reduce(cancelled, []Task[int]{value(1)}, 0, func(acc int, r Result[int]) int { return acc }, Options{}); !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,85 @@
+package await
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Cache holds the last value produced by a task and refreshes it in the
+// background as it ages. Create one with Refreshing.
+type Cache[T any] struct {
+	task     Task[T]
+	ttl      time.Duration
+	staleTTL time.Duration
+
+	mu       sync.Mutex
+	val      T
+	fetched  time.Time
+	has      bool
+	inflight *Future[T]
+}
+
+// Refreshing returns a stale-while-revalidate cache over task. A value is
+// served as is for ttl after it was fetched. For the following staleTTL it is
+// still served instantly, but the first Get in that window starts a refresh in
+// the background; if the refresh fails the stale value is kept and the next
+// Get tries again. Once a value is older than ttl+staleTTL, or before the
+// first fetch, Get waits for a fresh one.
+//
+// Concurrent callers share a single run of task. Runs use the context of the
+// Get that started them without its cancellation, so a caller giving up does
+// not abort a fetch other callers rely on; bound each run inside the task
+// instead. To retry failed refreshes, wrap the task with retry.Task.
+func Refreshing[T any](task Task[T], ttl, staleTTL time.Duration) *Cache[T] {
+	return &Cache[T]{task: task, ttl: ttl, staleTTL: staleTTL}
+}
+
+// Get returns the cached value, fetching it first if there is none still
+// usable. If ctx is done while waiting, the context error is returned and
+// the fetch keeps running for later callers.
+func (c *Cache[T]) Get(ctx context.Context) (T, error) {
+	c.mu.Lock()
+	if c.has {
+		age := time.Since(c.fetched)
+		if age < c.ttl {
+			defer c.mu.Unlock()
+			return c.val, nil
+		}
+		if age < c.ttl+c.staleTTL {
+			defer c.mu.Unlock()
+			if c.inflight == nil {
+				c.refresh(ctx)
+			}
+			return c.val, nil
+		}
+	}
+	f := c.inflight
+	if f == nil {
+		f = c.refresh(ctx)
+	}
+	c.mu.Unlock()
+
+	return f.Await(ctx)
+}
+
+// refresh starts a run of the task and records its value on success. The
+// caller must hold c.mu.
+func (c *Cache[T]) refresh(ctx context.Context) *Future[T] {
+	f := newFuture[T]()
+	c.inflight = f
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		val, err := c.task(ctx)
+
+		c.mu.Lock()
+		if err == nil {
+			c.val, c.fetched, c.has = val, time.Now(), true
+		}
+		c.inflight = nil
+		c.mu.Unlock()
+
+		f.complete(val, err)
+	}()
+	return f
+}
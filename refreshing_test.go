@@ -0,0 +1,133 @@
+package await
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRefreshing(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("serves fresh value without refetching", func(t *testing.T) {
+		var calls atomic.Int32
+		c := Refreshing(func(ctx context.Context) (int, error) {
+			return int(calls.Add(1)), nil
+		}, time.Hour, time.Hour)
+
+		for i := 0; i < 3; i++ {
+			if v, err := c.Get(ctx); err != nil || v != 1 {
+				t.Fatalf("expected {1, nil}, got {%d, %v}", v, err)
+			}
+		}
+		if calls.Load() != 1 {
+			t.Fatalf("expected 1 fetch, got %d", calls.Load())
+		}
+	})
+
+	t.Run("concurrent first Gets share a fetch", func(t *testing.T) {
+		var calls atomic.Int32
+		release := make(chan struct{})
+		c := Refreshing(func(ctx context.Context) (int, error) {
+			calls.Add(1)
+			<-release
+			return 7, nil
+		}, time.Hour, 0)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if v, err := c.Get(ctx); err != nil || v != 7 {
+					t.Errorf("expected {7, nil}, got {%d, %v}", v, err)
+				}
+			}()
+		}
+		time.Sleep(10 * time.Millisecond)
+		close(release)
+		wg.Wait()
+		if calls.Load() != 1 {
+			t.Fatalf("expected 1 fetch, got %d", calls.Load())
+		}
+	})
+
+	t.Run("stale value served while refreshing", func(t *testing.T) {
+		var calls atomic.Int32
+		release := make(chan struct{})
+		c := Refreshing(func(ctx context.Context) (int, error) {
+			n := int(calls.Add(1))
+			if n > 1 {
+				<-release
+			}
+			return n, nil
+		}, 10*time.Millisecond, time.Hour)
+
+		if v, _ := c.Get(ctx); v != 1 {
+			t.Fatalf("expected first value 1, got %d", v)
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		// The value is stale: served immediately while a refresh is pending.
+		for i := 0; i < 3; i++ {
+			if v, err := c.Get(ctx); err != nil || v != 1 {
+				t.Fatalf("expected stale {1, nil}, got {%d, %v}", v, err)
+			}
+		}
+		close(release)
+		waitUntil(t, func() bool {
+			v, _ := c.Get(ctx)
+			return v == 2
+		})
+		if calls.Load() != 2 {
+			t.Fatalf("expected a single background refresh, got %d fetches", calls.Load())
+		}
+	})
+
+	t.Run("failed refresh keeps stale value", func(t *testing.T) {
+		errDown := errors.New("config server down")
+		var calls atomic.Int32
+		c := Refreshing(func(ctx context.Context) (string, error) {
+			if calls.Add(1) == 1 {
+				return "v1", nil
+			}
+			return "", errDown
+		}, 10*time.Millisecond, 50*time.Millisecond)
+
+		if v, _ := c.Get(ctx); v != "v1" {
+			t.Fatalf("expected v1, got %q", v)
+		}
+		time.Sleep(15 * time.Millisecond)
+		if v, err := c.Get(ctx); err != nil || v != "v1" {
+			t.Fatalf("expected stale v1, got {%q, %v}", v, err)
+		}
+		waitUntil(t, func() bool { return calls.Load() >= 2 })
+		if v, err := c.Get(ctx); err != nil || v != "v1" {
+			t.Fatalf("expected v1 kept after failed refresh, got {%q, %v}", v, err)
+		}
+
+		// Beyond the stale window the error surfaces.
+		time.Sleep(60 * time.Millisecond)
+		if _, err := c.Get(ctx); !errors.Is(err, errDown) {
+			t.Fatalf("expected errDown once the value expired, got %v", err)
+		}
+	})
+
+	t.Run("caller context bounds the wait", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+		c := Refreshing(func(ctx context.Context) (int, error) {
+			<-release
+			return 1, nil
+		}, time.Hour, 0)
+
+		waitCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		if _, err := c.Get(waitCtx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected DeadlineExceeded, got %v", err)
+		}
+	})
+}
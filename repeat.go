@@ -0,0 +1,126 @@
+package await
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Repeater runs a task periodically and keeps its recent results. Create one
+// with Repeat.
+type Repeater[T any] struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu      sync.RWMutex
+	ring    []Result[T] // Recent results; next is the slot for the next one
+	next    int
+	count   int
+	lastOK  T
+	hasOK   bool
+	hasLast bool
+}
+
+// DefaultRepeatInterval is the interval Repeat uses when given one <= 0.
+const DefaultRepeatInterval = time.Second
+
+// Repeat runs task immediately and then every interval until ctx is done or
+// Stop is called, keeping the last history results (at least 1). An interval
+// <= 0 uses DefaultRepeatInterval. Runs do not overlap: a run that takes
+// longer than interval delays the next one.
+// It suits values refreshed in the background, such as tokens or remote
+// configuration, that readers fetch with Latest or LastOK.
+func Repeat[T any](ctx context.Context, task Task[T], interval time.Duration, history int) *Repeater[T] {
+	if history < 1 {
+		history = 1
+	}
+	if interval <= 0 {
+		interval = DefaultRepeatInterval
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	r := &Repeater[T]{
+		cancel: cancel,
+		done:   make(chan struct{}),
+		ring:   make([]Result[T], history),
+	}
+	go r.loop(ctx, task, interval)
+	return r
+}
+
+func (r *Repeater[T]) loop(ctx context.Context, task Task[T], interval time.Duration) {
+	defer close(r.done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		val, err := task(ctx)
+		if ctx.Err() != nil {
+			// A run interrupted by Stop is not a meaningful result.
+			return
+		}
+		r.record(Result[T]{Value: val, Err: err})
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Repeater[T]) record(res Result[T]) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ring[r.next] = res
+	r.next = (r.next + 1) % len(r.ring)
+	if r.count < len(r.ring) {
+		r.count++
+	}
+	r.hasLast = true
+	if res.Err == nil {
+		r.lastOK = res.Value
+		r.hasOK = true
+	}
+}
+
+// Latest returns the result of the most recent run, and false if no run has
+// finished yet.
+func (r *Repeater[T]) Latest() (Result[T], bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if !r.hasLast {
+		return Result[T]{}, false
+	}
+	return r.ring[(r.next-1+len(r.ring))%len(r.ring)], true
+}
+
+// LastOK returns the value of the most recent successful run, which may be
+// older than the retained history, and false if no run has succeeded yet.
+func (r *Repeater[T]) LastOK() (T, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.lastOK, r.hasOK
+}
+
+// History returns the retained results, oldest first.
+func (r *Repeater[T]) History() []Result[T] {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]Result[T], 0, r.count)
+	start := (r.next - r.count + len(r.ring)) % len(r.ring)
+	for i := 0; i < r.count; i++ {
+		out = append(out, r.ring[(start+i)%len(r.ring)])
+	}
+	return out
+}
+
+// Stop cancels the current run, if any, and waits for the repeater to exit.
+func (r *Repeater[T]) Stop() {
+	r.cancel()
+	<-r.done
+}
+
+// Done returns a channel closed once the repeater has exited.
+func (r *Repeater[T]) Done() <-chan struct{} {
+	return r.done
+}
@@ -0,0 +1,115 @@
+package await
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRepeat(t *testing.T) {
+	t.Run("keeps bounded history", func(t *testing.T) {
+		var runs atomic.Int32
+		r := Repeat(context.Background(), func(ctx context.Context) (int, error) {
+			n := int(runs.Add(1))
+			if n == 5 {
+				return 0, errors.New("refresh failed")
+			}
+			return n, nil
+		}, time.Millisecond, 3)
+
+		waitUntil(t, func() bool { return runs.Load() >= 6 })
+		r.Stop()
+
+		history := r.History()
+		if len(history) != 3 {
+			t.Fatalf("expected 3 retained results, got %d", len(history))
+		}
+		for i := 1; i < len(history); i++ {
+			if history[i].Err == nil && history[i-1].Err == nil && history[i].Value != history[i-1].Value+1 {
+				t.Fatalf("expected history oldest first, got %v", history)
+			}
+		}
+		latest, ok := r.Latest()
+		if !ok || latest != history[len(history)-1] {
+			t.Fatalf("expected Latest to match the newest history entry, got %v", latest)
+		}
+	})
+
+	t.Run("LastOK survives failures", func(t *testing.T) {
+		var runs atomic.Int32
+		r := Repeat(context.Background(), func(ctx context.Context) (string, error) {
+			if runs.Add(1) == 1 {
+				return "token-1", nil
+			}
+			return "", errors.New("auth server down")
+		}, time.Millisecond, 1)
+
+		waitUntil(t, func() bool { return runs.Load() >= 3 })
+		r.Stop()
+
+		if latest, _ := r.Latest(); latest.Err == nil {
+			t.Fatal("expected the latest run to have failed")
+		}
+		if tok, ok := r.LastOK(); !ok || tok != "token-1" {
+			t.Fatalf("expected last good token-1, got %q, %v", tok, ok)
+		}
+	})
+
+	t.Run("nothing before first run", func(t *testing.T) {
+		block := make(chan struct{})
+		r := Repeat(context.Background(), func(ctx context.Context) (int, error) {
+			select {
+			case <-block:
+			case <-ctx.Done():
+			}
+			return 1, nil
+		}, time.Hour, 2)
+		if _, ok := r.Latest(); ok {
+			t.Error("expected no result before the first run finishes")
+		}
+		if _, ok := r.LastOK(); ok {
+			t.Error("expected no successful value yet")
+		}
+		r.Stop()
+		if len(r.History()) != 0 {
+			t.Error("expected a run interrupted by Stop not to be recorded")
+		}
+	})
+
+	t.Run("non-positive interval uses the default", func(t *testing.T) {
+		var runs atomic.Int32
+		r := Repeat(context.Background(), func(ctx context.Context) (int, error) {
+			return int(runs.Add(1)), nil
+		}, 0, 1)
+		waitUntil(t, func() bool { return runs.Load() >= 1 })
+		time.Sleep(20 * time.Millisecond)
+		r.Stop()
+		if n := runs.Load(); n != 1 {
+			t.Fatalf("expected one run within the default interval, got %d", n)
+		}
+	})
+
+	t.Run("stops with context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		r := Repeat(ctx, func(ctx context.Context) (int, error) { return 1, nil }, time.Millisecond, 1)
+		cancel()
+		select {
+		case <-r.Done():
+		case <-time.After(time.Second):
+			t.Fatal("expected repeater to exit when ctx is cancelled")
+		}
+	})
+}
+
+func waitUntil(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
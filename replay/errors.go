@@ -0,0 +1,81 @@
+package replay
+
+import (
+	"context"
+	"errors"
+
+	"github.com/remiges-tech/await/retry"
+)
+
+// RecordedError is a replayed task error that carried an HTTP status when
+// recorded. It keeps the recorded message and status.
+type RecordedError struct {
+	Msg    string // The original error message
+	Status int    // HTTP status carried by the original error, or 0
+}
+
+// Error returns the original error message.
+func (e *RecordedError) Error() string {
+	return e.Msg
+}
+
+// HTTPStatusCode returns the recorded status, so retry.HTTPStatus and
+// retry.OnHTTPStatus treat the error like the original.
+func (e *RecordedError) HTTPStatusCode() int {
+	return e.Status
+}
+
+// describeError records what retry policies look at in err.
+func describeError(entry *Entry, err error) {
+	entry.Err = err.Error()
+	switch {
+	case errors.Is(err, context.Canceled):
+		entry.ErrKind = ErrKindCanceled
+	case errors.Is(err, context.DeadlineExceeded):
+		entry.ErrKind = ErrKindDeadlineExceeded
+	}
+	if status, ok := retry.HTTPStatus(err); ok {
+		entry.Status = status
+	}
+	entry.Permanent = retry.IsPermanentError(err)
+}
+
+// rebuildError turns a recorded error back into an error with the same
+// classification. Context errors keep the recorded message, wrapping the
+// context error they matched.
+func rebuildError(entry Entry) error {
+	var err error
+	switch entry.ErrKind {
+	case ErrKindCanceled:
+		err = &contextError{msg: entry.Err, err: context.Canceled}
+	case ErrKindDeadlineExceeded:
+		err = &contextError{msg: entry.Err, err: context.DeadlineExceeded}
+	default:
+		if entry.Status != 0 {
+			err = &RecordedError{Msg: entry.Err, Status: entry.Status}
+		} else {
+			err = errors.New(entry.Err)
+		}
+	}
+	if entry.Permanent {
+		err = retry.Permanent(err)
+	}
+	return err
+}
+
+// contextError is a replayed context error. It keeps the recorded message,
+// which may add context to the error it wraps.
+type contextError struct {
+	msg string
+	err error // context.Canceled or context.DeadlineExceeded
+}
+
+// Error returns the recorded message.
+func (e *contextError) Error() string {
+	return e.msg
+}
+
+// Unwrap returns the context error, so errors.Is matches it.
+func (e *contextError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,242 @@
+// Package replay records the outcomes of tasks to a file and replays them
+// later without calling the real dependencies. A recording of production or
+// staging traffic can then be fed to await.All, await.Any or retry.Do under
+// different policies, to tune retries and hedging offline.
+//
+// Calls are identified by a key, typically built with Key from the task's
+// name and input. A key called several times, as a retried task is, records
+// one entry per call and replays them in order.
+package replay
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/remiges-tech/await"
+)
+
+// ErrNotRecorded is returned by a replayed task whose key has no entries.
+var ErrNotRecorded = errors.New("no recorded outcome")
+
+// Kinds of recorded errors that replay as the matching sentinel.
+const (
+	ErrKindCanceled         = "canceled"
+	ErrKindDeadlineExceeded = "deadline_exceeded"
+)
+
+// Entry is one recorded call.
+type Entry struct {
+	Key       string          `json:"key"`                  // Identifies the call, see Key
+	Seq       int             `json:"seq"`                  // 0-based occurrence of Key in the recording
+	Started   time.Time       `json:"started"`              // When the call started
+	Duration  time.Duration   `json:"duration"`             // How long the call took
+	Value     json.RawMessage `json:"value,omitempty"`      // JSON-encoded value, for successful calls
+	Err       string          `json:"error,omitempty"`      // Error message, for failed calls
+	ErrKind   string          `json:"error_kind,omitempty"` // ErrKindCanceled, ErrKindDeadlineExceeded or ""
+	Status    int             `json:"status,omitempty"`     // HTTP status carried by the error, if any
+	Permanent bool            `json:"permanent,omitempty"`  // Whether the error was marked with retry.Permanent
+}
+
+// Key returns a call key made of name and a hash of input's JSON encoding,
+// so calls with the same input share a key without storing the input itself.
+func Key(name string, input any) (string, error) {
+	data, err := json.Marshal(input)
+	if err != nil {
+		return "", fmt.Errorf("replay: encode input of %s: %w", name, err)
+	}
+	sum := sha256.Sum256(data)
+	return name + ":" + hex.EncodeToString(sum[:8]), nil
+}
+
+// Recorder collects the entries of recorded tasks. It is safe for
+// concurrent use.
+type Recorder struct {
+	mu      sync.Mutex
+	entries []Entry
+	seq     map[string]int
+	err     error // First value encoding error, reported by Save
+}
+
+// NewRecorder creates an empty Recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{seq: make(map[string]int)}
+}
+
+// Record wraps task so each call's outcome and timing is added to r under
+// key. The task's result is returned unchanged; values are stored with
+// encoding/json, so T must be encodable.
+func Record[T any](r *Recorder, key string, task await.Task[T]) await.Task[T] {
+	return func(ctx context.Context) (T, error) {
+		started := time.Now()
+		val, err := task(ctx)
+		entry := Entry{Key: key, Started: started, Duration: time.Since(started)}
+
+		var encErr error
+		if err == nil {
+			entry.Value, encErr = json.Marshal(val)
+		} else {
+			describeError(&entry, err)
+		}
+		r.add(entry, encErr)
+		return val, err
+	}
+}
+
+func (r *Recorder) add(entry Entry, encErr error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if encErr != nil && r.err == nil {
+		r.err = fmt.Errorf("replay: encode value of %s: %w", entry.Key, encErr)
+	}
+	entry.Seq = r.seq[entry.Key]
+	r.seq[entry.Key]++
+	r.entries = append(r.entries, entry)
+}
+
+// Entries returns a copy of the recorded entries, in the order calls finished.
+func (r *Recorder) Entries() []Entry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Entry(nil), r.entries...)
+}
+
+// Save writes the entries to path as JSON lines, one entry per line. It fails
+// if any recorded value could not be encoded.
+func (r *Recorder) Save(path string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return r.err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for _, entry := range r.entries {
+		if err := enc.Encode(entry); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Load reads entries written by Recorder.Save.
+func Load(path string) ([]Entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []Entry
+	dec := json.NewDecoder(f)
+	for dec.More() {
+		var entry Entry
+		if err := dec.Decode(&entry); err != nil {
+			return nil, fmt.Errorf("replay: decode %s: %w", path, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// Player serves recorded outcomes in place of the real tasks. It is safe
+// for concurrent use.
+type Player struct {
+	// Speed scales the recorded durations replayed tasks wait before
+	// returning: 1 replays in real time, 0.1 ten times faster, and 0 returns
+	// at once. Set it before replaying.
+	Speed float64
+
+	mu      sync.Mutex
+	entries map[string][]Entry
+	next    map[string]int
+}
+
+// NewPlayer creates a Player over entries, replaying each key's entries in
+// Seq order at real-time speed.
+func NewPlayer(entries []Entry) *Player {
+	p := &Player{
+		Speed:   1,
+		entries: make(map[string][]Entry),
+		next:    make(map[string]int),
+	}
+	for _, entry := range entries {
+		p.entries[entry.Key] = append(p.entries[entry.Key], entry)
+	}
+	for _, list := range p.entries {
+		sort.SliceStable(list, func(i, j int) bool { return list[i].Seq < list[j].Seq })
+	}
+	return p
+}
+
+// Replay returns a task that plays back the recorded calls of key in order,
+// waiting each call's recorded duration scaled by Speed. Once the recording
+// runs out, the last call is repeated, so a policy that calls more often than
+// the recorded one sees the dependency's last observed behaviour. A key with
+// no entries fails with ErrNotRecorded.
+//
+// Recorded errors are rebuilt so that errors.Is matches context.Canceled and
+// context.DeadlineExceeded, retry.IsPermanentError and retry.HTTPStatus see
+// what they saw when recording, and the message is unchanged.
+func Replay[T any](p *Player, key string) await.Task[T] {
+	return func(ctx context.Context) (T, error) {
+		var zero T
+		entry, ok := p.take(key)
+		if !ok {
+			return zero, fmt.Errorf("%w for key %q", ErrNotRecorded, key)
+		}
+
+		if wait := time.Duration(float64(entry.Duration) * p.Speed); wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return zero, ctx.Err()
+			}
+		}
+
+		if entry.Err != "" || entry.ErrKind != "" {
+			return zero, rebuildError(entry)
+		}
+		var val T
+		if err := json.Unmarshal(entry.Value, &val); err != nil {
+			return zero, fmt.Errorf("replay: decode value of %s: %w", key, err)
+		}
+		return val, nil
+	}
+}
+
+// take returns the next entry for key, repeating the last once they run out.
+func (p *Player) take(key string) (Entry, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	list := p.entries[key]
+	if len(list) == 0 {
+		return Entry{}, false
+	}
+	i := p.next[key]
+	if i >= len(list) {
+		return list[len(list)-1], true
+	}
+	p.next[key] = i + 1
+	return list[i], true
+}
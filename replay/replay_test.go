@@ -0,0 +1,128 @@
+package replay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/remiges-tech/await"
+	"github.com/remiges-tech/await/retry"
+)
+
+type quote struct {
+	Symbol string  `json:"symbol"`
+	Price  float64 `json:"price"`
+}
+
+func TestRecordReplay(t *testing.T) {
+	ctx := context.Background()
+	key, err := Key("quote", map[string]string{"symbol": "ACME"})
+	if err != nil {
+		t.Fatalf("Key: %v", err)
+	}
+
+	// Record a flaky dependency behind a retry policy.
+	rec := NewRecorder()
+	calls := 0
+	live := Record(rec, key, func(ctx context.Context) (quote, error) {
+		calls++
+		switch calls {
+		case 1:
+			time.Sleep(5 * time.Millisecond)
+			return quote{}, &retry.HTTPError{StatusCode: 503}
+		case 2:
+			return quote{}, context.DeadlineExceeded
+		}
+		return quote{Symbol: "ACME", Price: 12.5}, nil
+	})
+	opts := retry.Options{Strategy: &retry.NoDelay{}, MaxAttempts: 3}
+	if _, err := retry.Do(ctx, live, opts); err != nil {
+		t.Fatalf("recording run failed: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "quotes.jsonl")
+	if err := rec.Save(path); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	entries, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(entries) != 3 || entries[0].Status != 503 || entries[0].Duration < 5*time.Millisecond || entries[2].Seq != 2 {
+		t.Fatalf("unexpected entries %+v", entries)
+	}
+
+	t.Run("replays outcomes in order", func(t *testing.T) {
+		p := NewPlayer(entries)
+		p.Speed = 0
+		task := Replay[quote](p, key)
+
+		_, err := task(ctx)
+		if status, ok := retry.HTTPStatus(err); !ok || status != 503 {
+			t.Fatalf("expected replayed 503, got %v", err)
+		}
+		if _, err := task(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected replayed DeadlineExceeded, got %v", err)
+		}
+		for i := 0; i < 2; i++ {
+			q, err := task(ctx)
+			if err != nil || q.Price != 12.5 {
+				t.Fatalf("expected recorded quote (repeated once exhausted), got %+v, %v", q, err)
+			}
+		}
+	})
+
+	t.Run("tunes a different policy offline", func(t *testing.T) {
+		p := NewPlayer(entries)
+		p.Speed = 0
+		_, err := retry.Do(ctx, Replay[quote](p, key), retry.Options{
+			Strategy:    &retry.NoDelay{},
+			MaxAttempts: 3,
+			RetryIf:     retry.OnHTTPStatus(503),
+		})
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected the stricter policy to stop at the deadline error, got %v", err)
+		}
+	})
+
+	t.Run("replays durations", func(t *testing.T) {
+		p := NewPlayer(entries)
+		start := time.Now()
+		_, _ = Replay[quote](p, key)(ctx)
+		if time.Since(start) < 5*time.Millisecond {
+			t.Fatal("expected the recorded duration to be replayed")
+		}
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		_, err := await.Any(ctx, Replay[quote](NewPlayer(entries), "missing"))
+		if !errors.Is(err, ErrNotRecorded) {
+			t.Fatalf("expected ErrNotRecorded, got %v", err)
+		}
+	})
+
+	t.Run("permanent errors stay permanent", func(t *testing.T) {
+		rec := NewRecorder()
+		_, _ = Record(rec, "k", func(ctx context.Context) (int, error) {
+			return 0, retry.Permanent(errors.New("bad input"))
+		})(ctx)
+		_, err := Replay[int](NewPlayer(rec.Entries()), "k")(ctx)
+		if !retry.IsPermanentError(err) || err.Error() != "bad input" {
+			t.Fatalf("expected permanent bad input, got %v", err)
+		}
+	})
+
+	t.Run("context errors keep their message", func(t *testing.T) {
+		rec := NewRecorder()
+		_, _ = Record(rec, "k", func(ctx context.Context) (int, error) {
+			return 0, fmt.Errorf("quote feed: %w", context.Canceled)
+		})(ctx)
+		_, err := Replay[int](NewPlayer(rec.Entries()), "k")(ctx)
+		if !errors.Is(err, context.Canceled) || err.Error() != "quote feed: context canceled" {
+			t.Fatalf("expected replayed cancellation, got %v", err)
+		}
+	})
+}
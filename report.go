@@ -0,0 +1,26 @@
+package await
+
+import "context"
+
+// AnyReport describes how an AnyWithReport call was settled.
+type AnyReport struct {
+	Winner   int            // Index of the task whose value was returned, or -1 if every task failed
+	Failures []IndexedError // Tasks that had failed by the time the call settled, in completion order
+	Timing   Timing         // When the winning task ran; only filled in with Options.Timing
+}
+
+// Degraded reports whether any task failed before the call settled, even if
+// another task went on to succeed.
+func (r AnyReport) Degraded() bool {
+	return len(r.Failures) > 0
+}
+
+// AnyWithReport is like AnyWithOptions but also reports which task won and
+// which tasks had already failed. Tasks still running when the winner
+// succeeded are cancelled and do not appear in the report. Useful for
+// alerting on fallback sets that are working but degraded.
+func AnyWithReport[T any](ctx context.Context, opts Options, tasks ...Task[T]) (T, AnyReport, error) {
+	report := AnyReport{Winner: -1}
+	val, err := runAny(ctx, opts, tasks, &report)
+	return val, report, err
+}
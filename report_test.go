@@ -0,0 +1,62 @@
+package await
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAnyWithReport(t *testing.T) {
+	ctx := context.Background()
+	errFast := errors.New("fast failure")
+
+	t.Run("degraded success", func(t *testing.T) {
+		val, report, err := AnyWithReport(ctx, Options{},
+			func(ctx context.Context) (int, error) { return 0, errFast },
+			func(ctx context.Context) (int, error) {
+				time.Sleep(20 * time.Millisecond)
+				return 2, nil
+			},
+			func(ctx context.Context) (int, error) {
+				<-ctx.Done()
+				return 0, ctx.Err()
+			},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if val != 2 || report.Winner != 1 {
+			t.Fatalf("expected task 1 to win with 2, got %d from task %d", val, report.Winner)
+		}
+		if !report.Degraded() || len(report.Failures) != 1 {
+			t.Fatalf("expected one prior failure, got %v", report.Failures)
+		}
+		if f := report.Failures[0]; f.Index != 0 || f.Err != errFast {
+			t.Fatalf("expected task 0 failure, got %v", f)
+		}
+	})
+
+	t.Run("all fail", func(t *testing.T) {
+		_, report, err := AnyWithReport(ctx, Options{Sequential: true},
+			func(ctx context.Context) (int, error) { return 0, errors.New("a") },
+			func(ctx context.Context) (int, error) { return 0, errors.New("b") },
+		)
+		var aggErr *AggregateError
+		if !errors.As(err, &aggErr) {
+			t.Fatalf("expected AggregateError, got %v", err)
+		}
+		if report.Winner != -1 || len(report.Failures) != 2 || report.Failures[1].Index != 1 {
+			t.Fatalf("expected no winner and two failures, got %+v", report)
+		}
+	})
+
+	t.Run("clean success", func(t *testing.T) {
+		_, report, err := AnyWithReport(ctx, Options{},
+			func(ctx context.Context) (int, error) { return 1, nil },
+		)
+		if err != nil || report.Winner != 0 || report.Degraded() {
+			t.Fatalf("expected clean win by task 0, got %+v, %v", report, err)
+		}
+	})
+}
@@ -0,0 +1,99 @@
+package await
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Unwrap returns the value and error as a pair, mirroring a task's return values.
+func (r Result[T]) Unwrap() (T, error) {
+	return r.Value, r.Err
+}
+
+// IsOK reports whether the task succeeded.
+func (r Result[T]) IsOK() bool {
+	return r.Err == nil
+}
+
+// OrElse returns the value if the task succeeded, or fallback otherwise.
+func (r Result[T]) OrElse(fallback T) T {
+	if r.Err != nil {
+		return fallback
+	}
+	return r.Value
+}
+
+// MustValue returns the value, panicking if the task failed.
+// Intended for tests and initialization code where failure is a bug.
+func (r Result[T]) MustValue() T {
+	if r.Err != nil {
+		panic("await: MustValue called on failed result: " + r.Err.Error())
+	}
+	return r.Value
+}
+
+// Values returns the values of successful results, preserving order.
+func Values[T any](results []Result[T]) []T {
+	values := make([]T, 0, len(results))
+	for _, r := range results {
+		if r.Err == nil {
+			values = append(values, r.Value)
+		}
+	}
+	return values
+}
+
+// Errors returns the non-nil errors of failed results, preserving order.
+func Errors[T any](results []Result[T]) []error {
+	var errs []error
+	for _, r := range results {
+		if r.Err != nil {
+			errs = append(errs, r.Err)
+		}
+	}
+	return errs
+}
+
+// Partition splits results into the values of successful results and the
+// index-tagged errors of failed ones, both in order.
+func Partition[T any](results []Result[T]) (values []T, failures []IndexedError) {
+	values = make([]T, 0, len(results))
+	for i, r := range results {
+		if r.Err != nil {
+			failures = append(failures, IndexedError{Index: i, Err: r.Err})
+			continue
+		}
+		values = append(values, r.Value)
+	}
+	return values, failures
+}
+
+// CollectErrors joins the errors of all failed results, each prefixed with its
+// task index, using errors.Join. Errors already wrapped in a *TaskError, as
+// with Options.TaskErrors, name their task themselves and are joined as is.
+// Returns nil if every task succeeded.
+func CollectErrors[T any](results []Result[T]) error {
+	var errs []error
+	for i, r := range results {
+		var taskErr *TaskError
+		switch {
+		case r.Err == nil:
+		case errors.As(r.Err, &taskErr):
+			errs = append(errs, r.Err)
+		default:
+			errs = append(errs, fmt.Errorf("task %d: %w", i, r.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// FirstError returns the error of the lowest-indexed failed result, or nil if
+// every task succeeded.
+func FirstError[T any](results []Result[T]) error {
+	for _, r := range results {
+		if r.Err != nil {
+			return r.Err
+		}
+	}
+	return nil
+}
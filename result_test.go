@@ -0,0 +1,118 @@
+package await
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestResultHelpers(t *testing.T) {
+	ok := Result[int]{Value: 1}
+	failed := Result[int]{Value: 5, Err: errors.New("failed")}
+
+	t.Run("Unwrap", func(t *testing.T) {
+		val, err := failed.Unwrap()
+		if val != 5 || err == nil {
+			t.Fatalf("expected {5, failed}, got {%d, %v}", val, err)
+		}
+	})
+
+	t.Run("IsOK", func(t *testing.T) {
+		if !ok.IsOK() || failed.IsOK() {
+			t.Fatal("expected IsOK to reflect the error field")
+		}
+	})
+
+	t.Run("OrElse", func(t *testing.T) {
+		if ok.OrElse(9) != 1 {
+			t.Fatalf("expected 1, got %d", ok.OrElse(9))
+		}
+		if failed.OrElse(9) != 9 {
+			t.Fatalf("expected fallback 9, got %d", failed.OrElse(9))
+		}
+	})
+
+	t.Run("MustValue", func(t *testing.T) {
+		if ok.MustValue() != 1 {
+			t.Fatalf("expected 1, got %d", ok.MustValue())
+		}
+		defer func() {
+			if recover() == nil {
+				t.Fatal("expected panic for failed result")
+			}
+		}()
+		failed.MustValue()
+	})
+
+	t.Run("Values and Errors", func(t *testing.T) {
+		results := []Result[int]{ok, failed, {Value: 3}}
+
+		values := Values(results)
+		if len(values) != 2 || values[0] != 1 || values[1] != 3 {
+			t.Fatalf("expected [1 3], got %v", values)
+		}
+		errs := Errors(results)
+		if len(errs) != 1 || errs[0].Error() != "failed" {
+			t.Fatalf("expected [failed], got %v", errs)
+		}
+		if Errors([]Result[int]{ok}) != nil {
+			t.Fatal("expected nil errors when all succeed")
+		}
+	})
+}
+
+func TestCollectErrors(t *testing.T) {
+	errA := errors.New("a failed")
+	errB := errors.New("b failed")
+	results := []Result[int]{{Value: 1}, {Err: errA}, {Value: 2}, {Err: errB}}
+
+	err := CollectErrors(results)
+	if !errors.Is(err, errA) || !errors.Is(err, errB) {
+		t.Fatalf("expected joined error to contain both errors, got %v", err)
+	}
+	if err.Error() != "task 1: a failed\ntask 3: b failed" {
+		t.Fatalf("expected indexed messages, got %q", err.Error())
+	}
+
+	if CollectErrors([]Result[int]{{Value: 1}}) != nil {
+		t.Fatal("expected nil when all succeed")
+	}
+
+	fail := func(ctx context.Context) (int, error) { return 0, errors.New("boom") }
+	wrapped, _ := AllWithOptions(context.Background(), Options{TaskErrors: true}, fail, Named("orders", fail))
+	if msg := CollectErrors(wrapped).Error(); msg != "task 0: boom\ntask 1 (orders): boom" {
+		t.Fatalf("expected TaskError messages without a second prefix, got %q", msg)
+	}
+}
+
+func TestFirstError(t *testing.T) {
+	errA := errors.New("a failed")
+	results := []Result[int]{{Value: 1}, {Err: errA}, {Err: errors.New("b failed")}}
+
+	if err := FirstError(results); err != errA {
+		t.Fatalf("expected first error, got %v", err)
+	}
+	if FirstError([]Result[int]{{Value: 1}}) != nil {
+		t.Fatal("expected nil when all succeed")
+	}
+}
+
+func TestPartition(t *testing.T) {
+	errA := errors.New("a failed")
+	results := []Result[int]{{Value: 1}, {Err: errA}, {Value: 2}}
+
+	values, failures := Partition(results)
+	if len(values) != 2 || values[0] != 1 || values[1] != 2 {
+		t.Fatalf("expected [1 2], got %v", values)
+	}
+	if len(failures) != 1 || failures[0].Index != 1 || failures[0].Err != errA {
+		t.Fatalf("expected failure at index 1, got %v", failures)
+	}
+	if !errors.Is(failures[0], errA) || failures[0].Error() != "task 1: a failed" {
+		t.Fatalf("expected indexed error wrapping errA, got %q", failures[0].Error())
+	}
+
+	if _, failures := Partition([]Result[int]{{Value: 1}}); failures != nil {
+		t.Fatal("expected nil failures when all succeed")
+	}
+}
@@ -0,0 +1,113 @@
+package retry
+
+import "time"
+
+// Phase is one stage of a strategy built with ChainStrategies.
+type Phase struct {
+	Attempts int      // Retries governed by Strategy; ignored for the last phase
+	Strategy Strategy // Delays and retry decisions during this phase
+}
+
+// ChainStrategies returns a strategy that follows each phase in turn: the
+// first phase's strategy governs its Attempts retries, then the next phase
+// takes over, and the last phase governs all remaining retries. Attempt
+// numbers restart at 1 in each phase, so an ExponentialBackoff following
+// three NoDelay retries starts from its InitialDelay:
+//
+//	retry.ChainStrategies(
+//		retry.Phase{Attempts: 3, Strategy: &retry.NoDelay{}},
+//		retry.Phase{Strategy: &retry.ExponentialBackoff{InitialDelay: time.Second, Multiplier: 2, MaxDelay: time.Minute}},
+//	)
+//
+// ShouldRetry is answered by the phase the attempt belongs to. With no
+// phases the result behaves like NoDelay.
+func ChainStrategies(phases ...Phase) Strategy {
+	if len(phases) == 0 {
+		return &NoDelay{}
+	}
+	return &chainStrategy{phases: phases}
+}
+
+type chainStrategy struct {
+	phases []Phase
+}
+
+// phase returns the strategy governing attempt and the attempt's number
+// within that phase.
+func (c *chainStrategy) phase(attempt int) (Strategy, int) {
+	for _, p := range c.phases[:len(c.phases)-1] {
+		if attempt <= p.Attempts {
+			return p.Strategy, attempt
+		}
+		attempt -= max(p.Attempts, 0)
+	}
+	return c.phases[len(c.phases)-1].Strategy, attempt
+}
+
+func (c *chainStrategy) NextDelay(attempt int) time.Duration {
+	if attempt <= 0 {
+		return 0
+	}
+	s, n := c.phase(attempt)
+	return s.NextDelay(n)
+}
+
+func (c *chainStrategy) ShouldRetry(attempt int, err error) bool {
+	s, n := c.phase(max(attempt, 1))
+	return s.ShouldRetry(n, err)
+}
+
+func (c *chainStrategy) DelayRange(attempt int) (lo, hi time.Duration) {
+	if attempt <= 0 {
+		return 0, 0
+	}
+	s, n := c.phase(attempt)
+	return delayRange(s, n)
+}
+
+// CapDelay returns a strategy that waits like s but never longer than limit.
+// Retry decisions are left to s.
+func CapDelay(s Strategy, limit time.Duration) Strategy {
+	return &clampStrategy{Strategy: s, lo: 0, hi: limit}
+}
+
+// MinDelay returns a strategy that waits like s but never less than floor
+// before a retry. Retry decisions are left to s.
+func MinDelay(s Strategy, floor time.Duration) Strategy {
+	return &clampStrategy{Strategy: s, lo: floor, hi: maxDuration}
+}
+
+// clampStrategy bounds the delays of the embedded strategy to [lo, hi].
+type clampStrategy struct {
+	Strategy
+	lo, hi time.Duration
+}
+
+func (c *clampStrategy) NextDelay(attempt int) time.Duration {
+	if attempt <= 0 {
+		return 0
+	}
+	return c.clamp(c.Strategy.NextDelay(attempt))
+}
+
+func (c *clampStrategy) DelayRange(attempt int) (lo, hi time.Duration) {
+	if attempt <= 0 {
+		return 0, 0
+	}
+	lo, hi = delayRange(c.Strategy, attempt)
+	return c.clamp(lo), c.clamp(hi)
+}
+
+func (c *clampStrategy) clamp(d time.Duration) time.Duration {
+	return min(max(d, c.lo), c.hi)
+}
+
+// delayRange returns the bounds of s's delay before retry attempt, from
+// DelayRange when s implements DelayRanger.
+func delayRange(s DelayPolicy, attempt int) (lo, hi time.Duration) {
+	if r, ok := s.(DelayRanger); ok {
+		return r.DelayRange(attempt)
+	}
+	d := s.NextDelay(attempt)
+	return d, d
+}
@@ -2,6 +2,8 @@ package retry
 
 import (
 	"errors"
+
+	"github.com/remiges-tech/await/errclass"
 )
 
 // RetryIf creates a condition function that retries only on specific errors.
@@ -16,3 +18,23 @@ func RetryIf(errs ...error) func(error) bool {
 		return false
 	}
 }
+
+// DefaultRetryIf is a RetryIf condition built on the shared errclass
+// taxonomy: it retries errors classified as Transient or Throttled, such as
+// timeouts (net.Error with Timeout() true, context and os deadline errors),
+// io.ErrUnexpectedEOF, connection-level syscall errors like ECONNRESET,
+// ECONNREFUSED and EPIPE, and HTTP 408, 429, 500, 502, 503 and 504 statuses,
+// as well as Cancelled errors. It stops on everything else, including
+// permanent errors and errors classified by an errclass.Register classifier
+// as Permanent.
+//
+// Retrying context cancellation lets a cancelled attempt, for example one cut
+// short by AttemptTimeout, be tried again; Do still stops as soon as its own
+// ctx is done.
+func DefaultRetryIf(err error) bool {
+	switch errclass.Classify(err) {
+	case errclass.Transient, errclass.Throttled, errclass.Cancelled:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,134 @@
+package retry
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Duration is a time.Duration that marshals to and from strings such as
+// "250ms" or "1m30s", for retry policies kept in JSON, YAML or environment
+// configuration.
+type Duration time.Duration
+
+// MarshalText returns the duration in time.Duration.String form.
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+// UnmarshalText parses a duration string accepted by time.ParseDuration.
+func (d *Duration) UnmarshalText(text []byte) error {
+	parsed, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// Strategy names accepted by Config.Strategy.
+const (
+	StrategyExponential = "exponential"
+	StrategyLinear      = "linear"
+	StrategyConstant    = "constant"
+	StrategyNone        = "none"
+)
+
+// Config is a serializable description of a retry policy, for loading from
+// configuration files instead of hard-coding Options. Build turns it into
+// Options. Fields not used by the selected strategy are ignored.
+type Config struct {
+	Strategy       string   `json:"strategy" yaml:"strategy"`               // exponential (default), linear, constant or none
+	MaxAttempts    int      `json:"max_attempts" yaml:"max_attempts"`       // Required, > 0
+	AttemptTimeout Duration `json:"attempt_timeout" yaml:"attempt_timeout"` // Optional per-attempt deadline
+	SplitDeadline  bool     `json:"split_deadline" yaml:"split_deadline"`   // Share ctx's deadline among attempts
+	Jitter         float64  `json:"jitter" yaml:"jitter"`                   // Random spread of each delay, 0 to 1
+	InitialDelay   Duration `json:"initial_delay" yaml:"initial_delay"`     // exponential, linear: first delay
+	Multiplier     float64  `json:"multiplier" yaml:"multiplier"`           // exponential: growth factor (default 2)
+	MaxDelay       Duration `json:"max_delay" yaml:"max_delay"`             // exponential: delay cap
+	Increment      Duration `json:"increment" yaml:"increment"`             // linear: added per attempt
+	Delay          Duration `json:"delay" yaml:"delay"`                     // constant: fixed delay
+}
+
+// Build validates the config and returns the equivalent Options. Errors are
+// *ConfigError values naming the offending field.
+func (c Config) Build() (Options, error) {
+	opts := Options{
+		MaxAttempts:    c.MaxAttempts,
+		AttemptTimeout: time.Duration(c.AttemptTimeout),
+		SplitDeadline:  c.SplitDeadline,
+		Jitter:         c.Jitter,
+	}
+	if c.MaxAttempts <= 0 {
+		return Options{}, configError("max_attempts", ErrMaxAttemptsInvalid)
+	}
+	if c.AttemptTimeout < 0 {
+		return Options{}, configError("attempt_timeout", ErrAttemptTimeoutInvalid)
+	}
+	if !validJitter(c.Jitter) {
+		return Options{}, configError("jitter", ErrInvalidJitter)
+	}
+
+	switch strings.ToLower(c.Strategy) {
+	case StrategyExponential, "":
+		multiplier := c.Multiplier
+		if multiplier == 0 {
+			multiplier = 2
+		}
+		switch {
+		case c.InitialDelay <= 0:
+			return Options{}, configError("initial_delay", fmt.Errorf("%w: must be positive, got %v", ErrInvalidBackoff, time.Duration(c.InitialDelay)))
+		case multiplier < 0 || math.IsNaN(multiplier) || math.IsInf(multiplier, 0):
+			return Options{}, configError("multiplier", fmt.Errorf("%w: must be positive and finite, got %v", ErrInvalidBackoff, c.Multiplier))
+		case c.MaxDelay < 0:
+			return Options{}, configError("max_delay", fmt.Errorf("%w: must not be negative, got %v", ErrInvalidBackoff, time.Duration(c.MaxDelay)))
+		}
+		opts.Strategy = &ExponentialBackoff{
+			InitialDelay: time.Duration(c.InitialDelay),
+			Multiplier:   multiplier,
+			MaxDelay:     time.Duration(c.MaxDelay),
+		}
+	case StrategyLinear:
+		switch {
+		case c.InitialDelay < 0:
+			return Options{}, configError("initial_delay", fmt.Errorf("%w: must not be negative, got %v", ErrInvalidBackoff, time.Duration(c.InitialDelay)))
+		case c.Increment < 0:
+			return Options{}, configError("increment", fmt.Errorf("%w: must not be negative, got %v", ErrInvalidBackoff, time.Duration(c.Increment)))
+		}
+		opts.Strategy = &LinearBackoff{
+			InitialDelay: time.Duration(c.InitialDelay),
+			Increment:    time.Duration(c.Increment),
+		}
+	case StrategyConstant:
+		if c.Delay < 0 {
+			return Options{}, configError("delay", fmt.Errorf("%w: must not be negative, got %v", ErrInvalidBackoff, time.Duration(c.Delay)))
+		}
+		opts.Strategy = &ConstantDelay{Delay: time.Duration(c.Delay)}
+	case StrategyNone:
+		opts.Strategy = &NoDelay{}
+	default:
+		return Options{}, configError("strategy", fmt.Errorf("unknown strategy %q", c.Strategy))
+	}
+	return opts, nil
+}
+
+// ConfigError reports an invalid Config field.
+type ConfigError struct {
+	Field string // The field's configuration key, e.g. "max_attempts"
+	Err   error  // What is wrong with it
+}
+
+// Error returns the field name and the problem.
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("retry config: %s: %v", e.Field, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
+
+func configError(field string, err error) error {
+	return &ConfigError{Field: field, Err: err}
+}
@@ -0,0 +1,36 @@
+package retry
+
+import "sync/atomic"
+
+// defaults holds the options set by SetDefaults; nil means the built-in defaults.
+var defaults atomic.Pointer[Options]
+
+// SetDefaults sets the application-wide retry policy returned by
+// DefaultOptions and used by Do and DoWithAttempt when they are called
+// without options. It is safe to call concurrently with retries in progress,
+// which keep the options they started with; it is typically called once at
+// startup. A nil Strategy is replaced by the built-in exponential backoff.
+// Invalid options are rejected and leave the current defaults in place.
+func SetDefaults(opts Options) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	if opts.Strategy == nil {
+		opts.Strategy = builtinStrategy()
+	}
+	defaults.Store(&opts)
+	return nil
+}
+
+// ResetDefaults restores the built-in defaults: exponential backoff from
+// 100ms to 30s with 3 attempts.
+func ResetDefaults() {
+	defaults.Store(nil)
+}
+
+func builtinOptions() Options {
+	return Options{
+		Strategy:    builtinStrategy(),
+		MaxAttempts: 3,
+	}
+}
@@ -3,12 +3,27 @@ package retry
 import (
 	"errors"
 	"fmt"
+
+	"github.com/remiges-tech/await/errclass"
 )
 
 var (
 	// ErrMaxAttemptsInvalid is returned when max attempts is <= 0.
 	ErrMaxAttemptsInvalid = errors.New("max attempts must be greater than 0")
 
+	// ErrAttemptTimeoutInvalid is returned when AttemptTimeout is negative.
+	ErrAttemptTimeoutInvalid = errors.New("attempt timeout must not be negative")
+
+	// ErrInvalidJitter is returned when Jitter is not between 0 and 1.
+	ErrInvalidJitter = errors.New("jitter must be between 0 and 1")
+
+	// ErrInvalidBackoff is returned by strategy constructors given out-of-range values.
+	ErrInvalidBackoff = errors.New("invalid backoff configuration")
+
+	// ErrConditionNotMet is the last error recorded by Until when attempts run
+	// out before fn returns a ready value.
+	ErrConditionNotMet = errors.New("condition not met")
+
 	// ErrPermanent is a sentinel error used to mark errors as non-retryable.
 	// Wrap errors with Permanent() to prevent retry attempts.
 	ErrPermanent = errors.New("permanent error")
@@ -52,6 +67,11 @@ func (p *PermanentError) Is(target error) bool {
 	return target == ErrPermanent
 }
 
+// ErrorClass classifies the error as errclass.Permanent, whatever it wraps.
+func (p *PermanentError) ErrorClass() errclass.Class {
+	return errclass.Permanent
+}
+
 // Permanent wraps an error to mark it as non-retryable.
 // Use this for errors that won't succeed on retry (e.g., invalid input, auth failures).
 func Permanent(err error) error {
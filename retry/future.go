@@ -0,0 +1,16 @@
+package retry
+
+import (
+	"context"
+
+	"github.com/remiges-tech/await"
+)
+
+// Go runs Do in a background goroutine and returns a Future for its outcome,
+// so retried operations can be started early and awaited later or combined
+// with other futures.
+func Go[T any](ctx context.Context, fn func(context.Context) (T, error), opts Options) *await.Future[T] {
+	return await.Go(ctx, func(ctx context.Context) (T, error) {
+		return Do(ctx, fn, opts)
+	})
+}
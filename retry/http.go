@@ -0,0 +1,77 @@
+package retry
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// HTTPError reports an HTTP response with an unsuccessful status code, so
+// REST clients can return it from the retried function and select retryable
+// statuses with OnHTTPStatus.
+type HTTPError struct {
+	StatusCode int           // The response's status code
+	RetryAfter time.Duration // Optional server-requested wait, e.g. from a Retry-After header
+	Err        error         // Optional underlying error, such as a decoded error body
+}
+
+// Error returns the status code and text, followed by the wrapped error if any.
+func (e *HTTPError) Error() string {
+	msg := fmt.Sprintf("http status %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Err != nil {
+		return msg + ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Unwrap returns the underlying error.
+func (e *HTTPError) Unwrap() error {
+	return e.Err
+}
+
+// Backoff returns RetryAfter, so a server-requested wait overrides the
+// strategy's delay.
+func (e *HTTPError) Backoff() time.Duration {
+	return e.RetryAfter
+}
+
+// HTTPStatusCode returns the response's status code.
+func (e *HTTPError) HTTPStatusCode() int {
+	return e.StatusCode
+}
+
+// StatusCoder is implemented by errors that carry an HTTP status code.
+// Implement it on an HTTP client library's own error type to use it with
+// OnHTTPStatus without converting to HTTPError.
+type StatusCoder interface {
+	HTTPStatusCode() int
+}
+
+// HTTPStatus returns the status code of the first error in err's chain that
+// implements StatusCoder.
+func HTTPStatus(err error) (int, bool) {
+	var sc StatusCoder
+	if errors.As(err, &sc) {
+		return sc.HTTPStatusCode(), true
+	}
+	return 0, false
+}
+
+// OnHTTPStatus creates a condition function that retries only errors carrying
+// one of the given HTTP status codes.
+// Example: OnHTTPStatus(429, 502, 503, 504) retries rate limiting and gateway errors.
+func OnHTTPStatus(codes ...int) func(error) bool {
+	return func(err error) bool {
+		status, ok := HTTPStatus(err)
+		if !ok {
+			return false
+		}
+		for _, code := range codes {
+			if status == code {
+				return true
+			}
+		}
+		return false
+	}
+}
@@ -0,0 +1,41 @@
+package retry
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+type idempotencyKeyCtx struct{}
+
+func withIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyCtx{}, key)
+}
+
+// IdempotencyKey returns the key Options.IdempotencyKey produced for the
+// current attempt, and whether one was set. Send it with the request (for
+// example as an Idempotency-Key header) so the server can deduplicate retries.
+func IdempotencyKey(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(idempotencyKeyCtx{}).(string)
+	return key, ok
+}
+
+// StaticKey returns an Options.IdempotencyKey function that uses key for every
+// attempt, which is what servers that deduplicate retried requests expect.
+func StaticKey(key string) func(attempt int) string {
+	return func(int) string {
+		return key
+	}
+}
+
+// NewIdempotencyKey returns a random 128-bit key encoded as 32 hex characters.
+// Generate one per logical operation, not per Options value:
+//
+//	opts.IdempotencyKey = retry.StaticKey(retry.NewIdempotencyKey())
+func NewIdempotencyKey() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic("retry: crypto/rand failed: " + err.Error())
+	}
+	return hex.EncodeToString(b[:])
+}
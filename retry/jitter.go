@@ -0,0 +1,63 @@
+package retry
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// jitterer spreads the delays of one retry loop by Options.Jitter. A nil
+// jitterer leaves delays unchanged.
+type jitterer struct {
+	fraction float64
+	rng      *rand.Rand // nil uses the global source
+}
+
+// newJitterer returns the jitterer for a retry loop, seeded from
+// Options.JitterSeed when it is set, or nil when Jitter is zero.
+func newJitterer(opts Options) *jitterer {
+	if opts.Jitter == 0 {
+		return nil
+	}
+	j := &jitterer{fraction: opts.Jitter}
+	if opts.JitterSeed != 0 {
+		j.rng = rand.New(rand.NewSource(opts.JitterSeed))
+	}
+	return j
+}
+
+// apply returns d scaled by a random factor in [1-fraction, 1+fraction].
+func (j *jitterer) apply(d time.Duration) time.Duration {
+	if j == nil || d <= 0 {
+		return d
+	}
+	var u float64
+	if j.rng != nil {
+		u = j.rng.Float64()
+	} else {
+		u = rand.Float64()
+	}
+	return scaleDelay(d, 1+j.fraction*(2*u-1))
+}
+
+// bounds returns the smallest and largest delays apply can return for d.
+func (j *jitterer) bounds(d time.Duration) (time.Duration, time.Duration) {
+	if j == nil {
+		return d, d
+	}
+	return scaleDelay(d, 1-j.fraction), scaleDelay(d, 1+j.fraction)
+}
+
+// scaleDelay multiplies d by f, capping the result at maxDuration.
+func scaleDelay(d time.Duration, f float64) time.Duration {
+	scaled := float64(d) * f
+	if scaled >= float64(maxDuration) {
+		return maxDuration
+	}
+	return time.Duration(scaled)
+}
+
+// validJitter reports whether f can be used as Options.Jitter.
+func validJitter(f float64) bool {
+	return f >= 0 && f <= 1 && !math.IsNaN(f)
+}
@@ -0,0 +1,50 @@
+package retry
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Attribute keys used for structured log events emitted through Options.Logger.
+const (
+	LogKeyAttempt     = "attempt"
+	LogKeyMaxAttempts = "max_attempts"
+	LogKeyDelay       = "delay"
+	LogKeyError       = "error"
+	LogKeyPermanent   = "permanent"
+)
+
+func logRetry(ctx context.Context, logger *slog.Logger, attempt, maxAttempts int, delay time.Duration, err error) {
+	if logger == nil {
+		return
+	}
+	logger.LogAttrs(ctx, slog.LevelInfo, "retry attempt failed",
+		slog.Int(LogKeyAttempt, attempt),
+		slog.Int(LogKeyMaxAttempts, maxAttempts),
+		slog.Duration(LogKeyDelay, delay),
+		slog.String(LogKeyError, err.Error()),
+	)
+}
+
+func logNonRetryable(ctx context.Context, logger *slog.Logger, attempt int, err error) {
+	if logger == nil {
+		return
+	}
+	logger.LogAttrs(ctx, slog.LevelWarn, "retry stopped on non-retryable error",
+		slog.Int(LogKeyAttempt, attempt),
+		slog.Bool(LogKeyPermanent, IsPermanentError(err)),
+		slog.String(LogKeyError, err.Error()),
+	)
+}
+
+func logExhausted(ctx context.Context, logger *slog.Logger, attempts, maxAttempts int, err error) {
+	if logger == nil {
+		return
+	}
+	logger.LogAttrs(ctx, slog.LevelError, "retry attempts exhausted",
+		slog.Int(LogKeyAttempt, attempts),
+		slog.Int(LogKeyMaxAttempts, maxAttempts),
+		slog.String(LogKeyError, err.Error()),
+	)
+}
@@ -0,0 +1,127 @@
+package retry
+
+import (
+	"context"
+	"time"
+
+	"github.com/remiges-tech/await"
+	"github.com/remiges-tech/await/errclass"
+)
+
+// DoParallel is like Do, but after the first attempt fails it runs the
+// following attempts in rounds of ParallelAttempts concurrent attempts
+// (default 2) and takes the first success, cancelling the rest of the round.
+// It trades extra load for latency, a hybrid of retrying and hedging, and is
+// only safe for idempotent operations.
+//
+// Rounds are separated by the strategy's delay and count every attempt they
+// start toward MaxAttempts. A round fails when all of its attempts fail; if
+// any of them failed with a non-retryable error, that error ends the loop.
+// OnAttemptStart is called for each attempt of a round before the round
+// starts, and the other hooks are called from the calling goroutine, so none
+// of them needs to be safe for concurrent use.
+//
+// When a round fails with an error errclass classifies as Throttled, the
+// next round runs a single attempt rather than adding parallel load.
+func DoParallel[T any](ctx context.Context, fn func(context.Context) (T, error), opts Options) (T, error) {
+	var zero T
+	if err := opts.Validate(); err != nil {
+		return zero, err
+	}
+	if opts.Strategy == nil {
+		opts.Strategy = defaultStrategy()
+	}
+	width := opts.ParallelAttempts
+	if width <= 0 {
+		width = 2
+	}
+	defer await.TrackRetryLoop()()
+
+	start := time.Now()
+	jitter := newJitterer(opts)
+	attempts := 0
+	var lastErr error
+	var rep Report
+	giveUp := func(err error) (T, error) {
+		notifyGiveUp(ctx, opts, err, attempts)
+		sendReport(opts, rep, attempts, err)
+		return zero, err
+	}
+
+	for attempts < opts.MaxAttempts {
+		if err := ctx.Err(); err != nil {
+			return giveUp(err)
+		}
+
+		// Hedging a throttled dependency only adds to its load, so a round
+		// after a Throttled failure runs a single attempt.
+		n := 1
+		if attempts > 0 && errclass.Classify(lastErr) != errclass.Throttled {
+			n = min(width, opts.MaxAttempts-attempts)
+		}
+		first := attempts + 1
+		tasks := make([]await.Task[T], n)
+		for i := range tasks {
+			attempt := first + i
+			if opts.OnAttemptStart != nil {
+				opts.OnAttemptStart(attempt)
+			}
+			await.RecordAttempt(ctx, attempt)
+			tasks[i] = func(ctx context.Context) (T, error) {
+				return runAttempt(ctx, func(ctx context.Context, _ int) (T, error) {
+					return fn(ctx)
+				}, attempt, opts)
+			}
+		}
+		attempts += n
+
+		roundStart := time.Now()
+		val, report, err := await.AnyWithReport(ctx, await.Options{}, tasks...)
+		rep.Executing += time.Since(roundStart)
+		if err == nil {
+			if opts.OnSuccess != nil {
+				opts.OnSuccess(first+report.Winner, time.Since(start))
+			}
+			sendReport(opts, rep, attempts, nil)
+			return val, nil
+		}
+
+		// Prefer a non-retryable failure from the round; otherwise keep the
+		// last one to finish.
+		for _, f := range report.Failures {
+			lastErr = f.Err
+			if !shouldRetryError(opts, f.Err) || !opts.Strategy.ShouldRetry(first+f.Index, f.Err) {
+				logNonRetryable(ctx, opts.Logger, first+f.Index, f.Err)
+				return giveUp(f.Err)
+			}
+		}
+		if lastErr == nil {
+			lastErr = err
+		}
+
+		if attempts >= opts.MaxAttempts {
+			break
+		}
+
+		if opts.OnRetry != nil {
+			opts.OnRetry(attempts, lastErr)
+		}
+
+		delay := calculateDelay(opts, attempts, lastErr, jitter)
+		logRetry(ctx, opts.Logger, attempts, opts.MaxAttempts, delay, lastErr)
+		publishRetry(attempts, delay, lastErr)
+
+		waitStart := time.Now()
+		err = waitForRetry(ctx, delay)
+		rep.Waiting += time.Since(waitStart)
+		if err != nil {
+			return giveUp(err)
+		}
+	}
+
+	logExhausted(ctx, opts.Logger, attempts, opts.MaxAttempts, lastErr)
+	return giveUp(&RetryError{
+		LastError: lastErr,
+		Attempts:  attempts,
+	})
+}
@@ -0,0 +1,66 @@
+package retry
+
+import "time"
+
+// DelayRanger is implemented by strategies whose delays vary between calls,
+// such as jittered backoff, to report the bounds NextDelay can return.
+type DelayRanger interface {
+	DelayRange(attempt int) (min, max time.Duration)
+}
+
+// DelayRange bounds the delay before one retry.
+type DelayRange struct {
+	Min time.Duration
+	Max time.Duration
+}
+
+// Plan returns the delays opts would wait between attempts if every attempt
+// failed, without running anything: entry i is the wait after attempt i+1.
+// It covers attempts attempts, or opts.MaxAttempts when attempts <= 0, so the
+// result has one entry fewer. Delays hinted by errors are not included.
+// Useful for documenting a policy and for asserting on it in tests.
+//
+// With Jitter, the delays are a random sample; set JitterSeed to get exactly
+// the delays each Do call with the same options waits.
+func Plan(opts Options, attempts int) []time.Duration {
+	strategy, n := planOf(opts, attempts)
+	if n <= 1 {
+		return nil
+	}
+	jitter := newJitterer(opts)
+	delays := make([]time.Duration, n-1)
+	for i := range delays {
+		delays[i] = jitter.apply(strategy.NextDelay(i + 1))
+	}
+	return delays
+}
+
+// PlanRange is like Plan but returns the bounds of each delay. For strategies
+// implementing DelayRanger they come from DelayRange; for others Min and Max
+// are both the strategy's delay. Options.Jitter widens the bounds.
+func PlanRange(opts Options, attempts int) []DelayRange {
+	strategy, n := planOf(opts, attempts)
+	if n <= 1 {
+		return nil
+	}
+	jitter := newJitterer(opts)
+	ranges := make([]DelayRange, n-1)
+	for i := range ranges {
+		lo, hi := delayRange(strategy, i+1)
+		ranges[i].Min, _ = jitter.bounds(lo)
+		_, ranges[i].Max = jitter.bounds(hi)
+	}
+	return ranges
+}
+
+// planOf resolves the strategy and number of attempts Plan covers.
+func planOf(opts Options, attempts int) (Strategy, int) {
+	strategy := opts.Strategy
+	if strategy == nil {
+		strategy = defaultStrategy()
+	}
+	if attempts <= 0 {
+		attempts = opts.MaxAttempts
+	}
+	return strategy, attempts
+}
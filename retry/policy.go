@@ -0,0 +1,53 @@
+package retry
+
+import "time"
+
+// DelayPolicyFunc adapts a function to a DelayPolicy.
+type DelayPolicyFunc func(attempt int) time.Duration
+
+// NextDelay calls f.
+func (f DelayPolicyFunc) NextDelay(attempt int) time.Duration {
+	return f(attempt)
+}
+
+// RetryPolicyFunc adapts a function to a RetryPolicy.
+type RetryPolicyFunc func(attempt int, err error) bool
+
+// ShouldRetry calls f.
+func (f RetryPolicyFunc) ShouldRetry(attempt int, err error) bool {
+	return f(attempt, err)
+}
+
+// RetryUnlessPermanent retries every error except permanent ones, the
+// decision the built-in strategies make.
+var RetryUnlessPermanent RetryPolicy = RetryPolicyFunc(func(_ int, err error) bool {
+	return !IsPermanentError(err)
+})
+
+// Combine builds a Strategy that takes its delays from delay and its retry
+// decisions from policy, so either can be swapped without writing a
+// CustomStrategy. Every Strategy is also a DelayPolicy and a RetryPolicy, so
+// an existing strategy's delays can be kept with a different predicate:
+//
+//	retry.Combine(&retry.ExponentialBackoff{InitialDelay: time.Second, Multiplier: 2, MaxDelay: time.Minute},
+//		retry.RetryPolicyFunc(func(attempt int, err error) bool { return retry.DefaultRetryIf(err) }))
+//
+// A nil delay retries immediately; a nil policy uses RetryUnlessPermanent.
+func Combine(delay DelayPolicy, policy RetryPolicy) Strategy {
+	if delay == nil {
+		delay = &NoDelay{}
+	}
+	if policy == nil {
+		policy = RetryUnlessPermanent
+	}
+	return &combinedStrategy{DelayPolicy: delay, RetryPolicy: policy}
+}
+
+type combinedStrategy struct {
+	DelayPolicy
+	RetryPolicy
+}
+
+func (c *combinedStrategy) DelayRange(attempt int) (lo, hi time.Duration) {
+	return delayRange(c.DelayPolicy, attempt)
+}
@@ -0,0 +1,23 @@
+package retry
+
+import "time"
+
+// Report breaks down where the time of one retry call went, so slow calls can
+// be blamed on the dependency (Executing) or on the backoff policy (Waiting).
+// It is passed to Options.OnReport once the call returns.
+type Report struct {
+	Attempts  int           // Attempts started
+	Executing time.Duration // Time spent inside attempts; for DoParallel, the wall-clock time of each round
+	Waiting   time.Duration // Time spent in backoff delays between attempts
+	Err       error         // The error the call returned, or nil
+}
+
+// sendReport passes rep to OnReport, if set, with the call's final error.
+func sendReport(opts Options, rep Report, attempts int, err error) {
+	if opts.OnReport == nil {
+		return
+	}
+	rep.Attempts = attempts
+	rep.Err = err
+	opts.OnReport(rep)
+}
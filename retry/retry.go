@@ -2,34 +2,111 @@ package retry
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
 	"time"
+
+	"github.com/remiges-tech/await"
 )
 
 // Strategy defines the retry behavior including delays and retry conditions.
+// It combines a DelayPolicy and a RetryPolicy; use Combine to build one from
+// separate policies.
 type Strategy interface {
+	DelayPolicy
+	RetryPolicy
+}
+
+// DelayPolicy decides how long to wait before each retry.
+type DelayPolicy interface {
 	// NextDelay returns the delay before the next retry attempt.
 	NextDelay(attempt int) time.Duration
+}
+
+// RetryPolicy decides whether a failed attempt is retried.
+type RetryPolicy interface {
 	// ShouldRetry determines if a retry should be attempted.
 	ShouldRetry(attempt int, err error) bool
 }
 
+// BackoffHinter is implemented by errors that say how long to wait before the
+// next attempt, such as a throttling response carrying Retry-After. A positive
+// Backoff replaces the strategy's delay for that retry.
+type BackoffHinter interface {
+	Backoff() time.Duration
+}
+
+// SeverityHinter is implemented by errors that grade how hard to back off.
+// The strategy's delay is multiplied by a positive Severity, so 2 waits twice
+// as long and 0.5 half as long; other values are ignored. A Backoff hint on
+// the same error takes precedence.
+type SeverityHinter interface {
+	Severity() float64
+}
+
 // Options configures retry behavior including strategy, conditions, and callbacks.
 type Options struct {
-	Strategy    Strategy                     // Determines delay between attempts
-	MaxAttempts int                          // Maximum number of attempts (must be > 0)
-	OnRetry     func(attempt int, err error) // Called before each retry
-	RetryIf     func(error) bool             // Optional condition to check if error is retryable
+	Strategy         Strategy                                              // Determines delay between attempts (defaults to DefaultOptions' strategy)
+	MaxAttempts      int                                                   // Maximum number of attempts (must be > 0)
+	AttemptTimeout   time.Duration                                         // Optional deadline for each attempt; zero means attempts share ctx's deadline
+	SplitDeadline    bool                                                  // Give each attempt an equal share of the time left before ctx's deadline
+	OnRetry          func(attempt int, err error)                          // Called before each retry
+	OnAttemptStart   func(attempt int)                                     // Called before each attempt, including the first
+	OnSuccess        func(attempt int, elapsed time.Duration)              // Called once when an attempt succeeds, with time since Do started
+	OnGiveUp         func(err error, attempts int)                         // Called once when Do returns an error after at least one attempt
+	OnExhausted      func(ctx context.Context, err error, attempts int)    // Called once when the operation fails for good; not on ctx cancellation
+	OnReport         func(Report)                                          // Called once when the call returns, with time spent executing vs waiting
+	BeforeAttempt    func(ctx context.Context) (cleanup func(), err error) // Optional per-attempt setup; cleanup runs when the attempt returns
+	RetryIf          func(error) bool                                      // Optional condition to check if error is retryable
+	IdempotencyKey   func(attempt int) string                              // Optional key per attempt, readable by fn via IdempotencyKey(ctx)
+	ParallelAttempts int                                                   // DoParallel: attempts run concurrently per round after the first failure (default 2)
+	ValidateResult   func(value any) error                                 // Optional check of each successful value; a rejected value fails the attempt
+	Jitter           float64                                               // Spread each strategy delay randomly by up to this fraction, 0 to 1 (e.g. 0.2 = ±20%)
+	JitterSeed       int64                                                 // Seed for Jitter so each call reproduces the same delays; 0 uses the global random source
+	Logger           *slog.Logger                                          // Optional structured logger for retry events
 }
 
-// DefaultOptions returns default options with exponential backoff and 3 attempts.
+// DefaultOptions returns the options set by SetDefaults, or, if it has not
+// been called, exponential backoff with 3 attempts.
 func DefaultOptions() Options {
-	return Options{
-		Strategy: &ExponentialBackoff{
-			InitialDelay: 100 * time.Millisecond,
-			Multiplier:   2,
-			MaxDelay:     30 * time.Second,
-		},
-		MaxAttempts: 3,
+	if d := defaults.Load(); d != nil {
+		return *d
+	}
+	return builtinOptions()
+}
+
+// Validate reports whether the options can be used with Do. It is safe to call
+// at configuration-load time; a nil Strategy is valid and falls back to the
+// DefaultOptions strategy.
+func (o Options) Validate() error {
+	if o.MaxAttempts <= 0 {
+		return ErrMaxAttemptsInvalid
+	}
+	if o.AttemptTimeout < 0 {
+		return ErrAttemptTimeoutInvalid
+	}
+	if !validJitter(o.Jitter) {
+		return ErrInvalidJitter
+	}
+	return nil
+}
+
+// defaultStrategy returns the strategy used when Options.Strategy is nil:
+// the one set by SetDefaults, or the built-in exponential backoff.
+func defaultStrategy() Strategy {
+	if d := defaults.Load(); d != nil {
+		return d.Strategy
+	}
+	return builtinStrategy()
+}
+
+func builtinStrategy() Strategy {
+	return &ExponentialBackoff{
+		InitialDelay: 100 * time.Millisecond,
+		Multiplier:   2,
+		MaxDelay:     30 * time.Second,
 	}
 }
 
@@ -37,31 +114,77 @@ func DefaultOptions() Options {
 // It stops retrying when the function succeeds, a permanent error occurs,
 // or the context is cancelled. Returns the last error wrapped in RetryError
 // if all attempts fail.
-func Do[T any](ctx context.Context, fn func(context.Context) (T, error), opts Options) (T, error) {
-	var zero T
-	if opts.MaxAttempts <= 0 {
-		return zero, ErrMaxAttemptsInvalid
+//
+// Called without options, Do uses DefaultOptions, so an application-wide
+// policy set with SetDefaults applies; explicit options replace it entirely.
+// Only the first options value is used.
+func Do[T any](ctx context.Context, fn func(context.Context) (T, error), opts ...Options) (T, error) {
+	return DoWithAttempt(ctx, func(ctx context.Context, _ int) (T, error) {
+		return fn(ctx)
+	}, opts...)
+}
+
+// DoWithAttempt is like Do but passes the 1-based attempt number to fn, so the
+// function can vary its input per attempt (e.g., switch mirror URLs or regenerate
+// idempotency keys) without capturing a mutable counter in a closure.
+func DoWithAttempt[T any](ctx context.Context, fn func(ctx context.Context, attempt int) (T, error), opts ...Options) (T, error) {
+	o := DefaultOptions()
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	if err := o.Validate(); err != nil {
+		var zero T
+		return zero, err
+	}
+	if o.Strategy == nil {
+		o.Strategy = defaultStrategy()
 	}
+	return run(ctx, fn, o)
+}
 
+// run is the retry loop shared by DoWithAttempt and Retryer. opts must already
+// be validated and have a non-nil Strategy.
+func run[T any](ctx context.Context, fn func(ctx context.Context, attempt int) (T, error), opts Options) (T, error) {
+	var zero T
+	defer await.TrackRetryLoop()()
+	start := time.Now()
+	jitter := newJitterer(opts)
 	var lastErr error
+	attempts := 0
+	var rep Report
+	giveUp := func(err error) (T, error) {
+		notifyGiveUp(ctx, opts, err, attempts)
+		sendReport(opts, rep, attempts, err)
+		return zero, err
+	}
+
 	for attempt := 1; attempt <= opts.MaxAttempts; attempt++ {
 		if err := ctx.Err(); err != nil {
-			return zero, err
+			return giveUp(err)
+		}
+
+		if opts.OnAttemptStart != nil {
+			opts.OnAttemptStart(attempt)
 		}
+		await.RecordAttempt(ctx, attempt)
+		attempts = attempt
 
-		result, err := fn(ctx)
+		attemptStart := time.Now()
+		result, err := runAttempt(ctx, fn, attempt, opts)
+		rep.Executing += time.Since(attemptStart)
 		if err == nil {
+			if opts.OnSuccess != nil {
+				opts.OnSuccess(attempt, time.Since(start))
+			}
+			sendReport(opts, rep, attempt, nil)
 			return result, nil
 		}
 
 		lastErr = err
 
-		if !shouldRetryError(opts, err) {
-			return zero, err
-		}
-
-		if !opts.Strategy.ShouldRetry(attempt, err) {
-			return zero, err
+		if !shouldRetryError(opts, err) || !opts.Strategy.ShouldRetry(attempt, err) {
+			logNonRetryable(ctx, opts.Logger, attempt, err)
+			return giveUp(err)
 		}
 
 		if isLastAttempt(attempt, opts.MaxAttempts) {
@@ -72,16 +195,45 @@ func Do[T any](ctx context.Context, fn func(context.Context) (T, error), opts Op
 			opts.OnRetry(attempt, err)
 		}
 
-		delay := calculateDelay(opts, attempt)
+		delay := calculateDelay(opts, attempt, err, jitter)
+		logRetry(ctx, opts.Logger, attempt, opts.MaxAttempts, delay, err)
+		publishRetry(attempt, delay, err)
 
-		if err := waitForRetry(ctx, delay); err != nil {
-			return zero, err
+		waitStart := time.Now()
+		err = waitForRetry(ctx, delay)
+		rep.Waiting += time.Since(waitStart)
+		if err != nil {
+			return giveUp(err)
 		}
 	}
 
-	return zero, &RetryError{
+	logExhausted(ctx, opts.Logger, attempts, opts.MaxAttempts, lastErr)
+	return giveUp(&RetryError{
 		LastError: lastErr,
 		Attempts:  opts.MaxAttempts,
+	})
+}
+
+// publishRetry publishes an await.RetryScheduled event when anyone is
+// subscribed to the await event bus.
+func publishRetry(attempt int, delay time.Duration, err error) {
+	if !await.Subscribed() {
+		return
+	}
+	await.Publish(await.RetryScheduled{Time: time.Now(), Attempt: attempt, Delay: delay, Err: err})
+}
+
+// notifyGiveUp calls OnGiveUp and, unless ctx is done, OnExhausted once the
+// retry loop returns err after attempts attempts.
+func notifyGiveUp(ctx context.Context, opts Options, err error, attempts int) {
+	if attempts == 0 {
+		return
+	}
+	if opts.OnGiveUp != nil {
+		opts.OnGiveUp(err, attempts)
+	}
+	if opts.OnExhausted != nil && ctx.Err() == nil {
+		opts.OnExhausted(ctx, err, attempts)
 	}
 }
 
@@ -106,6 +258,71 @@ func WithOnRetry(onRetry func(attempt int, err error)) Options {
 	return opts
 }
 
+// runAttempt calls fn once, attaching the attempt's idempotency key and
+// bounding it by AttemptTimeout or its SplitDeadline share when configured.
+// The attempt context is released as soon as fn returns so a timed-out
+// attempt does not shorten the parent context used for backoff.
+//
+// BeforeAttempt runs first, under the same context; its error fails the
+// attempt without calling fn, and its cleanup runs as soon as fn returns,
+// before any retry delay. A value rejected by ValidateResult fails the
+// attempt with an error wrapping await.ErrInvalidResult.
+func runAttempt[T any](ctx context.Context, fn func(ctx context.Context, attempt int) (T, error), attempt int, opts Options) (T, error) {
+	if opts.IdempotencyKey != nil {
+		ctx = withIdempotencyKey(ctx, opts.IdempotencyKey(attempt))
+	}
+	if timeout := attemptTimeout(ctx, opts, attempt); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	if opts.BeforeAttempt != nil {
+		cleanup, err := opts.BeforeAttempt(ctx)
+		if cleanup != nil {
+			defer cleanup()
+		}
+		if err != nil {
+			var zero T
+			return zero, err
+		}
+	}
+	val, err := fn(ctx, attempt)
+	if err == nil && opts.ValidateResult != nil {
+		if verr := opts.ValidateResult(val); verr != nil {
+			var zero T
+			return zero, fmt.Errorf("%w: %w", await.ErrInvalidResult, verr)
+		}
+	}
+	return val, err
+}
+
+// attemptTimeout returns the deadline for one attempt, or zero for none.
+// With SplitDeadline the time left before ctx's deadline is divided evenly
+// among the remaining attempts, bounded by AttemptTimeout when both are set.
+func attemptTimeout(ctx context.Context, opts Options, attempt int) time.Duration {
+	timeout := opts.AttemptTimeout
+	if !opts.SplitDeadline {
+		return timeout
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return timeout
+	}
+	left := opts.MaxAttempts - attempt + 1
+	if left < 1 {
+		left = 1
+	}
+	share := time.Until(deadline) / time.Duration(left)
+	if share <= 0 {
+		// Already past the deadline; ctx itself will fail the attempt.
+		return timeout
+	}
+	if timeout <= 0 || share < timeout {
+		return share
+	}
+	return timeout
+}
+
 func shouldRetryError(opts Options, err error) bool {
 	if opts.RetryIf == nil {
 		return true
@@ -117,8 +334,25 @@ func isLastAttempt(attempt, maxAttempts int) bool {
 	return attempt >= maxAttempts
 }
 
-func calculateDelay(opts Options, attempt int) time.Duration {
-	return opts.Strategy.NextDelay(attempt)
+// calculateDelay returns the wait before the next attempt: the error's own
+// Backoff hint when it has one, otherwise the strategy's delay scaled by the
+// error's Severity and spread by jitter. Backoff hints are used as is.
+func calculateDelay(opts Options, attempt int, err error, jitter *jitterer) time.Duration {
+	var hint BackoffHinter
+	if errors.As(err, &hint) {
+		if d := hint.Backoff(); d > 0 {
+			return d
+		}
+	}
+
+	delay := opts.Strategy.NextDelay(attempt)
+	var sev SeverityHinter
+	if errors.As(err, &sev) {
+		if f := sev.Severity(); f > 0 && !math.IsInf(f, 0) {
+			delay = scaleDelay(delay, f)
+		}
+	}
+	return jitter.apply(delay)
 }
 
 func waitForRetry(ctx context.Context, delay time.Duration) error {
@@ -1,10 +1,26 @@
 package retry
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
 	"testing"
 	"time"
+
+	"github.com/remiges-tech/await"
 )
 
 // TestCustomError is used for testing error type conditions.
@@ -214,6 +230,40 @@ func TestDo(t *testing.T) {
 			t.Fatalf("expected ErrMaxAttemptsInvalid, got %v", err)
 		}
 	})
+
+	t.Run("nil strategy uses default", func(t *testing.T) {
+		attempts := 0
+		fn := func(ctx context.Context) (int, error) {
+			attempts++
+			if attempts < 2 {
+				return 0, errors.New("temporary")
+			}
+			return 42, nil
+		}
+
+		result, err := Do(context.Background(), fn, Options{MaxAttempts: 3})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if result != 42 {
+			t.Fatalf("expected 42, got %d", result)
+		}
+		if attempts != 2 {
+			t.Fatalf("expected 2 attempts, got %d", attempts)
+		}
+	})
+}
+
+func TestOptionsValidate(t *testing.T) {
+	if err := DefaultOptions().Validate(); err != nil {
+		t.Errorf("expected default options to be valid, got %v", err)
+	}
+	if err := (Options{MaxAttempts: 1}).Validate(); err != nil {
+		t.Errorf("expected nil strategy to be valid, got %v", err)
+	}
+	if err := (Options{Strategy: &NoDelay{}}).Validate(); !errors.Is(err, ErrMaxAttemptsInvalid) {
+		t.Errorf("expected ErrMaxAttemptsInvalid, got %v", err)
+	}
 }
 
 func TestStrategies(t *testing.T) {
@@ -247,6 +297,23 @@ func TestStrategies(t *testing.T) {
 		}
 	})
 
+	t.Run("ExponentialBackoff overflow", func(t *testing.T) {
+		capped := &ExponentialBackoff{InitialDelay: time.Second, Multiplier: 10, MaxDelay: time.Hour}
+		if delay := capped.NextDelay(1000); delay != time.Hour {
+			t.Errorf("expected delay capped at 1h, got %v", delay)
+		}
+
+		uncapped := &ExponentialBackoff{InitialDelay: time.Second, Multiplier: 10}
+		for _, attempt := range []int{19, 20, 100, math.MaxInt32} {
+			if delay := uncapped.NextDelay(attempt); delay <= 0 {
+				t.Errorf("attempt %d: expected positive delay, got %v", attempt, delay)
+			}
+		}
+		if delay := uncapped.NextDelay(100); delay != time.Duration(math.MaxInt64) {
+			t.Errorf("expected max duration, got %v", delay)
+		}
+	})
+
 	t.Run("LinearBackoff", func(t *testing.T) {
 		strategy := &LinearBackoff{
 			InitialDelay: 100 * time.Millisecond,
@@ -302,6 +369,35 @@ func TestStrategies(t *testing.T) {
 	})
 }
 
+func TestNewExponentialBackoff(t *testing.T) {
+	strategy, err := NewExponentialBackoff(100*time.Millisecond, 2, time.Second)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if delay := strategy.NextDelay(3); delay != 400*time.Millisecond {
+		t.Errorf("expected 400ms, got %v", delay)
+	}
+
+	invalid := []struct {
+		name       string
+		initial    time.Duration
+		multiplier float64
+		max        time.Duration
+	}{
+		{"zero initial delay", 0, 2, time.Second},
+		{"negative multiplier", time.Millisecond, -1, time.Second},
+		{"infinite multiplier", time.Millisecond, math.Inf(1), time.Second},
+		{"zero max delay", time.Millisecond, 2, 0},
+	}
+	for _, tc := range invalid {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := NewExponentialBackoff(tc.initial, tc.multiplier, tc.max); !errors.Is(err, ErrInvalidBackoff) {
+				t.Errorf("expected ErrInvalidBackoff, got %v", err)
+			}
+		})
+	}
+}
+
 func TestConditions(t *testing.T) {
 	err1 := errors.New("error1")
 	err2 := errors.New("error2")
@@ -320,6 +416,86 @@ func TestConditions(t *testing.T) {
 			t.Error("expected false for err3")
 		}
 	})
+
+	t.Run("DefaultRetryIf", func(t *testing.T) {
+		retryable := []error{
+			context.DeadlineExceeded,
+			context.Canceled,
+			io.ErrUnexpectedEOF,
+			fmt.Errorf("read: %w", syscall.ECONNRESET),
+			&net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)},
+			&net.DNSError{Err: "timeout", IsTimeout: true},
+			&net.DNSError{Err: "server misbehaving", IsTemporary: true},
+			&HTTPError{StatusCode: 503},
+			&HTTPError{StatusCode: 429},
+		}
+		for _, err := range retryable {
+			if !DefaultRetryIf(err) {
+				t.Errorf("expected %v to be retryable", err)
+			}
+		}
+
+		final := []error{
+			nil,
+			err1,
+			io.EOF,
+			&net.DNSError{Err: "no such host", IsNotFound: true},
+			Permanent(context.DeadlineExceeded),
+			&HTTPError{StatusCode: 404},
+		}
+		for _, err := range final {
+			if DefaultRetryIf(err) {
+				t.Errorf("expected %v not to be retryable", err)
+			}
+		}
+	})
+}
+
+// apiError is a client library error type carrying a status code.
+type apiError struct{ code int }
+
+func (e apiError) Error() string       { return fmt.Sprintf("api error %d", e.code) }
+func (e apiError) HTTPStatusCode() int { return e.code }
+
+func TestOnHTTPStatus(t *testing.T) {
+	cond := OnHTTPStatus(http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout)
+
+	retryable := []error{
+		&HTTPError{StatusCode: http.StatusServiceUnavailable},
+		fmt.Errorf("fetch user: %w", &HTTPError{StatusCode: http.StatusTooManyRequests}),
+		apiError{code: http.StatusGatewayTimeout},
+	}
+	for _, err := range retryable {
+		if !cond(err) {
+			t.Errorf("expected %v to be retryable", err)
+		}
+	}
+
+	final := []error{
+		&HTTPError{StatusCode: http.StatusNotFound},
+		apiError{code: http.StatusBadRequest},
+		errors.New("no status"),
+		nil,
+	}
+	for _, err := range final {
+		if cond(err) {
+			t.Errorf("expected %v not to be retryable", err)
+		}
+	}
+
+	t.Run("HTTPError", func(t *testing.T) {
+		body := errors.New("quota exceeded")
+		err := &HTTPError{StatusCode: http.StatusTooManyRequests, Err: body}
+		if !errors.Is(err, body) {
+			t.Error("expected HTTPError to unwrap to the body error")
+		}
+		if msg := err.Error(); msg != "http status 429 Too Many Requests: quota exceeded" {
+			t.Errorf("unexpected message %q", msg)
+		}
+		if code, ok := HTTPStatus(err); !ok || code != http.StatusTooManyRequests {
+			t.Errorf("expected status 429, got %d, %v", code, ok)
+		}
+	})
 }
 
 func TestDefaultOptions(t *testing.T) {
@@ -416,3 +592,1252 @@ func TestWithOnRetry(t *testing.T) {
 		t.Errorf("expected second callback error to be 'retry error', got %v", callbackCalls[1].err)
 	}
 }
+
+func TestLifecycleHooks(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		var started []int
+		successAttempt := 0
+		gaveUp := false
+
+		opts := Options{
+			Strategy:       &NoDelay{},
+			MaxAttempts:    3,
+			OnAttemptStart: func(attempt int) { started = append(started, attempt) },
+			OnSuccess: func(attempt int, elapsed time.Duration) {
+				successAttempt = attempt
+				if elapsed < 0 {
+					t.Errorf("expected non-negative elapsed, got %v", elapsed)
+				}
+			},
+			OnGiveUp: func(err error, attempts int) { gaveUp = true },
+		}
+
+		_, err := DoWithAttempt(context.Background(), func(ctx context.Context, attempt int) (int, error) {
+			if attempt < 2 {
+				return 0, errors.New("temporary")
+			}
+			return 1, nil
+		}, opts)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(started) != 2 || started[0] != 1 || started[1] != 2 {
+			t.Errorf("expected starts [1 2], got %v", started)
+		}
+		if successAttempt != 2 {
+			t.Errorf("expected OnSuccess on attempt 2, got %d", successAttempt)
+		}
+		if gaveUp {
+			t.Error("expected OnGiveUp not to be called")
+		}
+	})
+
+	t.Run("exhausted", func(t *testing.T) {
+		var giveUpErr error
+		giveUpAttempts := 0
+
+		opts := Options{
+			Strategy:    &NoDelay{},
+			MaxAttempts: 3,
+			OnSuccess:   func(int, time.Duration) { t.Error("expected OnSuccess not to be called") },
+			OnGiveUp: func(err error, attempts int) {
+				giveUpErr = err
+				giveUpAttempts = attempts
+			},
+		}
+
+		_, err := Do(context.Background(), func(ctx context.Context) (int, error) {
+			return 0, errors.New("always fails")
+		}, opts)
+		if giveUpErr != err {
+			t.Errorf("expected OnGiveUp to receive returned error, got %v", giveUpErr)
+		}
+		if giveUpAttempts != 3 {
+			t.Errorf("expected 3 attempts, got %d", giveUpAttempts)
+		}
+	})
+
+	t.Run("permanent", func(t *testing.T) {
+		giveUpAttempts := 0
+		opts := Options{
+			Strategy:    &NoDelay{},
+			MaxAttempts: 3,
+			OnGiveUp:    func(err error, attempts int) { giveUpAttempts = attempts },
+		}
+
+		_, err := Do(context.Background(), func(ctx context.Context) (int, error) {
+			return 0, Permanent(errors.New("bad input"))
+		}, opts)
+		if !IsPermanentError(err) {
+			t.Fatalf("expected permanent error, got %v", err)
+		}
+		if giveUpAttempts != 1 {
+			t.Errorf("expected 1 attempt, got %d", giveUpAttempts)
+		}
+	})
+}
+
+func TestOnExhausted(t *testing.T) {
+	type call struct {
+		err      error
+		attempts int
+	}
+
+	t.Run("exhausted", func(t *testing.T) {
+		var calls []call
+		opts := Options{
+			Strategy:    &NoDelay{},
+			MaxAttempts: 3,
+			OnExhausted: func(ctx context.Context, err error, attempts int) {
+				if ctx == nil {
+					t.Error("expected a context")
+				}
+				calls = append(calls, call{err, attempts})
+			},
+		}
+
+		_, err := Do(context.Background(), func(ctx context.Context) (int, error) {
+			return 0, errors.New("always fails")
+		}, opts)
+		if len(calls) != 1 || calls[0].err != err || calls[0].attempts != 3 {
+			t.Fatalf("expected one call with the returned error after 3 attempts, got %+v", calls)
+		}
+	})
+
+	t.Run("non-retryable", func(t *testing.T) {
+		var calls []call
+		opts := Options{
+			Strategy:    &NoDelay{},
+			MaxAttempts: 3,
+			OnExhausted: func(ctx context.Context, err error, attempts int) { calls = append(calls, call{err, attempts}) },
+		}
+
+		_, err := Do(context.Background(), func(ctx context.Context) (int, error) {
+			return 0, Permanent(errors.New("bad input"))
+		}, opts)
+		if len(calls) != 1 || calls[0].err != err || calls[0].attempts != 1 {
+			t.Fatalf("expected one call after 1 attempt, got %+v", calls)
+		}
+	})
+
+	t.Run("not on success or cancellation", func(t *testing.T) {
+		called := false
+		opts := Options{
+			Strategy:    &ConstantDelay{Delay: time.Second},
+			MaxAttempts: 3,
+			OnExhausted: func(ctx context.Context, err error, attempts int) { called = true },
+		}
+
+		if _, err := Do(context.Background(), func(ctx context.Context) (int, error) { return 1, nil }, opts); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		_, err := Do(ctx, func(ctx context.Context) (int, error) {
+			cancel()
+			return 0, errors.New("temporary")
+		}, opts)
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+		if called {
+			t.Error("expected OnExhausted not to be called")
+		}
+	})
+}
+
+func TestBeforeAttempt(t *testing.T) {
+	t.Run("fresh resource per attempt", func(t *testing.T) {
+		var events []string
+		acquired := 0
+		var token string
+
+		opts := Options{
+			Strategy:    &NoDelay{},
+			MaxAttempts: 3,
+			BeforeAttempt: func(ctx context.Context) (func(), error) {
+				acquired++
+				token = fmt.Sprintf("token-%d", acquired)
+				events = append(events, "acquire "+token)
+				t := token
+				return func() { events = append(events, "release "+t) }, nil
+			},
+		}
+
+		_, err := Do(context.Background(), func(ctx context.Context) (string, error) {
+			events = append(events, "use "+token)
+			if token == "token-1" {
+				return "", errors.New("expired")
+			}
+			return token, nil
+		}, opts)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		want := []string{"acquire token-1", "use token-1", "release token-1", "acquire token-2", "use token-2", "release token-2"}
+		if strings.Join(events, ",") != strings.Join(want, ",") {
+			t.Fatalf("expected %v, got %v", want, events)
+		}
+	})
+
+	t.Run("setup error fails the attempt", func(t *testing.T) {
+		errUnavailable := errors.New("pool exhausted")
+		setups, calls := 0, 0
+		opts := Options{
+			Strategy:    &NoDelay{},
+			MaxAttempts: 3,
+			BeforeAttempt: func(ctx context.Context) (func(), error) {
+				setups++
+				if setups == 1 {
+					return nil, errUnavailable
+				}
+				return nil, nil
+			},
+			OnRetry: func(attempt int, err error) {
+				if !errors.Is(err, errUnavailable) {
+					t.Errorf("expected setup error to be retried, got %v", err)
+				}
+			},
+		}
+
+		_, err := Do(context.Background(), func(ctx context.Context) (int, error) {
+			calls++
+			return 1, nil
+		}, opts)
+		if err != nil || setups != 2 || calls != 1 {
+			t.Fatalf("expected fn to run once after 2 setups, got %d setups, %d calls, %v", setups, calls, err)
+		}
+	})
+}
+
+// throttled is an error carrying backoff hints.
+type throttled struct {
+	wait     time.Duration
+	severity float64
+}
+
+func (e *throttled) Error() string          { return "throttled" }
+func (e *throttled) Backoff() time.Duration { return e.wait }
+func (e *throttled) Severity() float64      { return e.severity }
+
+func TestErrorBackoffHints(t *testing.T) {
+	strategy := &ConstantDelay{Delay: 100 * time.Millisecond}
+	opts := Options{Strategy: strategy}
+
+	tests := []struct {
+		name string
+		err  error
+		want time.Duration
+	}{
+		{"no hint", errors.New("plain"), 100 * time.Millisecond},
+		{"backoff", &throttled{wait: 3 * time.Second}, 3 * time.Second},
+		{"wrapped backoff", fmt.Errorf("call: %w", &throttled{wait: time.Second, severity: 10}), time.Second},
+		{"severity", &throttled{severity: 2.5}, 250 * time.Millisecond},
+		{"ignored severity", &throttled{severity: -1}, 100 * time.Millisecond},
+		{"severity overflow", &throttled{severity: math.MaxFloat64}, maxDuration},
+		{"http retry-after", &HTTPError{StatusCode: 429, RetryAfter: 2 * time.Second}, 2 * time.Second},
+		{"http without retry-after", &HTTPError{StatusCode: 503}, 100 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		if got := calculateDelay(opts, 1, tt.err, nil); got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+
+	t.Run("drives Do", func(t *testing.T) {
+		start := time.Now()
+		attempts := 0
+		_, err := Do(context.Background(), func(ctx context.Context) (int, error) {
+			attempts++
+			if attempts == 1 {
+				return 0, &throttled{wait: 20 * time.Millisecond}
+			}
+			return 1, nil
+		}, Options{Strategy: &ConstantDelay{Delay: time.Hour}, MaxAttempts: 2})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Fatalf("expected the error's backoff to replace the strategy delay, took %v", elapsed)
+		}
+	})
+}
+
+func TestSetDefaults(t *testing.T) {
+	t.Cleanup(ResetDefaults)
+
+	if err := SetDefaults(Options{MaxAttempts: 0}); !errors.Is(err, ErrMaxAttemptsInvalid) {
+		t.Fatalf("expected ErrMaxAttemptsInvalid, got %v", err)
+	}
+	if got := DefaultOptions().MaxAttempts; got != 3 {
+		t.Fatalf("expected rejected defaults to leave built-ins, got %d attempts", got)
+	}
+
+	if err := SetDefaults(Options{Strategy: &NoDelay{}, MaxAttempts: 5}); err != nil {
+		t.Fatalf("SetDefaults: %v", err)
+	}
+
+	fail := func(calls *int) func(context.Context) (int, error) {
+		return func(ctx context.Context) (int, error) {
+			*calls++
+			return 0, errors.New("fail")
+		}
+	}
+
+	calls := 0
+	if _, err := Do(context.Background(), fail(&calls)); err == nil || calls != 5 {
+		t.Fatalf("expected 5 attempts from the defaults, got %d, %v", calls, err)
+	}
+
+	calls = 0
+	if _, err := Do(context.Background(), fail(&calls), Options{Strategy: &NoDelay{}, MaxAttempts: 2}); err == nil || calls != 2 {
+		t.Fatalf("expected explicit options to override, got %d attempts, %v", calls, err)
+	}
+
+	calls = 0
+	if _, err := Do(context.Background(), fail(&calls), Options{MaxAttempts: 2}); err == nil || calls != 2 {
+		t.Fatalf("expected nil strategy to use the default NoDelay, got %d attempts, %v", calls, err)
+	}
+	if _, ok := DefaultOptions().Strategy.(*NoDelay); !ok {
+		t.Fatalf("expected DefaultOptions to return the configured strategy, got %T", DefaultOptions().Strategy)
+	}
+
+	ResetDefaults()
+	if got := DefaultOptions().MaxAttempts; got != 3 {
+		t.Fatalf("expected built-in defaults after reset, got %d attempts", got)
+	}
+}
+
+func TestConfig(t *testing.T) {
+	t.Run("from JSON", func(t *testing.T) {
+		var cfg Config
+		err := json.Unmarshal([]byte(`{
+			"strategy": "exponential",
+			"max_attempts": 5,
+			"attempt_timeout": "2s",
+			"initial_delay": "250ms",
+			"multiplier": 3,
+			"max_delay": "1m"
+		}`), &cfg)
+		if err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+
+		opts, err := cfg.Build()
+		if err != nil {
+			t.Fatalf("Build: %v", err)
+		}
+		exp, ok := opts.Strategy.(*ExponentialBackoff)
+		if !ok || exp.InitialDelay != 250*time.Millisecond || exp.Multiplier != 3 || exp.MaxDelay != time.Minute {
+			t.Fatalf("unexpected strategy %#v", opts.Strategy)
+		}
+		if opts.MaxAttempts != 5 || opts.AttemptTimeout != 2*time.Second {
+			t.Fatalf("unexpected options %+v", opts)
+		}
+
+		out, err := json.Marshal(cfg)
+		if err != nil || !strings.Contains(string(out), `"initial_delay":"250ms"`) {
+			t.Fatalf("expected durations to marshal as strings, got %s, %v", out, err)
+		}
+	})
+
+	t.Run("strategies", func(t *testing.T) {
+		tests := []struct {
+			cfg  Config
+			want Strategy
+		}{
+			{Config{MaxAttempts: 1, InitialDelay: Duration(time.Second)}, &ExponentialBackoff{InitialDelay: time.Second, Multiplier: 2}},
+			{Config{Strategy: "Linear", MaxAttempts: 1, InitialDelay: Duration(time.Second), Increment: Duration(time.Second)}, &LinearBackoff{InitialDelay: time.Second, Increment: time.Second}},
+			{Config{Strategy: "constant", MaxAttempts: 1, Delay: Duration(time.Second)}, &ConstantDelay{Delay: time.Second}},
+			{Config{Strategy: "none", MaxAttempts: 1}, &NoDelay{}},
+		}
+		for _, tt := range tests {
+			opts, err := tt.cfg.Build()
+			if err != nil {
+				t.Fatalf("%s: Build: %v", tt.cfg.Strategy, err)
+			}
+			if fmt.Sprintf("%#v", opts.Strategy) != fmt.Sprintf("%#v", tt.want) {
+				t.Errorf("%s: expected %#v, got %#v", tt.cfg.Strategy, tt.want, opts.Strategy)
+			}
+		}
+	})
+
+	t.Run("field errors", func(t *testing.T) {
+		tests := []struct {
+			cfg   Config
+			field string
+		}{
+			{Config{}, "max_attempts"},
+			{Config{MaxAttempts: 1, AttemptTimeout: -1}, "attempt_timeout"},
+			{Config{MaxAttempts: 1, Jitter: 2}, "jitter"},
+			{Config{MaxAttempts: 1}, "initial_delay"},
+			{Config{MaxAttempts: 1, InitialDelay: 1, Multiplier: -2}, "multiplier"},
+			{Config{Strategy: "constant", MaxAttempts: 1, Delay: -1}, "delay"},
+			{Config{Strategy: "fibonacci", MaxAttempts: 1}, "strategy"},
+		}
+		for _, tt := range tests {
+			_, err := tt.cfg.Build()
+			var cfgErr *ConfigError
+			if !errors.As(err, &cfgErr) || cfgErr.Field != tt.field {
+				t.Errorf("expected error for field %s, got %v", tt.field, err)
+			}
+		}
+
+		_, err := Config{}.Build()
+		if !errors.Is(err, ErrMaxAttemptsInvalid) || !strings.Contains(err.Error(), "max_attempts") {
+			t.Errorf("expected wrapped ErrMaxAttemptsInvalid naming the field, got %v", err)
+		}
+	})
+
+	t.Run("bad duration", func(t *testing.T) {
+		var cfg Config
+		if err := json.Unmarshal([]byte(`{"initial_delay": "soon"}`), &cfg); err == nil {
+			t.Fatal("expected unparseable duration to fail")
+		}
+	})
+}
+
+func TestDoWithAttempt(t *testing.T) {
+	t.Run("passes attempt number", func(t *testing.T) {
+		mirrors := []string{"primary", "secondary", "tertiary"}
+		var seen []int
+
+		fn := func(ctx context.Context, attempt int) (string, error) {
+			seen = append(seen, attempt)
+			if attempt < 3 {
+				return "", errors.New("mirror unavailable")
+			}
+			return mirrors[attempt-1], nil
+		}
+
+		result, err := DoWithAttempt(context.Background(), fn, Options{
+			Strategy:    &NoDelay{},
+			MaxAttempts: 3,
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if result != "tertiary" {
+			t.Fatalf("expected 'tertiary', got %s", result)
+		}
+		if len(seen) != 3 || seen[0] != 1 || seen[1] != 2 || seen[2] != 3 {
+			t.Fatalf("expected attempts [1 2 3], got %v", seen)
+		}
+	})
+
+	t.Run("invalid max attempts", func(t *testing.T) {
+		fn := func(ctx context.Context, attempt int) (int, error) {
+			return attempt, nil
+		}
+
+		_, err := DoWithAttempt(context.Background(), fn, Options{MaxAttempts: 0})
+		if err != ErrMaxAttemptsInvalid {
+			t.Fatalf("expected ErrMaxAttemptsInvalid, got %v", err)
+		}
+	})
+}
+
+func TestLogger(t *testing.T) {
+	t.Run("emits structured retry events", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+		fn := func(ctx context.Context) (int, error) {
+			return 0, errors.New("unavailable")
+		}
+
+		_, err := Do(context.Background(), fn, Options{
+			Strategy:    &NoDelay{},
+			MaxAttempts: 2,
+			Logger:      logger,
+		})
+		if err == nil {
+			t.Fatal("expected error")
+		}
+
+		out := buf.String()
+		if !strings.Contains(out, `"msg":"retry attempt failed"`) {
+			t.Fatalf("expected retry event, got %s", out)
+		}
+		if !strings.Contains(out, `"msg":"retry attempts exhausted","attempt":2,"max_attempts":2`) {
+			t.Fatalf("expected exhausted event with attempt counts, got %s", out)
+		}
+		if !strings.Contains(out, `"attempt":1`) || !strings.Contains(out, `"error":"unavailable"`) {
+			t.Fatalf("expected attempt and error attributes, got %s", out)
+		}
+	})
+
+	t.Run("logs permanent errors", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+		fn := func(ctx context.Context) (int, error) {
+			return 0, Permanent(errors.New("bad input"))
+		}
+
+		Do(context.Background(), fn, Options{
+			Strategy:    &NoDelay{},
+			MaxAttempts: 3,
+			Logger:      logger,
+		})
+
+		if !strings.Contains(buf.String(), `"permanent":true`) {
+			t.Fatalf("expected permanent attribute, got %s", buf.String())
+		}
+	})
+}
+
+func TestRetryer(t *testing.T) {
+	t.Run("invalid options", func(t *testing.T) {
+		if _, err := New(Options{}); !errors.Is(err, ErrMaxAttemptsInvalid) {
+			t.Fatalf("expected ErrMaxAttemptsInvalid, got %v", err)
+		}
+	})
+
+	t.Run("nil strategy defaulted once", func(t *testing.T) {
+		r, err := New(Options{MaxAttempts: 2})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if r.Options().Strategy == nil {
+			t.Fatal("expected default strategy to be set")
+		}
+	})
+
+	r, err := New(Options{Strategy: &NoDelay{}, MaxAttempts: 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Run("Do", func(t *testing.T) {
+		attempts := 0
+		err := r.Do(context.Background(), func(ctx context.Context) error {
+			attempts++
+			if attempts < 3 {
+				return errors.New("temporary")
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if attempts != 3 {
+			t.Fatalf("expected 3 attempts, got %d", attempts)
+		}
+	})
+
+	t.Run("Run", func(t *testing.T) {
+		result, err := Run(context.Background(), r, func(ctx context.Context) (string, error) {
+			return "ok", nil
+		})
+		if err != nil || result != "ok" {
+			t.Fatalf("expected ok, got %q, %v", result, err)
+		}
+	})
+
+	t.Run("RunWithAttempt exhausted", func(t *testing.T) {
+		_, err := RunWithAttempt(context.Background(), r, func(ctx context.Context, attempt int) (int, error) {
+			return 0, errors.New("always fails")
+		})
+		var retryErr *RetryError
+		if !errors.As(err, &retryErr) || retryErr.Attempts != 3 {
+			t.Fatalf("expected RetryError after 3 attempts, got %v", err)
+		}
+	})
+}
+
+func TestAttemptTimeout(t *testing.T) {
+	t.Run("slow attempt is retried", func(t *testing.T) {
+		opts := Options{
+			Strategy:       &NoDelay{},
+			MaxAttempts:    3,
+			AttemptTimeout: 20 * time.Millisecond,
+		}
+
+		result, err := DoWithAttempt(context.Background(), func(ctx context.Context, attempt int) (int, error) {
+			if attempt == 1 {
+				<-ctx.Done()
+				return 0, ctx.Err()
+			}
+			return attempt, nil
+		}, opts)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if result != 2 {
+			t.Fatalf("expected success on attempt 2, got %d", result)
+		}
+	})
+
+	t.Run("exhausted with deadline errors", func(t *testing.T) {
+		opts := Options{
+			Strategy:       &NoDelay{},
+			MaxAttempts:    2,
+			AttemptTimeout: 10 * time.Millisecond,
+		}
+
+		_, err := Do(context.Background(), func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		}, opts)
+		var retryErr *RetryError
+		if !errors.As(err, &retryErr) || !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected RetryError wrapping DeadlineExceeded, got %v", err)
+		}
+	})
+
+	t.Run("negative timeout invalid", func(t *testing.T) {
+		err := Options{MaxAttempts: 1, AttemptTimeout: -time.Second}.Validate()
+		if !errors.Is(err, ErrAttemptTimeoutInvalid) {
+			t.Fatalf("expected ErrAttemptTimeoutInvalid, got %v", err)
+		}
+	})
+}
+
+func TestSplitDeadline(t *testing.T) {
+	t.Run("shares the remaining budget", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+		defer cancel()
+
+		var budgets []time.Duration
+		_, err := Do(ctx, func(ctx context.Context) (int, error) {
+			deadline, _ := ctx.Deadline()
+			budgets = append(budgets, time.Until(deadline))
+			<-ctx.Done()
+			return 0, ctx.Err()
+		}, Options{Strategy: &NoDelay{}, MaxAttempts: 3, SplitDeadline: true})
+		if err == nil {
+			t.Fatal("expected every attempt to time out")
+		}
+		if len(budgets) != 3 {
+			t.Fatalf("expected 3 attempts within the budget, got %d", len(budgets))
+		}
+		for i, b := range budgets {
+			if b > 110*time.Millisecond || b < 50*time.Millisecond {
+				t.Errorf("attempt %d: expected about 100ms, got %v", i+1, b)
+			}
+		}
+	})
+
+	t.Run("capped by AttemptTimeout", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+		defer cancel()
+		got := attemptTimeout(ctx, Options{MaxAttempts: 2, AttemptTimeout: time.Second, SplitDeadline: true}, 1)
+		if got != time.Second {
+			t.Fatalf("expected AttemptTimeout to cap the share, got %v", got)
+		}
+	})
+
+	t.Run("no deadline", func(t *testing.T) {
+		got := attemptTimeout(context.Background(), Options{MaxAttempts: 2, SplitDeadline: true}, 1)
+		if got != 0 {
+			t.Fatalf("expected no attempt timeout without a deadline, got %v", got)
+		}
+	})
+}
+
+func TestDoParallel(t *testing.T) {
+	t.Run("parallel round after first failure", func(t *testing.T) {
+		var mu sync.Mutex
+		var started []int
+		calls := 0
+		cancelled := make(chan bool, 1)
+		slowStarted := make(chan struct{})
+		val, err := DoParallel(context.Background(), func(ctx context.Context) (int, error) {
+			mu.Lock()
+			calls++
+			call := calls
+			mu.Unlock()
+			switch call {
+			case 1:
+				return 0, errors.New("cold cache")
+			case 2:
+				close(slowStarted)
+				<-ctx.Done() // slow replica, cancelled by the winner
+				cancelled <- await.CancelledBySibling(ctx)
+				return 0, ctx.Err()
+			default:
+				<-slowStarted
+				return 42, nil
+			}
+		}, Options{
+			Strategy:         &NoDelay{},
+			MaxAttempts:      3,
+			ParallelAttempts: 2,
+			OnAttemptStart: func(attempt int) {
+				started = append(started, attempt)
+			},
+		})
+		if err != nil || val != 42 {
+			t.Fatalf("expected 42, got %d, %v", val, err)
+		}
+		if !<-cancelled {
+			t.Error("expected the slower parallel attempt to be cancelled")
+		}
+		if len(started) != 3 || started[0] != 1 || started[1] != 2 || started[2] != 3 {
+			t.Errorf("expected attempts [1 2 3] to start, got %v", started)
+		}
+	})
+
+	t.Run("exhausted", func(t *testing.T) {
+		var calls atomic.Int32
+		gaveUp := 0
+		_, err := DoParallel(context.Background(), func(ctx context.Context) (int, error) {
+			calls.Add(1)
+			return 0, errors.New("down")
+		}, Options{
+			Strategy:         &NoDelay{},
+			MaxAttempts:      6,
+			ParallelAttempts: 3,
+			OnGiveUp:         func(err error, attempts int) { gaveUp = attempts },
+		})
+		var retryErr *RetryError
+		if !errors.As(err, &retryErr) || retryErr.Attempts != 6 {
+			t.Fatalf("expected RetryError after 6 attempts, got %v", err)
+		}
+		if calls.Load() != 6 || gaveUp != 6 {
+			t.Fatalf("expected 6 calls (1 + 3 + 2) and OnGiveUp(6), got %d and %d", calls.Load(), gaveUp)
+		}
+	})
+
+	t.Run("non-retryable error in round", func(t *testing.T) {
+		var calls atomic.Int32
+		_, err := DoParallel(context.Background(), func(ctx context.Context) (int, error) {
+			if calls.Add(1) == 1 {
+				return 0, errors.New("temporary")
+			}
+			return 0, Permanent(errors.New("bad request"))
+		}, Options{Strategy: &NoDelay{}, MaxAttempts: 10})
+		if !IsPermanentError(err) {
+			t.Fatalf("expected permanent error, got %v", err)
+		}
+		if calls.Load() != 3 {
+			t.Fatalf("expected one round of 2 after the first attempt, got %d calls", calls.Load())
+		}
+	})
+
+	t.Run("throttled errors are not hedged", func(t *testing.T) {
+		var rounds []int
+		_, err := DoParallel(context.Background(), func(ctx context.Context) (int, error) {
+			return 0, &HTTPError{StatusCode: 429}
+		}, Options{
+			Strategy:         &NoDelay{},
+			MaxAttempts:      3,
+			ParallelAttempts: 3,
+			OnRetry:          func(attempt int, err error) { rounds = append(rounds, attempt) },
+		})
+		var retryErr *RetryError
+		if !errors.As(err, &retryErr) || retryErr.Attempts != 3 {
+			t.Fatalf("expected RetryError after 3 attempts, got %v", err)
+		}
+		if !reflect.DeepEqual(rounds, []int{1, 2}) {
+			t.Fatalf("expected single-attempt rounds while throttled, got retries after %v", rounds)
+		}
+	})
+}
+
+func TestGo(t *testing.T) {
+	attempts := 0
+	f := Go(context.Background(), func(ctx context.Context) (int, error) {
+		attempts++
+		if attempts < 2 {
+			return 0, errors.New("temporary")
+		}
+		return 7, nil
+	}, Options{Strategy: &NoDelay{}, MaxAttempts: 3})
+
+	result, err := f.Await(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if result != 7 {
+		t.Fatalf("expected 7, got %d", result)
+	}
+	if attempts != 2 {
+		t.Fatalf("expected 2 attempts, got %d", attempts)
+	}
+
+	_, err = Go(context.Background(), func(ctx context.Context) (int, error) {
+		return 0, nil
+	}, Options{}).Await(context.Background())
+	if !errors.Is(err, ErrMaxAttemptsInvalid) {
+		t.Fatalf("expected ErrMaxAttemptsInvalid, got %v", err)
+	}
+}
+
+func TestTask(t *testing.T) {
+	opts := Options{Strategy: &NoDelay{}, MaxAttempts: 3}
+
+	flaky := func(failures int, val int) await.Task[int] {
+		attempts := 0
+		return func(ctx context.Context) (int, error) {
+			attempts++
+			if attempts <= failures {
+				return 0, errors.New("temporary")
+			}
+			return val, nil
+		}
+	}
+
+	results, err := await.All(context.Background(), Tasks(opts, flaky(0, 1), flaky(1, 2), flaky(2, 3))...)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := await.CollectErrors(results); err != nil {
+		t.Fatalf("expected all tasks to succeed, got %v", err)
+	}
+	if values := await.Values(results); len(values) != 3 || values[0] != 1 || values[1] != 2 || values[2] != 3 {
+		t.Fatalf("expected [1 2 3], got %v", values)
+	}
+
+	_, err = await.Any(context.Background(), Task(flaky(5, 0), opts))
+	var retryErr *RetryError
+	if !errors.As(err, &retryErr) || retryErr.Attempts != 3 {
+		t.Fatalf("expected RetryError after 3 attempts, got %v", err)
+	}
+}
+
+func TestValidateResult(t *testing.T) {
+	attempts := 0
+	val, err := Do(context.Background(), func(ctx context.Context) (string, error) {
+		attempts++
+		if attempts < 3 {
+			return "", nil // 200 with an empty body
+		}
+		return "payload", nil
+	}, Options{
+		Strategy:    &NoDelay{},
+		MaxAttempts: 5,
+		ValidateResult: await.ValidateAs(func(body string) error {
+			if body == "" {
+				return errors.New("empty body")
+			}
+			return nil
+		}),
+	})
+	if err != nil || val != "payload" || attempts != 3 {
+		t.Fatalf("expected payload after 3 attempts, got %q, %v after %d", val, err, attempts)
+	}
+
+	_, err = Do(context.Background(), func(ctx context.Context) (int, error) {
+		return 0, nil
+	}, Options{
+		Strategy:       &NoDelay{},
+		MaxAttempts:    2,
+		ValidateResult: func(any) error { return errors.New("never valid") },
+	})
+	if !errors.Is(err, await.ErrInvalidResult) {
+		t.Fatalf("expected ErrInvalidResult after exhausting attempts, got %v", err)
+	}
+}
+
+func TestTaskTiming(t *testing.T) {
+	attempts := 0
+	flaky := Task(func(ctx context.Context) (int, error) {
+		attempts++
+		if attempts < 2 {
+			return 0, errors.New("temporary")
+		}
+		return 1, nil
+	}, Options{Strategy: &NoDelay{}, MaxAttempts: 3})
+
+	results, err := await.AllWithOptions(context.Background(), await.Options{Timing: true}, flaky)
+	if err != nil || results[0].Err != nil {
+		t.Fatalf("unexpected error: %v, %v", err, results[0].Err)
+	}
+	if results[0].Attempt != 2 || results[0].Started.IsZero() {
+		t.Fatalf("expected timing for 2 attempts, got %+v", results[0].Timing)
+	}
+}
+
+// rangedStrategy reports a fixed spread around a constant delay.
+type rangedStrategy struct{ ConstantDelay }
+
+func (r *rangedStrategy) DelayRange(attempt int) (time.Duration, time.Duration) {
+	return r.Delay / 2, r.Delay * 3 / 2
+}
+
+func TestStrategyCombinators(t *testing.T) {
+	exp := &ExponentialBackoff{InitialDelay: 100 * time.Millisecond, Multiplier: 2, MaxDelay: time.Minute}
+
+	t.Run("ChainStrategies", func(t *testing.T) {
+		chain := ChainStrategies(
+			Phase{Attempts: 2, Strategy: &NoDelay{}},
+			Phase{Attempts: 1, Strategy: &ConstantDelay{Delay: time.Second}},
+			Phase{Strategy: exp},
+		)
+		got := Plan(Options{Strategy: chain}, 7)
+		want := []time.Duration{0, 0, time.Second, 100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	})
+
+	t.Run("phase answers ShouldRetry", func(t *testing.T) {
+		chain := ChainStrategies(
+			Phase{Attempts: 1, Strategy: &NoDelay{}},
+			Phase{Strategy: &CustomStrategy{ShouldRetryFunc: func(attempt int, err error) bool { return attempt < 2 }}},
+		)
+		errTemp := errors.New("temporary")
+		for attempt, want := range map[int]bool{1: true, 2: true, 3: false} {
+			if got := chain.ShouldRetry(attempt, errTemp); got != want {
+				t.Errorf("attempt %d: expected ShouldRetry %v, got %v", attempt, want, got)
+			}
+		}
+	})
+
+	t.Run("CapDelay and MinDelay", func(t *testing.T) {
+		capped := CapDelay(exp, 300*time.Millisecond)
+		floored := MinDelay(&NoDelay{}, 50*time.Millisecond)
+		got := Plan(Options{Strategy: capped}, 5)
+		want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond, 300 * time.Millisecond}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("CapDelay: expected %v, got %v", want, got)
+		}
+		if d := floored.NextDelay(1); d != 50*time.Millisecond {
+			t.Errorf("MinDelay: expected 50ms, got %v", d)
+		}
+		if capped.ShouldRetry(1, Permanent(errors.New("bad"))) {
+			t.Error("expected CapDelay to keep the wrapped strategy's ShouldRetry")
+		}
+	})
+}
+
+func TestCombine(t *testing.T) {
+	errTemp := errors.New("temporary")
+	onlyTwice := RetryPolicyFunc(func(attempt int, err error) bool { return attempt < 2 })
+	s := Combine(&ConstantDelay{Delay: time.Second}, onlyTwice)
+
+	if d := s.NextDelay(1); d != time.Second {
+		t.Errorf("expected delay from the delay policy, got %v", d)
+	}
+	if !s.ShouldRetry(1, errTemp) || s.ShouldRetry(2, errTemp) {
+		t.Error("expected retry decisions from the retry policy")
+	}
+
+	defaults := Combine(DelayPolicyFunc(func(attempt int) time.Duration { return time.Duration(attempt) * time.Millisecond }), nil)
+	if defaults.NextDelay(3) != 3*time.Millisecond || !defaults.ShouldRetry(9, errTemp) || defaults.ShouldRetry(1, Permanent(errTemp)) {
+		t.Error("expected a nil retry policy to retry everything but permanent errors")
+	}
+	if d := Combine(nil, nil).NextDelay(1); d != 0 {
+		t.Errorf("expected a nil delay policy to retry immediately, got %v", d)
+	}
+}
+
+func TestPlan(t *testing.T) {
+	opts := Options{
+		Strategy:    &ExponentialBackoff{InitialDelay: 100 * time.Millisecond, Multiplier: 2, MaxDelay: time.Second},
+		MaxAttempts: 6,
+	}
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second}
+	if got := Plan(opts, 0); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if got := Plan(opts, 3); !reflect.DeepEqual(got, want[:2]) {
+		t.Fatalf("expected %v for 3 attempts, got %v", want[:2], got)
+	}
+	if got := Plan(opts, 1); got != nil {
+		t.Fatalf("expected no delays for a single attempt, got %v", got)
+	}
+
+	ranges := PlanRange(opts, 2)
+	if len(ranges) != 1 || ranges[0] != (DelayRange{Min: 100 * time.Millisecond, Max: 100 * time.Millisecond}) {
+		t.Fatalf("expected fixed range for deterministic strategy, got %v", ranges)
+	}
+	ranges = PlanRange(Options{Strategy: &rangedStrategy{ConstantDelay{Delay: time.Second}}, MaxAttempts: 2}, 0)
+	if len(ranges) != 1 || ranges[0] != (DelayRange{Min: 500 * time.Millisecond, Max: 1500 * time.Millisecond}) {
+		t.Fatalf("expected DelayRange bounds, got %v", ranges)
+	}
+}
+
+func TestJitter(t *testing.T) {
+	opts := Options{
+		Strategy:    &ConstantDelay{Delay: time.Millisecond},
+		MaxAttempts: 5,
+		Jitter:      0.5,
+		JitterSeed:  42,
+	}
+
+	plan := Plan(opts, 0)
+	if !reflect.DeepEqual(plan, Plan(opts, 0)) {
+		t.Fatal("expected a seeded plan to be reproducible")
+	}
+	for i, d := range plan {
+		if d < 500*time.Microsecond || d > 1500*time.Microsecond {
+			t.Errorf("delay %d out of jitter bounds: %v", i, d)
+		}
+	}
+	if ranges := PlanRange(opts, 2); ranges[0] != (DelayRange{Min: 500 * time.Microsecond, Max: 1500 * time.Microsecond}) {
+		t.Errorf("expected jitter bounds, got %v", ranges)
+	}
+
+	// Do waits exactly the planned delays.
+	var buf bytes.Buffer
+	opts.Logger = slog.New(slog.NewJSONHandler(&buf, nil))
+	_, _ = Do(context.Background(), func(ctx context.Context) (int, error) {
+		return 0, errors.New("down")
+	}, opts)
+	var waited []time.Duration
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var event map[string]any
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatalf("decode log line: %v", err)
+		}
+		if d, ok := event[LogKeyDelay].(float64); ok {
+			waited = append(waited, time.Duration(d))
+		}
+	}
+	if !reflect.DeepEqual(waited, plan) {
+		t.Fatalf("expected Do to wait %v, got %v", plan, waited)
+	}
+
+	for _, j := range []float64{-0.1, 1.5, math.NaN()} {
+		if err := (Options{MaxAttempts: 1, Jitter: j}).Validate(); !errors.Is(err, ErrInvalidJitter) {
+			t.Errorf("jitter %v: expected ErrInvalidJitter, got %v", j, err)
+		}
+	}
+}
+
+func TestOnReport(t *testing.T) {
+	errTemp := errors.New("temporary")
+	calls := 0
+	var reports []Report
+	opts := Options{
+		Strategy:    &ConstantDelay{Delay: 20 * time.Millisecond},
+		MaxAttempts: 3,
+		OnReport:    func(r Report) { reports = append(reports, r) },
+	}
+
+	_, err := Do(context.Background(), func(ctx context.Context) (int, error) {
+		calls++
+		time.Sleep(5 * time.Millisecond)
+		if calls < 3 {
+			return 0, errTemp
+		}
+		return 1, nil
+	}, opts)
+	if err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+
+	_, err = DoParallel(context.Background(), func(ctx context.Context) (int, error) {
+		return 0, Permanent(errTemp)
+	}, opts)
+
+	if len(reports) != 2 {
+		t.Fatalf("expected one report per call, got %d", len(reports))
+	}
+	r := reports[0]
+	if r.Attempts != 3 || r.Err != nil || r.Executing < 15*time.Millisecond || r.Waiting < 40*time.Millisecond {
+		t.Errorf("expected 3 attempts, >=15ms executing and >=40ms waiting, got %+v", r)
+	}
+	if r := reports[1]; r.Attempts != 1 || r.Err != err || r.Waiting != 0 {
+		t.Errorf("expected one failed attempt without waiting, got %+v", r)
+	}
+}
+
+func TestRetryScheduledEvent(t *testing.T) {
+	var mu sync.Mutex
+	var scheduled []await.RetryScheduled
+	t.Cleanup(await.Subscribe(func(ev await.Event) {
+		if ev, ok := ev.(await.RetryScheduled); ok {
+			mu.Lock()
+			scheduled = append(scheduled, ev)
+			mu.Unlock()
+		}
+	}))
+
+	errTemp := errors.New("temporary")
+	_, _ = Do(context.Background(), func(ctx context.Context) (int, error) {
+		return 0, errTemp
+	}, Options{Strategy: &ConstantDelay{Delay: time.Millisecond}, MaxAttempts: 3})
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(scheduled) != 2 || scheduled[0].Attempt != 1 || scheduled[1].Delay != time.Millisecond || !errors.Is(scheduled[1].Err, errTemp) {
+		t.Fatalf("expected 2 retries scheduled, got %+v", scheduled)
+	}
+}
+
+func TestDoTuples(t *testing.T) {
+	ctx := context.Background()
+	opts := Options{Strategy: &NoDelay{}, MaxAttempts: 3}
+
+	attempts := 0
+	q, r, err := Do2(ctx, func(ctx context.Context) (int, int, error) {
+		attempts++
+		if attempts < 2 {
+			return 9, 9, errors.New("temporary")
+		}
+		return 3, 1, nil
+	}, opts)
+	if err != nil || q != 3 || r != 1 || attempts != 2 {
+		t.Fatalf("expected (3, 1, nil) after 2 attempts, got (%d, %d, %v) after %d", q, r, err, attempts)
+	}
+
+	name, age, active, err := Do3(ctx, func(ctx context.Context) (string, int, bool, error) {
+		return "asha", 30, true, nil
+	}, opts)
+	if err != nil || name != "asha" || age != 30 || !active {
+		t.Fatalf("unexpected Do3 result (%q, %d, %v, %v)", name, age, active, err)
+	}
+
+	q, r, err = Do2(ctx, func(ctx context.Context) (int, int, error) {
+		return 9, 9, Permanent(errors.New("bad input"))
+	}, opts)
+	if err == nil || q != 0 || r != 0 {
+		t.Fatalf("expected zero values with error, got (%d, %d, %v)", q, r, err)
+	}
+}
+
+func TestUntil(t *testing.T) {
+	type operation struct{ status string }
+	isDone := func(op operation) bool { return op.status == "DONE" }
+
+	t.Run("polls until ready", func(t *testing.T) {
+		statuses := []string{"PENDING", "RUNNING", "DONE"}
+		calls := 0
+		op, err := Until(context.Background(), func(ctx context.Context) (operation, error) {
+			s := statuses[calls]
+			calls++
+			return operation{status: s}, nil
+		}, isDone, Options{Strategy: &NoDelay{}, MaxAttempts: 5})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if op.status != "DONE" || calls != 3 {
+			t.Fatalf("expected DONE after 3 calls, got %s after %d", op.status, calls)
+		}
+	})
+
+	t.Run("attempts exhausted", func(t *testing.T) {
+		_, err := Until(context.Background(), func(ctx context.Context) (operation, error) {
+			return operation{status: "PENDING"}, nil
+		}, isDone, Options{Strategy: &NoDelay{}, MaxAttempts: 3})
+		var retryErr *RetryError
+		if !errors.As(err, &retryErr) || !errors.Is(err, ErrConditionNotMet) {
+			t.Fatalf("expected RetryError wrapping ErrConditionNotMet, got %v", err)
+		}
+	})
+
+	t.Run("unlimited attempts until deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+		defer cancel()
+		_, err := Until(ctx, func(ctx context.Context) (operation, error) {
+			return operation{status: "PENDING"}, nil
+		}, isDone, Options{Strategy: &ConstantDelay{Delay: 5 * time.Millisecond}})
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected DeadlineExceeded, got %v", err)
+		}
+	})
+
+	t.Run("RetryIf does not stop unready values", func(t *testing.T) {
+		calls := 0
+		_, err := Until(context.Background(), func(ctx context.Context) (operation, error) {
+			calls++
+			if calls == 1 {
+				return operation{status: "PENDING"}, nil
+			}
+			return operation{}, errors.New("fatal")
+		}, isDone, Options{Strategy: &NoDelay{}, MaxAttempts: 5, RetryIf: func(error) bool { return false }})
+		if err == nil || err.Error() != "fatal" || calls != 2 {
+			t.Fatalf("expected fatal error on call 2, got %v after %d", err, calls)
+		}
+	})
+}
+
+func TestIdempotencyKey(t *testing.T) {
+	t.Run("threaded through attempts", func(t *testing.T) {
+		key := NewIdempotencyKey()
+		if len(key) != 32 {
+			t.Fatalf("expected 32 hex characters, got %q", key)
+		}
+
+		var seen []string
+		_, err := DoWithAttempt(context.Background(), func(ctx context.Context, attempt int) (int, error) {
+			k, ok := IdempotencyKey(ctx)
+			if !ok {
+				t.Fatal("expected idempotency key in context")
+			}
+			seen = append(seen, k)
+			if attempt < 3 {
+				return 0, errors.New("temporary")
+			}
+			return 1, nil
+		}, Options{Strategy: &NoDelay{}, MaxAttempts: 3, IdempotencyKey: StaticKey(key)})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(seen) != 3 || seen[0] != key || seen[2] != key {
+			t.Fatalf("expected the same key on every attempt, got %v", seen)
+		}
+	})
+
+	t.Run("per attempt", func(t *testing.T) {
+		var seen []string
+		Do(context.Background(), func(ctx context.Context) (int, error) {
+			k, _ := IdempotencyKey(ctx)
+			seen = append(seen, k)
+			return 0, errors.New("temporary")
+		}, Options{
+			Strategy:       &NoDelay{},
+			MaxAttempts:    2,
+			IdempotencyKey: func(attempt int) string { return fmt.Sprintf("op-%d", attempt) },
+		})
+		if len(seen) != 2 || seen[0] != "op-1" || seen[1] != "op-2" {
+			t.Fatalf("expected [op-1 op-2], got %v", seen)
+		}
+	})
+
+	t.Run("unset", func(t *testing.T) {
+		if _, ok := IdempotencyKey(context.Background()); ok {
+			t.Fatal("expected no key")
+		}
+		if NewIdempotencyKey() == NewIdempotencyKey() {
+			t.Fatal("expected distinct keys")
+		}
+	})
+}
+
+func TestMonitoredBackoff(t *testing.T) {
+	backoff, err := NewMonitoredBackoff(time.Millisecond, 2, 4*time.Millisecond)
+	if err != nil {
+		t.Fatalf("NewMonitoredBackoff: %v", err)
+	}
+	if _, err := NewMonitoredBackoff(0, 2, time.Second); !errors.Is(err, ErrInvalidBackoff) {
+		t.Fatalf("expected ErrInvalidBackoff, got %v", err)
+	}
+
+	attempts := 0
+	_, err = Do(context.Background(), func(ctx context.Context) (int, error) {
+		attempts++
+		return 0, errors.New("down")
+	}, Options{MaxAttempts: 6, Strategy: backoff})
+	if err == nil || attempts != 6 {
+		t.Fatalf("expected 6 failed attempts, got %d, %v", attempts, err)
+	}
+
+	// Delays 1ms, 2ms, then 4ms three times at the cap.
+	stats := backoff.Stats()
+	if stats.Delays != 5 || stats.Saturated != 3 {
+		t.Fatalf("expected 3 of 5 delays saturated, got %+v", stats)
+	}
+	if r := stats.Ratio(); r != 0.6 {
+		t.Fatalf("expected ratio 0.6, got %v", r)
+	}
+
+	PlanRange(Options{MaxAttempts: 10, Strategy: backoff}, 0)
+	if got := backoff.Stats(); got != stats {
+		t.Fatalf("expected PlanRange not to count, got %+v", got)
+	}
+
+	var published SaturationStats
+	if err := json.Unmarshal([]byte(backoff.Var().String()), &published); err != nil || published != stats {
+		t.Fatalf("expected Var to report %+v, got %+v, %v", stats, published, err)
+	}
+
+	backoff.Reset()
+	if got := backoff.Stats(); got != (SaturationStats{}) || got.Ratio() != 0 {
+		t.Fatalf("expected zero stats after Reset, got %+v", got)
+	}
+}
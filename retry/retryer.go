@@ -0,0 +1,50 @@
+package retry
+
+import "context"
+
+// Retryer holds a validated Options value so services can check configuration
+// once at startup and reuse it on the hot path. A Retryer is safe for
+// concurrent use provided the configured Strategy and callbacks are.
+type Retryer struct {
+	opts Options
+}
+
+// New validates opts and returns a Retryer that uses them for every call.
+// A nil Strategy is replaced with the DefaultOptions strategy here, once.
+func New(opts Options) (*Retryer, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	if opts.Strategy == nil {
+		opts.Strategy = defaultStrategy()
+	}
+	return &Retryer{opts: opts}, nil
+}
+
+// Options returns a copy of the configuration the Retryer was built with.
+func (r *Retryer) Options() Options {
+	return r.opts
+}
+
+// Do runs fn with the Retryer's configuration. Use Run or RunWithAttempt
+// when fn produces a value.
+func (r *Retryer) Do(ctx context.Context, fn func(context.Context) error) error {
+	_, err := run(ctx, func(ctx context.Context, _ int) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	}, r.opts)
+	return err
+}
+
+// Run is the Retryer counterpart of Do for functions returning a value.
+// It is a function rather than a method because Go methods cannot declare
+// type parameters.
+func Run[T any](ctx context.Context, r *Retryer, fn func(context.Context) (T, error)) (T, error) {
+	return run(ctx, func(ctx context.Context, _ int) (T, error) {
+		return fn(ctx)
+	}, r.opts)
+}
+
+// RunWithAttempt is the Retryer counterpart of DoWithAttempt.
+func RunWithAttempt[T any](ctx context.Context, r *Retryer, fn func(ctx context.Context, attempt int) (T, error)) (T, error) {
+	return run(ctx, fn, r.opts)
+}
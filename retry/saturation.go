@@ -0,0 +1,86 @@
+package retry
+
+import (
+	"expvar"
+	"sync/atomic"
+	"time"
+)
+
+// SaturationStats counts the delays a MonitoredBackoff has computed.
+type SaturationStats struct {
+	Delays    int64 `json:"delays"`    // Delays computed by NextDelay
+	Saturated int64 `json:"saturated"` // Delays capped at MaxDelay
+}
+
+// Ratio returns the fraction of delays that were capped at MaxDelay, or 0
+// before any delay has been computed.
+func (s SaturationStats) Ratio() float64 {
+	if s.Delays == 0 {
+		return 0
+	}
+	return float64(s.Saturated) / float64(s.Delays)
+}
+
+// MonitoredBackoff is an ExponentialBackoff that counts how often its delay
+// reaches the MaxDelay cap. A high ratio means retries regularly back off as
+// far as the policy allows, so the dependency stays down for longer than the
+// policy was sized for: raise MaxDelay, lower MaxAttempts or add capacity.
+//
+// Share one MonitoredBackoff between the calls to be measured, and publish
+// it with Var. Every NextDelay call is counted, which is once per retry in
+// Do; Plan counts too, while PlanRange does not.
+type MonitoredBackoff struct {
+	ExponentialBackoff
+
+	delays    atomic.Int64
+	saturated atomic.Int64
+}
+
+// NewMonitoredBackoff creates a MonitoredBackoff with the same validation as
+// NewExponentialBackoff.
+func NewMonitoredBackoff(initialDelay time.Duration, multiplier float64, maxDelay time.Duration) (*MonitoredBackoff, error) {
+	e, err := NewExponentialBackoff(initialDelay, multiplier, maxDelay)
+	if err != nil {
+		return nil, err
+	}
+	return &MonitoredBackoff{ExponentialBackoff: *e}, nil
+}
+
+// NextDelay returns the ExponentialBackoff delay and counts it.
+func (m *MonitoredBackoff) NextDelay(attempt int) time.Duration {
+	delay, capped := m.ExponentialBackoff.delay(attempt)
+	if attempt > 0 {
+		m.delays.Add(1)
+		if capped {
+			m.saturated.Add(1)
+		}
+	}
+	return delay
+}
+
+// DelayRange reports the delay without counting it, so PlanRange leaves the
+// counters alone.
+func (m *MonitoredBackoff) DelayRange(attempt int) (lo, hi time.Duration) {
+	delay, _ := m.ExponentialBackoff.delay(attempt)
+	return delay, delay
+}
+
+// Stats returns the current counts.
+func (m *MonitoredBackoff) Stats() SaturationStats {
+	return SaturationStats{Delays: m.delays.Load(), Saturated: m.saturated.Load()}
+}
+
+// Reset sets the counts back to zero, for example at the start of each
+// reporting interval.
+func (m *MonitoredBackoff) Reset() {
+	m.delays.Store(0)
+	m.saturated.Store(0)
+}
+
+// Var returns an expvar.Var that reports Stats as JSON, for publishing on the
+// /debug/vars endpoint:
+//
+//	expvar.Publish("payments_retry", backoff.Var())
+func (m *MonitoredBackoff) Var() expvar.Var {
+	return expvar.Func(func() any { return m.Stats() })
+}
@@ -1,31 +1,66 @@
 package retry
 
 import (
+	"fmt"
+	"math"
 	"time"
 )
 
 // ExponentialBackoff implements a retry strategy where delays double after each attempt.
 // For example, with InitialDelay=1s and Multiplier=2: 1s, 2s, 4s, 8s...
+// Delays that would overflow time.Duration are capped at MaxDelay, or at the
+// largest representable duration when MaxDelay is zero.
 type ExponentialBackoff struct {
 	InitialDelay time.Duration // Starting delay for first retry
 	Multiplier   float64       // Factor to multiply delay by after each attempt
 	MaxDelay     time.Duration // Maximum delay between attempts
 }
 
+// maxDuration is the largest representable time.Duration.
+const maxDuration = time.Duration(math.MaxInt64)
+
+// NewExponentialBackoff creates an ExponentialBackoff, returning ErrInvalidBackoff
+// if any of the values is not positive.
+func NewExponentialBackoff(initialDelay time.Duration, multiplier float64, maxDelay time.Duration) (*ExponentialBackoff, error) {
+	switch {
+	case initialDelay <= 0:
+		return nil, fmt.Errorf("%w: initial delay must be positive, got %v", ErrInvalidBackoff, initialDelay)
+	case multiplier <= 0 || math.IsNaN(multiplier) || math.IsInf(multiplier, 0):
+		return nil, fmt.Errorf("%w: multiplier must be positive and finite, got %v", ErrInvalidBackoff, multiplier)
+	case maxDelay <= 0:
+		return nil, fmt.Errorf("%w: max delay must be positive, got %v", ErrInvalidBackoff, maxDelay)
+	}
+	return &ExponentialBackoff{
+		InitialDelay: initialDelay,
+		Multiplier:   multiplier,
+		MaxDelay:     maxDelay,
+	}, nil
+}
+
 // NextDelay calculates the delay for the given attempt using exponential growth.
 func (e *ExponentialBackoff) NextDelay(attempt int) time.Duration {
+	delay, _ := e.delay(attempt)
+	return delay
+}
+
+// delay returns NextDelay's delay and whether it was capped at the limit.
+func (e *ExponentialBackoff) delay(attempt int) (time.Duration, bool) {
 	if attempt <= 0 {
-		return 0
+		return 0, false
 	}
 
-	delay := e.InitialDelay
-	for i := 1; i < attempt; i++ {
-		delay = time.Duration(float64(delay) * e.Multiplier)
-		if e.MaxDelay > 0 && delay > e.MaxDelay {
-			return e.MaxDelay
-		}
+	limit := maxDuration
+	if e.MaxDelay > 0 {
+		limit = e.MaxDelay
 	}
-	return delay
+
+	delay := float64(e.InitialDelay) * math.Pow(e.Multiplier, float64(attempt-1))
+	// float64(math.MaxInt64) rounds up to 2^63, so >= catches every value that
+	// would overflow on conversion, as well as +Inf. NaN fails every comparison.
+	if math.IsNaN(delay) || delay >= float64(limit) {
+		return limit, true
+	}
+	return time.Duration(delay), false
 }
 
 // ShouldRetry returns true unless the error is permanent.
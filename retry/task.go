@@ -0,0 +1,28 @@
+package retry
+
+import (
+	"context"
+
+	"github.com/remiges-tech/await"
+)
+
+// Task wraps an await task so every run of it is retried according to opts.
+// The result can be passed to await.All, await.Any or any other executor.
+//
+// The wrapper lives in this package rather than in await because retry
+// already depends on await.
+func Task[T any](task await.Task[T], opts Options) await.Task[T] {
+	return func(ctx context.Context) (T, error) {
+		return Do(ctx, task, opts)
+	}
+}
+
+// Tasks applies the same retry policy to every task, for use as
+// await.All(ctx, retry.Tasks(opts, a, b, c)...).
+func Tasks[T any](opts Options, tasks ...await.Task[T]) []await.Task[T] {
+	wrapped := make([]await.Task[T], len(tasks))
+	for i, task := range tasks {
+		wrapped[i] = Task(task, opts)
+	}
+	return wrapped
+}
@@ -0,0 +1,21 @@
+package retry
+
+import (
+	"context"
+
+	"github.com/remiges-tech/await"
+)
+
+// Do2 is like Do for a function that returns two values and an error, so
+// callers need not pack the values into a struct or capture them in a
+// closure. On failure both values are zero.
+func Do2[T, U any](ctx context.Context, fn func(context.Context) (T, U, error), opts ...Options) (T, U, error) {
+	p, err := Do(ctx, await.Pack2(fn), opts...)
+	return p.First, p.Second, err
+}
+
+// Do3 is like Do for a function that returns three values and an error.
+func Do3[T, U, V any](ctx context.Context, fn func(context.Context) (T, U, V, error), opts ...Options) (T, U, V, error) {
+	t, err := Do(ctx, await.Pack3(fn), opts...)
+	return t.First, t.Second, t.Third, err
+}
@@ -0,0 +1,38 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"math"
+)
+
+// Until calls fn until it returns a value satisfying ready, retrying both
+// errors and unready values according to opts. It implements the "poll until
+// status=READY" pattern, where a nil error does not mean the work is done.
+//
+// A zero MaxAttempts polls until ready or ctx is done, so ctx should carry a
+// deadline. When attempts run out on an unready value, the returned
+// RetryError wraps ErrConditionNotMet. RetryIf only filters fn's errors;
+// unready values are always retried.
+func Until[T any](ctx context.Context, fn func(context.Context) (T, error), ready func(T) bool, opts Options) (T, error) {
+	if opts.MaxAttempts == 0 {
+		opts.MaxAttempts = math.MaxInt
+	}
+	if retryIf := opts.RetryIf; retryIf != nil {
+		opts.RetryIf = func(err error) bool {
+			return errors.Is(err, ErrConditionNotMet) || retryIf(err)
+		}
+	}
+
+	return Do(ctx, func(ctx context.Context) (T, error) {
+		val, err := fn(ctx)
+		if err != nil {
+			return val, err
+		}
+		if !ready(val) {
+			var zero T
+			return zero, ErrConditionNotMet
+		}
+		return val, nil
+	}, opts)
+}
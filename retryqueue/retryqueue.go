@@ -0,0 +1,282 @@
+// Package retryqueue persists failed operations and retries them on a
+// schedule. Unlike retry.Do, which keeps its state in memory and loses it when
+// the process exits, a Queue writes each pending operation's payload and retry
+// metadata to a Store, so retries continue after a restart.
+//
+// Operations are identified by a kind, which selects the Handler registered
+// with Handle, and carry an opaque serialized payload.
+package retryqueue
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/remiges-tech/await/retry"
+)
+
+var (
+	// ErrInvalidConfig is returned by New when the Config cannot be used.
+	ErrInvalidConfig = errors.New("invalid retry queue configuration")
+
+	// ErrUnknownKind is the error recorded for a job whose kind has no handler.
+	// Such jobs are not retried.
+	ErrUnknownKind = errors.New("no handler for job kind")
+
+	// ErrQueued is wrapped by the error Do returns when the first attempt
+	// failed and the operation was stored for a later retry.
+	ErrQueued = errors.New("operation queued for retry")
+)
+
+// Job is a persisted operation and its retry metadata.
+type Job struct {
+	ID        string    // Unique job ID assigned on enqueue
+	Kind      string    // Selects the Handler that runs the job
+	Payload   []byte    // Serialized operation input
+	Attempts  int       // Attempts made so far
+	NextRun   time.Time // Earliest time of the next attempt
+	LastError string    // Error from the most recent attempt, if any
+	CreatedAt time.Time // When the job was first enqueued
+}
+
+// Handler executes one attempt of a job's operation.
+type Handler func(ctx context.Context, payload []byte) error
+
+// Config controls where jobs are stored and how they are retried.
+type Config struct {
+	Store        Store                    // Required: where jobs are persisted
+	Strategy     retry.Strategy           // Delay before each retry (default: exponential 1s to 5m)
+	MaxAttempts  int                      // Attempts before a job is dead (0 = unlimited)
+	RetryIf      func(error) bool         // Optional: errors it rejects make the job dead immediately
+	PollInterval time.Duration            // How often Run checks for due jobs (default: 1s)
+	BatchSize    int                      // Maximum jobs RunDue loads at once (default: 100)
+	OnDead       func(job Job, err error) // Optional: called when a job is dropped after its last attempt
+}
+
+// Validate reports whether the config can be used with New.
+func (c Config) Validate() error {
+	switch {
+	case c.Store == nil:
+		return fmt.Errorf("%w: store is required", ErrInvalidConfig)
+	case c.MaxAttempts < 0:
+		return fmt.Errorf("%w: max attempts must not be negative, got %d", ErrInvalidConfig, c.MaxAttempts)
+	case c.PollInterval < 0:
+		return fmt.Errorf("%w: poll interval must not be negative, got %v", ErrInvalidConfig, c.PollInterval)
+	case c.BatchSize < 0:
+		return fmt.Errorf("%w: batch size must not be negative, got %d", ErrInvalidConfig, c.BatchSize)
+	}
+	return nil
+}
+
+// Queue runs persisted jobs and reschedules the ones that fail.
+type Queue struct {
+	config Config
+	now    func() time.Time
+
+	mu       sync.RWMutex
+	handlers map[string]Handler
+}
+
+// New creates a Queue, filling in defaults for unset Config fields.
+func New(config Config) (*Queue, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if config.Strategy == nil {
+		config.Strategy = &retry.ExponentialBackoff{
+			InitialDelay: time.Second,
+			Multiplier:   2,
+			MaxDelay:     5 * time.Minute,
+		}
+	}
+	if config.PollInterval == 0 {
+		config.PollInterval = time.Second
+	}
+	if config.BatchSize == 0 {
+		config.BatchSize = 100
+	}
+	return &Queue{
+		config:   config,
+		now:      time.Now,
+		handlers: make(map[string]Handler),
+	}, nil
+}
+
+// Handle registers the handler for jobs of the given kind, replacing any
+// previous one. Register every kind before calling Run or RunDue; due jobs
+// without a handler fail with ErrUnknownKind and are dropped.
+func (q *Queue) Handle(kind string, h Handler) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.handlers[kind] = h
+}
+
+// Enqueue stores a job to run as soon as the queue next processes due jobs
+// and returns its ID.
+func (q *Queue) Enqueue(ctx context.Context, kind string, payload []byte) (string, error) {
+	id, err := newID()
+	if err != nil {
+		return "", err
+	}
+	now := q.now()
+	job := Job{ID: id, Kind: kind, Payload: payload, NextRun: now, CreatedAt: now}
+	if err := q.config.Store.Put(ctx, job); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// Do runs the operation immediately. If the attempt fails with a retryable
+// error the job is stored for a later retry and Do returns an error wrapping
+// both ErrQueued and the attempt's error; non-retryable errors are returned
+// unchanged and nothing is stored.
+func (q *Queue) Do(ctx context.Context, kind string, payload []byte) error {
+	h, ok := q.handler(kind)
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownKind, kind)
+	}
+	err := h(ctx, payload)
+	if err == nil {
+		return nil
+	}
+	if !q.retryable(1, err) {
+		return err
+	}
+
+	id, idErr := newID()
+	if idErr != nil {
+		return errors.Join(err, idErr)
+	}
+	now := q.now()
+	job := Job{
+		ID:        id,
+		Kind:      kind,
+		Payload:   payload,
+		Attempts:  1,
+		NextRun:   now.Add(q.config.Strategy.NextDelay(1)),
+		LastError: err.Error(),
+		CreatedAt: now,
+	}
+	if putErr := q.config.Store.Put(ctx, job); putErr != nil {
+		return errors.Join(err, putErr)
+	}
+	return fmt.Errorf("%w: %w", ErrQueued, err)
+}
+
+// RunDue makes one attempt at each job that is due, up to BatchSize jobs, and
+// returns how many it ran. Jobs that succeed are deleted; jobs that fail are
+// rescheduled, or deleted and passed to OnDead once they are out of attempts
+// or their error is not retryable. It stops early if ctx is done or the store
+// fails.
+func (q *Queue) RunDue(ctx context.Context) (int, error) {
+	jobs, err := q.config.Store.Due(ctx, q.now(), q.config.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	ran := 0
+	for _, job := range jobs {
+		if err := ctx.Err(); err != nil {
+			return ran, err
+		}
+		if err := q.runJob(ctx, job); err != nil {
+			return ran, err
+		}
+		ran++
+	}
+	return ran, nil
+}
+
+// Run processes due jobs every PollInterval until ctx is done, then returns
+// ctx's error. Store errors are returned immediately.
+func (q *Queue) Run(ctx context.Context) error {
+	ticker := time.NewTicker(q.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			ran, err := q.RunDue(ctx)
+			if err != nil {
+				return err
+			}
+			// A full batch may mean more jobs are due; keep going without waiting.
+			if ran < q.config.BatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// runJob makes one attempt at job and records the outcome in the store.
+func (q *Queue) runJob(ctx context.Context, job Job) error {
+	err := fmt.Errorf("%w: %q", ErrUnknownKind, job.Kind)
+	if h, ok := q.handler(job.Kind); ok {
+		err = h(ctx, job.Payload)
+	}
+	job.Attempts++
+
+	if err == nil {
+		return q.config.Store.Delete(ctx, job.ID)
+	}
+	if ctx.Err() != nil {
+		// The attempt was interrupted by shutdown; leave the job as it was so
+		// the next run retries it without charging the attempt.
+		return ctx.Err()
+	}
+
+	if !q.retryable(job.Attempts, err) {
+		if delErr := q.config.Store.Delete(ctx, job.ID); delErr != nil {
+			return delErr
+		}
+		job.LastError = err.Error()
+		if q.config.OnDead != nil {
+			q.config.OnDead(job, err)
+		}
+		return nil
+	}
+
+	job.LastError = err.Error()
+	job.NextRun = q.now().Add(q.config.Strategy.NextDelay(job.Attempts))
+	return q.config.Store.Put(ctx, job)
+}
+
+// retryable reports whether a job that has made attempts attempts and last
+// failed with err should run again.
+func (q *Queue) retryable(attempts int, err error) bool {
+	if errors.Is(err, ErrUnknownKind) {
+		return false
+	}
+	if q.config.MaxAttempts > 0 && attempts >= q.config.MaxAttempts {
+		return false
+	}
+	if q.config.RetryIf != nil && !q.config.RetryIf(err) {
+		return false
+	}
+	return q.config.Strategy.ShouldRetry(attempts, err)
+}
+
+func (q *Queue) handler(kind string) (Handler, bool) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	h, ok := q.handlers[kind]
+	return h, ok
+}
+
+// newID returns a random 128-bit job ID in hex.
+func newID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b[:]), nil
+}
@@ -0,0 +1,244 @@
+package retryqueue
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/remiges-tech/await/retry"
+)
+
+// clock is a manually advanced time source.
+type clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newTestQueue(t *testing.T, config Config) (*Queue, *clock) {
+	t.Helper()
+	q, err := New(config)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	c := &clock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	q.now = c.Now
+	return q, c
+}
+
+func TestQueue(t *testing.T) {
+	ctx := context.Background()
+	errDown := errors.New("down")
+
+	t.Run("reschedules failures until success", func(t *testing.T) {
+		store := NewMemoryStore()
+		q, c := newTestQueue(t, Config{Store: store, Strategy: &retry.ConstantDelay{Delay: time.Minute}})
+
+		calls := 0
+		q.Handle("email", func(ctx context.Context, payload []byte) error {
+			calls++
+			if string(payload) != "hello" {
+				t.Errorf("unexpected payload %q", payload)
+			}
+			if calls < 3 {
+				return errDown
+			}
+			return nil
+		})
+
+		err := q.Do(ctx, "email", []byte("hello"))
+		if !errors.Is(err, ErrQueued) || !errors.Is(err, errDown) {
+			t.Fatalf("expected queued down error, got %v", err)
+		}
+
+		if ran, err := q.RunDue(ctx); err != nil || ran != 0 {
+			t.Fatalf("expected nothing due yet, ran %d, %v", ran, err)
+		}
+
+		c.Advance(time.Minute)
+		if ran, err := q.RunDue(ctx); err != nil || ran != 1 {
+			t.Fatalf("expected one job run, ran %d, %v", ran, err)
+		}
+		jobs, _ := store.Due(ctx, c.Now().Add(time.Minute), 0)
+		if len(jobs) != 1 || jobs[0].Attempts != 2 || jobs[0].LastError != "down" {
+			t.Fatalf("expected job rescheduled after 2 attempts, got %+v", jobs)
+		}
+
+		c.Advance(time.Minute)
+		if _, err := q.RunDue(ctx); err != nil {
+			t.Fatalf("RunDue: %v", err)
+		}
+		if calls != 3 || store.Len() != 0 {
+			t.Fatalf("expected success on third call and empty store, got %d calls, %d jobs", calls, store.Len())
+		}
+	})
+
+	t.Run("success needs no storage", func(t *testing.T) {
+		store := NewMemoryStore()
+		q, _ := newTestQueue(t, Config{Store: store})
+		q.Handle("noop", func(ctx context.Context, payload []byte) error { return nil })
+
+		if err := q.Do(ctx, "noop", nil); err != nil || store.Len() != 0 {
+			t.Fatalf("expected success without storing, got %v, %d jobs", err, store.Len())
+		}
+	})
+
+	t.Run("dead letter", func(t *testing.T) {
+		store := NewMemoryStore()
+		var dead []Job
+		q, _ := newTestQueue(t, Config{
+			Store:       store,
+			Strategy:    &retry.NoDelay{},
+			MaxAttempts: 3,
+			OnDead:      func(job Job, err error) { dead = append(dead, job) },
+		})
+		q.Handle("charge", func(ctx context.Context, payload []byte) error { return errDown })
+
+		if _, err := q.Enqueue(ctx, "charge", []byte("order-1")); err != nil {
+			t.Fatalf("Enqueue: %v", err)
+		}
+		for i := 0; i < 5; i++ {
+			if _, err := q.RunDue(ctx); err != nil {
+				t.Fatalf("RunDue: %v", err)
+			}
+		}
+		if len(dead) != 1 || dead[0].Attempts != 3 || string(dead[0].Payload) != "order-1" {
+			t.Fatalf("expected one dead job after 3 attempts, got %+v", dead)
+		}
+		if store.Len() != 0 {
+			t.Fatalf("expected dead job removed from store, got %d jobs", store.Len())
+		}
+	})
+
+	t.Run("non-retryable error", func(t *testing.T) {
+		store := NewMemoryStore()
+		q, _ := newTestQueue(t, Config{Store: store})
+		q.Handle("charge", func(ctx context.Context, payload []byte) error {
+			return retry.Permanent(errDown)
+		})
+
+		if err := q.Do(ctx, "charge", nil); errors.Is(err, ErrQueued) || !errors.Is(err, errDown) {
+			t.Fatalf("expected unqueued permanent error, got %v", err)
+		}
+		if store.Len() != 0 {
+			t.Fatalf("expected nothing stored, got %d jobs", store.Len())
+		}
+	})
+
+	t.Run("unknown kind", func(t *testing.T) {
+		var deadErr error
+		q, _ := newTestQueue(t, Config{
+			Store:  NewMemoryStore(),
+			OnDead: func(job Job, err error) { deadErr = err },
+		})
+
+		if err := q.Do(ctx, "missing", nil); !errors.Is(err, ErrUnknownKind) {
+			t.Fatalf("expected ErrUnknownKind from Do, got %v", err)
+		}
+		if _, err := q.Enqueue(ctx, "missing", nil); err != nil {
+			t.Fatalf("Enqueue: %v", err)
+		}
+		if _, err := q.RunDue(ctx); err != nil {
+			t.Fatalf("RunDue: %v", err)
+		}
+		if !errors.Is(deadErr, ErrUnknownKind) {
+			t.Fatalf("expected job dropped with ErrUnknownKind, got %v", deadErr)
+		}
+	})
+
+	t.Run("Run processes until cancelled", func(t *testing.T) {
+		q, err := New(Config{Store: NewMemoryStore(), PollInterval: time.Millisecond})
+		if err != nil {
+			t.Fatalf("New: %v", err)
+		}
+		done := make(chan struct{})
+		q.Handle("signal", func(ctx context.Context, payload []byte) error {
+			close(done)
+			return nil
+		})
+
+		runCtx, cancel := context.WithCancel(ctx)
+		result := make(chan error, 1)
+		go func() { result <- q.Run(runCtx) }()
+
+		if _, err := q.Enqueue(ctx, "signal", nil); err != nil {
+			t.Fatalf("Enqueue: %v", err)
+		}
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("job was not run")
+		}
+
+		cancel()
+		if err := <-result; !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+	})
+}
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "queue.json")
+
+	store, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore: %v", err)
+	}
+	now := time.Now().UTC().Truncate(time.Second)
+	for _, job := range []Job{
+		{ID: "b", Kind: "email", Payload: []byte("second"), NextRun: now.Add(time.Second)},
+		{ID: "a", Kind: "email", Payload: []byte("first"), NextRun: now, Attempts: 2},
+		{ID: "c", Kind: "email", NextRun: now.Add(time.Hour)},
+	} {
+		if err := store.Put(ctx, job); err != nil {
+			t.Fatalf("Put: %v", err)
+		}
+	}
+	if err := store.Delete(ctx, "c"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	// A new store on the same file sees the jobs, as after a restart.
+	reopened, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	jobs, err := reopened.Due(ctx, now.Add(time.Hour), 0)
+	if err != nil {
+		t.Fatalf("Due: %v", err)
+	}
+	if len(jobs) != 2 || jobs[0].ID != "a" || string(jobs[0].Payload) != "first" || jobs[0].Attempts != 2 || jobs[1].ID != "b" {
+		t.Fatalf("expected jobs a and b in NextRun order, got %+v", jobs)
+	}
+	if jobs, _ := reopened.Due(ctx, now, 0); len(jobs) != 1 {
+		t.Fatalf("expected only job a due now, got %+v", jobs)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	for name, config := range map[string]Config{
+		"no store":          {},
+		"negative attempts": {Store: NewMemoryStore(), MaxAttempts: -1},
+		"negative interval": {Store: NewMemoryStore(), PollInterval: -time.Second},
+		"negative batch":    {Store: NewMemoryStore(), BatchSize: -1},
+	} {
+		if _, err := New(config); !errors.Is(err, ErrInvalidConfig) {
+			t.Errorf("%s: expected ErrInvalidConfig, got %v", name, err)
+		}
+	}
+}
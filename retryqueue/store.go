@@ -0,0 +1,197 @@
+package retryqueue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Store persists queued jobs. Implementations must be safe for concurrent use.
+// A SQL store needs a table keyed by ID with an index on NextRun; Due is then
+// a "WHERE next_run <= $1 ORDER BY next_run LIMIT $2" query.
+type Store interface {
+	// Put inserts the job, or replaces the stored job with the same ID.
+	Put(ctx context.Context, job Job) error
+	// Due returns up to limit jobs whose NextRun is not after now, earliest first.
+	Due(ctx context.Context, now time.Time, limit int) ([]Job, error)
+	// Delete removes the job with the given ID. Deleting a missing job is not an error.
+	Delete(ctx context.Context, id string) error
+}
+
+// MemoryStore is a Store that keeps jobs in memory. Jobs do not survive a
+// restart, so it is mainly useful for tests and as a reference implementation.
+type MemoryStore struct {
+	mu   sync.Mutex
+	jobs map[string]Job
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{jobs: make(map[string]Job)}
+}
+
+// Put stores a copy of job.
+func (s *MemoryStore) Put(ctx context.Context, job Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job.Payload = append([]byte(nil), job.Payload...)
+	s.jobs[job.ID] = job
+	return nil
+}
+
+// Due returns the jobs that are due at now, earliest first.
+func (s *MemoryStore) Due(ctx context.Context, now time.Time, limit int) ([]Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return due(s.jobs, now, limit), nil
+}
+
+// Delete removes the job with the given ID.
+func (s *MemoryStore) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.jobs, id)
+	return nil
+}
+
+// Len returns the number of stored jobs.
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.jobs)
+}
+
+// FileStore is a Store that keeps jobs in a JSON file. Every change rewrites
+// the file through a temporary file and a rename, so a crash leaves either the
+// old or the new contents. It suits modest queues owned by a single process.
+type FileStore struct {
+	mu   sync.Mutex
+	path string
+	jobs map[string]Job
+}
+
+// NewFileStore opens the store at path, loading any jobs saved by a previous
+// process. A missing file is treated as an empty store.
+func NewFileStore(path string) (*FileStore, error) {
+	s := &FileStore{path: path, jobs: make(map[string]Job)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var jobs []Job
+	if err := json.Unmarshal(data, &jobs); err != nil {
+		return nil, fmt.Errorf("retryqueue: decode %s: %w", path, err)
+	}
+	for _, job := range jobs {
+		s.jobs[job.ID] = job
+	}
+	return s, nil
+}
+
+// Put stores job and saves the file.
+func (s *FileStore) Put(ctx context.Context, job Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prev, existed := s.jobs[job.ID]
+	job.Payload = append([]byte(nil), job.Payload...)
+	s.jobs[job.ID] = job
+	if err := s.save(); err != nil {
+		if existed {
+			s.jobs[job.ID] = prev
+		} else {
+			delete(s.jobs, job.ID)
+		}
+		return err
+	}
+	return nil
+}
+
+// Due returns the jobs that are due at now, earliest first.
+func (s *FileStore) Due(ctx context.Context, now time.Time, limit int) ([]Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return due(s.jobs, now, limit), nil
+}
+
+// Delete removes the job with the given ID and saves the file.
+func (s *FileStore) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prev, existed := s.jobs[id]
+	if !existed {
+		return nil
+	}
+	delete(s.jobs, id)
+	if err := s.save(); err != nil {
+		s.jobs[id] = prev
+		return err
+	}
+	return nil
+}
+
+// save writes all jobs to a temporary file in the same directory and renames
+// it over the store file.
+func (s *FileStore) save() error {
+	jobs := make([]Job, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		jobs = append(jobs, job)
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].ID < jobs[j].ID })
+
+	data, err := json.Marshal(jobs)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+// due selects up to limit jobs with NextRun not after now, earliest first.
+func due(jobs map[string]Job, now time.Time, limit int) []Job {
+	var ready []Job
+	for _, job := range jobs {
+		if !job.NextRun.After(now) {
+			ready = append(ready, job)
+		}
+	}
+	sort.Slice(ready, func(i, j int) bool {
+		if !ready[i].NextRun.Equal(ready[j].NextRun) {
+			return ready[i].NextRun.Before(ready[j].NextRun)
+		}
+		return ready[i].ID < ready[j].ID
+	})
+	if limit > 0 && len(ready) > limit {
+		ready = ready[:limit]
+	}
+	return ready
+}
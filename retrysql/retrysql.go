@@ -0,0 +1,217 @@
+// Package retrysql provides retry conditions for database errors and a
+// transaction wrapper that retries whole transactions.
+//
+// Errors are classified without depending on a specific driver: by the
+// package's sentinel errors, by database/sql's connection errors, and by the
+// SQLSTATE code of errors that expose one through a SQLState() string method
+// (as the pgx and lib/pq PostgreSQL drivers do). Drivers with other error
+// shapes, such as MySQL's numeric codes, are supported by registering a
+// Classifier.
+package retrysql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/remiges-tech/await/retry"
+)
+
+var (
+	// ErrSerializationFailure marks a transaction aborted because it could not
+	// be serialized with concurrent transactions. Wrap a driver error with it
+	// to have the error classified as KindSerialization.
+	ErrSerializationFailure = errors.New("serialization failure")
+
+	// ErrDeadlock marks a transaction aborted to break a deadlock.
+	ErrDeadlock = errors.New("deadlock detected")
+
+	// ErrConnection marks a lost or unusable database connection.
+	ErrConnection = errors.New("database connection error")
+
+	// ErrCommitUnknown is returned by WithTx and WithTxOptions when the
+	// connection fails during commit. The server may have committed the
+	// transaction before the connection was lost, so it is not retried; the
+	// caller must check whether its effects were applied.
+	ErrCommitUnknown = errors.New("transaction commit outcome unknown")
+)
+
+// Kind is the retry-relevant class of a database error.
+type Kind int
+
+const (
+	// KindOther is an error that is not known to be transient.
+	KindOther Kind = iota
+	// KindSerialization is a serialization failure (SQLSTATE 40001).
+	KindSerialization
+	// KindDeadlock is a detected deadlock (SQLSTATE 40P01).
+	KindDeadlock
+	// KindConnection is a connection failure (SQLSTATE class 08, driver.ErrBadConn).
+	KindConnection
+)
+
+// String returns the kind name.
+func (k Kind) String() string {
+	switch k {
+	case KindSerialization:
+		return "serialization"
+	case KindDeadlock:
+		return "deadlock"
+	case KindConnection:
+		return "connection"
+	default:
+		return "other"
+	}
+}
+
+// Classifier maps a driver error to a Kind, returning KindOther for errors it
+// does not recognize.
+type Classifier func(err error) Kind
+
+var (
+	classifiersMu sync.RWMutex
+	classifiers   []Classifier
+)
+
+// RegisterClassifier adds a Classifier consulted by Classify after the
+// built-in rules, for drivers whose errors carry no SQLSTATE. For example,
+// for MySQL:
+//
+//	retrysql.RegisterClassifier(func(err error) retrysql.Kind {
+//		var me *mysql.MySQLError
+//		if errors.As(err, &me) && me.Number == 1213 {
+//			return retrysql.KindDeadlock
+//		}
+//		return retrysql.KindOther
+//	})
+func RegisterClassifier(c Classifier) {
+	classifiersMu.Lock()
+	defer classifiersMu.Unlock()
+	classifiers = append(classifiers, c)
+}
+
+// sqlStater is implemented by driver errors that expose their SQLSTATE code.
+type sqlStater interface {
+	SQLState() string
+}
+
+// Classify returns the Kind of err.
+func Classify(err error) Kind {
+	switch {
+	case err == nil:
+		return KindOther
+	case errors.Is(err, ErrSerializationFailure):
+		return KindSerialization
+	case errors.Is(err, ErrDeadlock):
+		return KindDeadlock
+	case errors.Is(err, ErrConnection), errors.Is(err, driver.ErrBadConn), errors.Is(err, sql.ErrConnDone):
+		return KindConnection
+	}
+
+	var st sqlStater
+	if errors.As(err, &st) {
+		switch code := st.SQLState(); {
+		case code == "40001":
+			return KindSerialization
+		case code == "40P01":
+			return KindDeadlock
+		case strings.HasPrefix(code, "08"):
+			return KindConnection
+		}
+	}
+
+	classifiersMu.RLock()
+	defer classifiersMu.RUnlock()
+	for _, c := range classifiers {
+		if kind := c(err); kind != KindOther {
+			return kind
+		}
+	}
+	return KindOther
+}
+
+// IsSerializationFailure reports whether err is a serialization failure.
+func IsSerializationFailure(err error) bool {
+	return Classify(err) == KindSerialization
+}
+
+// IsDeadlock reports whether err is a detected deadlock.
+func IsDeadlock(err error) bool {
+	return Classify(err) == KindDeadlock
+}
+
+// IsConnectionError reports whether err is a connection failure.
+func IsConnectionError(err error) bool {
+	return Classify(err) == KindConnection
+}
+
+// Retryable reports whether err is a serialization failure, deadlock or
+// connection failure. Use it as retry.Options.RetryIf.
+func Retryable(err error) bool {
+	return Classify(err) != KindOther
+}
+
+// TxBeginner starts transactions; *sql.DB and *sql.Conn implement it.
+type TxBeginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+// WithTx runs fn in a transaction and commits it, retrying the whole
+// transaction according to opts. See WithTxOptions.
+func WithTx(ctx context.Context, db TxBeginner, fn func(ctx context.Context, tx *sql.Tx) error, opts retry.Options) error {
+	return WithTxOptions(ctx, db, nil, fn, opts)
+}
+
+// WithTxOptions runs fn in a transaction started with txOpts and commits it.
+// Each attempt begins a fresh transaction; if fn fails or panics the
+// transaction is rolled back before the next attempt, so no statement from a
+// failed attempt survives. Commit failures are retried like fn's errors,
+// except connection failures: those leave it unknown whether the transaction
+// committed, so WithTxOptions stops and returns an error wrapping both
+// ErrCommitUnknown and the driver error, marked retry.Permanent.
+// A nil opts.RetryIf defaults to Retryable, since retrying other errors would
+// usually just repeat them. fn may run several times and must not have side
+// effects outside the transaction.
+func WithTxOptions(ctx context.Context, db TxBeginner, txOpts *sql.TxOptions, fn func(ctx context.Context, tx *sql.Tx) error, opts retry.Options) error {
+	if opts.RetryIf == nil {
+		opts.RetryIf = Retryable
+	}
+	_, err := retry.Do(ctx, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, runTx(ctx, db, txOpts, fn)
+	}, opts)
+	return err
+}
+
+// runTx makes one attempt at the transaction.
+func runTx(ctx context.Context, db TxBeginner, txOpts *sql.TxOptions, fn func(ctx context.Context, tx *sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, txOpts)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(ctx, tx); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil && !errors.Is(rbErr, sql.ErrTxDone) {
+			return errors.Join(err, rbErr)
+		}
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		if IsConnectionError(err) {
+			// The commit may have reached the server before the connection
+			// dropped; running fn again could apply the transaction twice.
+			return retry.Permanent(fmt.Errorf("%w: %w", ErrCommitUnknown, err))
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+package retrysql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/remiges-tech/await/retry"
+)
+
+// pgError mimics a PostgreSQL driver error exposing its SQLSTATE.
+type pgError struct{ code string }
+
+func (e *pgError) Error() string    { return "pg error " + e.code }
+func (e *pgError) SQLState() string { return e.code }
+
+// fakeDriver records transaction outcomes and can fail commits.
+type fakeDriver struct {
+	mu         sync.Mutex
+	commits    int
+	rollbacks  int
+	commitErrs []error // Returned by successive commits, then nil
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) { return &fakeConn{d}, nil }
+
+type fakeConn struct{ d *fakeDriver }
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+func (c *fakeConn) Close() error              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) { return &fakeTx{c.d}, nil }
+
+type fakeTx struct{ d *fakeDriver }
+
+func (tx *fakeTx) Commit() error {
+	tx.d.mu.Lock()
+	defer tx.d.mu.Unlock()
+	if len(tx.d.commitErrs) > 0 {
+		err := tx.d.commitErrs[0]
+		tx.d.commitErrs = tx.d.commitErrs[1:]
+		return err
+	}
+	tx.d.commits++
+	return nil
+}
+
+func (tx *fakeTx) Rollback() error {
+	tx.d.mu.Lock()
+	defer tx.d.mu.Unlock()
+	tx.d.rollbacks++
+	return nil
+}
+
+var driverSeq int
+
+func openFake(t *testing.T, d *fakeDriver) *sql.DB {
+	t.Helper()
+	driverSeq++
+	name := fmt.Sprintf("retrysql-fake-%d", driverSeq)
+	sql.Register(name, d)
+	db, err := sql.Open(name, "")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		err  error
+		kind Kind
+	}{
+		{&pgError{"40001"}, KindSerialization},
+		{fmt.Errorf("update: %w", &pgError{"40P01"}), KindDeadlock},
+		{&pgError{"08006"}, KindConnection},
+		{&pgError{"23505"}, KindOther},
+		{fmt.Errorf("query: %w", driver.ErrBadConn), KindConnection},
+		{sql.ErrConnDone, KindConnection},
+		{fmt.Errorf("%w: lock order", ErrDeadlock), KindDeadlock},
+		{ErrSerializationFailure, KindSerialization},
+		{sql.ErrNoRows, KindOther},
+		{nil, KindOther},
+	}
+	for _, tt := range tests {
+		if got := Classify(tt.err); got != tt.kind {
+			t.Errorf("Classify(%v) = %v, want %v", tt.err, got, tt.kind)
+		}
+	}
+
+	if !Retryable(&pgError{"40001"}) || Retryable(sql.ErrNoRows) {
+		t.Error("expected Retryable to accept transient kinds only")
+	}
+}
+
+// mysqlError mimics a driver error identified by a numeric code.
+type mysqlError struct{ number int }
+
+func (e *mysqlError) Error() string { return fmt.Sprintf("mysql error %d", e.number) }
+
+func TestRegisterClassifier(t *testing.T) {
+	err := &mysqlError{1213}
+	if IsDeadlock(err) {
+		t.Fatal("expected unregistered error shape to be unknown")
+	}
+
+	RegisterClassifier(func(err error) Kind {
+		var me *mysqlError
+		if errors.As(err, &me) && me.number == 1213 {
+			return KindDeadlock
+		}
+		return KindOther
+	})
+	if !IsDeadlock(err) {
+		t.Fatal("expected registered classifier to recognize the deadlock")
+	}
+}
+
+func TestWithTx(t *testing.T) {
+	ctx := context.Background()
+	opts := retry.Options{Strategy: &retry.NoDelay{}, MaxAttempts: 3}
+
+	t.Run("retries serialization failure with a fresh transaction", func(t *testing.T) {
+		d := &fakeDriver{}
+		db := openFake(t, d)
+
+		var txs []*sql.Tx
+		err := WithTx(ctx, db, func(ctx context.Context, tx *sql.Tx) error {
+			txs = append(txs, tx)
+			if len(txs) == 1 {
+				return &pgError{"40001"}
+			}
+			return nil
+		}, opts)
+		if err != nil {
+			t.Fatalf("expected success, got %v", err)
+		}
+		if len(txs) != 2 || txs[0] == txs[1] {
+			t.Fatalf("expected a new transaction per attempt, got %d", len(txs))
+		}
+		if d.rollbacks != 1 || d.commits != 1 {
+			t.Fatalf("expected 1 rollback and 1 commit, got %d and %d", d.rollbacks, d.commits)
+		}
+	})
+
+	t.Run("retries failed commit", func(t *testing.T) {
+		d := &fakeDriver{commitErrs: []error{&pgError{"40001"}}}
+		db := openFake(t, d)
+
+		attempts := 0
+		err := WithTx(ctx, db, func(ctx context.Context, tx *sql.Tx) error {
+			attempts++
+			return nil
+		}, opts)
+		if err != nil || attempts != 2 || d.commits != 1 {
+			t.Fatalf("expected commit to succeed on attempt 2, got %d attempts, %d commits, %v", attempts, d.commits, err)
+		}
+	})
+
+	t.Run("does not retry commit connection failure", func(t *testing.T) {
+		d := &fakeDriver{commitErrs: []error{driver.ErrBadConn}}
+		db := openFake(t, d)
+
+		attempts := 0
+		err := WithTx(ctx, db, func(ctx context.Context, tx *sql.Tx) error {
+			attempts++
+			return nil
+		}, opts)
+		if !errors.Is(err, ErrCommitUnknown) || !errors.Is(err, driver.ErrBadConn) || attempts != 1 {
+			t.Fatalf("expected ErrCommitUnknown after one attempt, got %d attempts, %v", attempts, err)
+		}
+	})
+
+	t.Run("does not retry other errors", func(t *testing.T) {
+		d := &fakeDriver{}
+		db := openFake(t, d)
+
+		attempts := 0
+		errConstraint := &pgError{"23505"}
+		err := WithTx(ctx, db, func(ctx context.Context, tx *sql.Tx) error {
+			attempts++
+			return errConstraint
+		}, opts)
+		if !errors.Is(err, errConstraint) || attempts != 1 || d.rollbacks != 1 {
+			t.Fatalf("expected one rolled back attempt, got %d attempts, %d rollbacks, %v", attempts, d.rollbacks, err)
+		}
+	})
+
+	t.Run("rolls back on panic", func(t *testing.T) {
+		d := &fakeDriver{}
+		db := openFake(t, d)
+
+		defer func() {
+			if recover() == nil {
+				t.Fatal("expected panic to propagate")
+			}
+			if d.rollbacks != 1 {
+				t.Fatalf("expected rollback, got %d", d.rollbacks)
+			}
+		}()
+		WithTx(ctx, db, func(ctx context.Context, tx *sql.Tx) error {
+			panic("boom")
+		}, opts)
+	})
+}
@@ -0,0 +1,115 @@
+package await
+
+import (
+	"context"
+	"sync"
+)
+
+// Scope owns a set of tasks that must all finish before the WithScope call
+// that created it returns. Tasks are started with Go or Spawn and share the
+// scope's context, which is cancelled when the first task started with Go,
+// or the scope body itself, fails. Code holding only the context can start
+// tasks in the scope through SpawnerFromContext.
+type Scope struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu     sync.Mutex
+	active int
+	closed bool
+	err    error
+
+	wg sync.WaitGroup
+}
+
+// WithScope runs body with a new Scope and waits for every task started in
+// it to return before returning itself, so no task outlives the call.
+// Returns the first error from body or from a task started with Go.
+func WithScope(ctx context.Context, body func(s *Scope) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	s := &Scope{cancel: cancel}
+	s.ctx = context.WithValue(ctx, spawnerKey{}, Spawner(s))
+
+	if err := body(s); err != nil {
+		s.fail(err)
+	}
+
+	// Tasks may start further tasks while the scope drains, so only close it
+	// once no task is active under the lock.
+	for {
+		s.wg.Wait()
+		s.mu.Lock()
+		if s.active == 0 {
+			s.closed = true
+			s.mu.Unlock()
+			break
+		}
+		s.mu.Unlock()
+	}
+
+	cancel()
+	return s.err
+}
+
+// Context returns the scope's context. It is cancelled when the scope fails
+// or the parent context is done.
+func (s *Scope) Context() context.Context {
+	return s.ctx
+}
+
+// Go starts fn in a new goroutine owned by the scope. An error returned by fn
+// cancels the scope's context and is reported by WithScope.
+// Returns ErrScopeClosed if WithScope has already returned.
+func (s *Scope) Go(fn func(ctx context.Context) error) error {
+	return s.spawn(func() {
+		if err := fn(s.ctx); err != nil {
+			s.fail(err)
+		}
+	})
+}
+
+// Spawn starts task in a new goroutine owned by s and returns a Future for its
+// outcome. Unlike Go, a failing task does not cancel the scope; its error is
+// only reported through the Future. If WithScope has already returned, the
+// Future completes immediately with ErrScopeClosed.
+func Spawn[T any](s *Scope, task Task[T]) *Future[T] {
+	f := newFuture[T]()
+	err := s.spawn(func() {
+		f.complete(task(s.ctx))
+	})
+	if err != nil {
+		var zero T
+		f.complete(zero, err)
+	}
+	return f
+}
+
+func (s *Scope) spawn(run func()) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return ErrScopeClosed
+	}
+
+	s.active++
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		run()
+
+		s.mu.Lock()
+		s.active--
+		s.mu.Unlock()
+	}()
+	return nil
+}
+
+// fail records the first error and cancels the scope.
+func (s *Scope) fail(err error) {
+	s.mu.Lock()
+	if s.err == nil {
+		s.err = err
+	}
+	s.mu.Unlock()
+	s.cancel()
+}
@@ -0,0 +1,174 @@
+package await
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithScope(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("waits for all tasks", func(t *testing.T) {
+		var finished atomic.Int32
+		err := WithScope(ctx, func(s *Scope) error {
+			for i := 0; i < 5; i++ {
+				s.Go(func(ctx context.Context) error {
+					time.Sleep(10 * time.Millisecond)
+					finished.Add(1)
+					return nil
+				})
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if n := finished.Load(); n != 5 {
+			t.Fatalf("expected 5 finished tasks, got %d", n)
+		}
+	})
+
+	t.Run("first error cancels siblings", func(t *testing.T) {
+		errBoom := errors.New("boom")
+		var cancelled atomic.Bool
+		err := WithScope(ctx, func(s *Scope) error {
+			s.Go(func(ctx context.Context) error {
+				<-ctx.Done()
+				cancelled.Store(true)
+				return ctx.Err()
+			})
+			s.Go(func(ctx context.Context) error { return errBoom })
+			return nil
+		})
+		if err != errBoom {
+			t.Fatalf("expected boom, got %v", err)
+		}
+		if !cancelled.Load() {
+			t.Fatal("expected sibling to observe cancellation before WithScope returned")
+		}
+	})
+
+	t.Run("body error", func(t *testing.T) {
+		errBody := errors.New("body failed")
+		err := WithScope(ctx, func(s *Scope) error {
+			s.Go(func(ctx context.Context) error {
+				<-ctx.Done()
+				return nil
+			})
+			return errBody
+		})
+		if err != errBody {
+			t.Fatalf("expected body error, got %v", err)
+		}
+	})
+
+	t.Run("nested spawn and futures", func(t *testing.T) {
+		var inner *Future[int]
+		err := WithScope(ctx, func(s *Scope) error {
+			outer := Spawn(s, func(ctx context.Context) (int, error) {
+				inner = Spawn(s, func(ctx context.Context) (int, error) {
+					time.Sleep(10 * time.Millisecond)
+					return 2, errors.New("inner failed")
+				})
+				return 1, nil
+			})
+			val, err := outer.Await(s.Context())
+			if err != nil || val != 1 {
+				t.Errorf("expected 1, got %d, %v", val, err)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("expected Spawn errors not to fail the scope, got %v", err)
+		}
+		if res, ok := inner.Result(); !ok || res.Err == nil {
+			t.Fatalf("expected inner task to have finished with an error, got %v, %v", res, ok)
+		}
+	})
+
+	t.Run("closed scope", func(t *testing.T) {
+		var leaked *Scope
+		WithScope(ctx, func(s *Scope) error {
+			leaked = s
+			return nil
+		})
+		if err := leaked.Go(func(ctx context.Context) error { return nil }); err != ErrScopeClosed {
+			t.Fatalf("expected ErrScopeClosed, got %v", err)
+		}
+		if _, err := Spawn(leaked, func(ctx context.Context) (int, error) { return 1, nil }).Await(ctx); err != ErrScopeClosed {
+			t.Fatalf("expected ErrScopeClosed, got %v", err)
+		}
+	})
+}
+
+func TestSpawnerFromContext(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("All waits for subtasks", func(t *testing.T) {
+		var finished atomic.Int32
+		parent := func(ctx context.Context) (int, error) {
+			for i := 0; i < 3; i++ {
+				err := SpawnerFromContext(ctx).Go(func(ctx context.Context) error {
+					time.Sleep(5 * time.Millisecond)
+					finished.Add(1)
+					return nil
+				})
+				if err != nil {
+					return 0, err
+				}
+			}
+			return 1, nil
+		}
+
+		results, err := AllWithOptions(ctx, Options{Subtasks: true}, parent, parent)
+		if err != nil || results[0].Err != nil || results[1].Err != nil {
+			t.Fatalf("expected success, got %v, %+v", err, results)
+		}
+		if finished.Load() != 6 {
+			t.Fatalf("expected all 6 subtasks finished before All returned, got %d", finished.Load())
+		}
+	})
+
+	t.Run("failing subtask fails its task", func(t *testing.T) {
+		errSub := errors.New("subtask failed")
+		results, _ := AllWithOptions(ctx, Options{Subtasks: true},
+			func(ctx context.Context) (int, error) {
+				_ = SpawnerFromContext(ctx).Go(func(ctx context.Context) error { return errSub })
+				<-ctx.Done() // cancelled by the failing subtask
+				return 0, ctx.Err()
+			},
+			func(ctx context.Context) (int, error) { return 2, nil },
+		)
+		if !errors.Is(results[0].Err, errSub) {
+			t.Errorf("expected subtask error, got %v", results[0].Err)
+		}
+		if results[1].Err != nil || results[1].Value != 2 {
+			t.Errorf("expected sibling unaffected, got %+v", results[1])
+		}
+	})
+
+	t.Run("joins the enclosing scope", func(t *testing.T) {
+		var ran atomic.Bool
+		err := WithScope(ctx, func(s *Scope) error {
+			return s.Go(func(ctx context.Context) error {
+				return SpawnerFromContext(ctx).Go(func(ctx context.Context) error {
+					time.Sleep(5 * time.Millisecond)
+					ran.Store(true)
+					return nil
+				})
+			})
+		})
+		if err != nil || !ran.Load() {
+			t.Fatalf("expected WithScope to wait for the nested subtask, got %v, ran %v", err, ran.Load())
+		}
+	})
+
+	t.Run("no spawner", func(t *testing.T) {
+		if err := SpawnerFromContext(ctx).Go(func(ctx context.Context) error { return nil }); !errors.Is(err, ErrNoSpawner) {
+			t.Fatalf("expected ErrNoSpawner, got %v", err)
+		}
+	})
+}
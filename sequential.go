@@ -0,0 +1,87 @@
+package await
+
+import "context"
+
+// allSequential runs tasks one at a time, in order, on the caller's goroutine.
+// Tasks reached after ctx is done record the context error without running.
+// With AbortAfterFailures, abort cancels ctx once that many tasks have failed
+// and the remaining tasks are skipped.
+func allSequential[T any](ctx context.Context, opts Options, tasks []Task[T], abort context.CancelCauseFunc) []Result[T] {
+	results := make([]Result[T], len(tasks))
+	var infos []*taskInfo
+	if trackingEnabled(opts) {
+		infos = make([]*taskInfo, len(tasks))
+	}
+
+	failures := 0
+	for i, task := range tasks {
+		var info *taskInfo
+		if infos != nil {
+			info = &taskInfo{index: i}
+			infos[i] = info
+		}
+		if abort != nil && failures == opts.AbortAfterFailures {
+			results[i] = Result[T]{Err: skippedError(ctx, opts, i)}
+			continue
+		}
+		val, err := runSingle(ctx, opts, info, task)
+		results[i] = Result[T]{Value: val, Err: err, Timing: info.timing()}
+		if err != nil && abort != nil {
+			if failures++; failures == opts.AbortAfterFailures {
+				abort(abortCause(failures))
+			}
+		}
+	}
+
+	if infos != nil {
+		logAllFailures(ctx, results, infos)
+	}
+	return results
+}
+
+// anySequential runs tasks one at a time on the caller's goroutine, in the
+// given order or argument order when order is nil, and stops at the first
+// success. Errors are collected in the order tasks ran, and recorded in report
+// when it is non-nil.
+func anySequential[T any](ctx context.Context, opts Options, tasks []Task[T], order []int, report *AnyReport) (T, error) {
+	tracking := trackingEnabled(opts)
+	var errs []error
+	var indices []int
+	var failed []*taskInfo
+
+	for k := range tasks {
+		i := k
+		if order != nil {
+			i = order[k]
+		}
+		var info *taskInfo
+		if tracking {
+			info = &taskInfo{index: i}
+		}
+		val, err := runSingle(ctx, opts, info, tasks[i])
+		if err == nil {
+			if report != nil {
+				report.Winner = i
+				report.Timing = info.timing()
+			}
+			return val, nil
+		}
+		if errs == nil {
+			errs = make([]error, 0, len(tasks))
+			indices = make([]int, 0, len(tasks))
+		}
+		errs = append(errs, err)
+		indices = append(indices, i)
+		if report != nil {
+			report.Failures = append(report.Failures, IndexedError{Index: i, Err: err})
+		}
+		if info != nil {
+			failed = append(failed, info)
+		}
+	}
+
+	var zero T
+	aggErr := newAggregateError(opts, errs, indices, failed)
+	logAggregateFailure(ctx, len(tasks), aggErr, failed)
+	return zero, aggErr
+}
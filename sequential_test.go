@@ -0,0 +1,83 @@
+package await
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestSequential(t *testing.T) {
+	ctx := context.Background()
+	opts := Options{Sequential: true}
+
+	var order []int
+	record := func(i int, err error) Task[int] {
+		return func(ctx context.Context) (int, error) {
+			order = append(order, i)
+			return i, err
+		}
+	}
+
+	t.Run("All runs in order", func(t *testing.T) {
+		order = nil
+		results, err := AllWithOptions(ctx, opts, record(0, nil), record(1, errors.New("failed")), record(2, nil))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(order) != 3 || order[0] != 0 || order[1] != 1 || order[2] != 2 {
+			t.Fatalf("expected order [0 1 2], got %v", order)
+		}
+		if results[1].Err == nil || results[2].Value != 2 {
+			t.Fatalf("unexpected results: %v", results)
+		}
+	})
+
+	t.Run("Any stops at first success", func(t *testing.T) {
+		order = nil
+		val, err := AnyWithOptions(ctx, opts, record(0, errors.New("failed")), record(1, nil), record(2, nil))
+		if err != nil || val != 1 {
+			t.Fatalf("expected {1, nil}, got {%d, %v}", val, err)
+		}
+		if len(order) != 2 {
+			t.Fatalf("expected 2 tasks to run, got %v", order)
+		}
+	})
+
+	t.Run("Any aggregates errors in order", func(t *testing.T) {
+		e1, e2 := errors.New("e1"), errors.New("e2")
+		_, err := AnyWithOptions(ctx, opts, record(0, e1), record(1, e2))
+		aggErr, ok := err.(*AggregateError)
+		if !ok || len(aggErr.Errors) != 2 || aggErr.Errors[0] != e1 || aggErr.Errors[1] != e2 {
+			t.Fatalf("expected AggregateError [e1 e2], got %v", err)
+		}
+	})
+
+	t.Run("Race returns first task", func(t *testing.T) {
+		order = nil
+		val, err := RaceWithOptions(ctx, opts, record(0, nil), record(1, nil))
+		if err != nil || val != 0 {
+			t.Fatalf("expected {0, nil}, got {%d, %v}", val, err)
+		}
+		if len(order) != 1 {
+			t.Fatalf("expected only the first task to run, got %v", order)
+		}
+	})
+
+	t.Run("context cancelled mid-run", func(t *testing.T) {
+		cctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		order = nil
+		cancelling := Task[int](func(ctx context.Context) (int, error) {
+			cancel()
+			return 0, nil
+		})
+		results, err := AllWithOptions(cctx, opts, cancelling, record(1, nil))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if results[1].Err != context.Canceled || len(order) != 0 {
+			t.Fatalf("expected second task skipped with context.Canceled, got %v (ran %v)", results[1].Err, order)
+		}
+	})
+}
@@ -0,0 +1,60 @@
+package await
+
+import (
+	"context"
+	"sync"
+)
+
+// Shared is a mutex-guarded value for state that concurrently running tasks
+// accumulate into, such as a per-provider status map or a running total.
+// The zero value holds the zero value of T and is ready to use.
+//
+// For reference types such as maps and slices, mutate them only inside
+// Update; the value returned by Load is shared, not a copy.
+type Shared[T any] struct {
+	mu  sync.Mutex
+	val T
+}
+
+// NewShared returns a Shared holding initial.
+func NewShared[T any](initial T) *Shared[T] {
+	return &Shared[T]{val: initial}
+}
+
+// Load returns the current value.
+func (s *Shared[T]) Load() T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.val
+}
+
+// Store replaces the current value.
+func (s *Shared[T]) Store(val T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.val = val
+}
+
+// Update replaces the value with fn's result and returns it. fn runs with the
+// lock held, so it must not call other methods of s.
+func (s *Shared[T]) Update(fn func(T) T) T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.val = fn(s.val)
+	return s.val
+}
+
+// Accumulate wraps task so that each run's outcome is folded into shared with
+// fn before the task returns. The task's value and error pass through unchanged,
+// so it can be used with All, Any, Race or any other combinator while a
+// cross-task view, such as which providers failed, is built up without
+// hand-written locking.
+func Accumulate[T, S any](task Task[T], shared *Shared[S], fn func(S, Result[T]) S) Task[T] {
+	return func(ctx context.Context) (T, error) {
+		val, err := task(ctx)
+		shared.Update(func(s S) S {
+			return fn(s, Result[T]{Value: val, Err: err})
+		})
+		return val, err
+	}
+}
@@ -0,0 +1,60 @@
+package await
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestShared(t *testing.T) {
+	t.Run("concurrent updates", func(t *testing.T) {
+		var counter Shared[int]
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				counter.Update(func(n int) int { return n + 1 })
+			}()
+		}
+		wg.Wait()
+		if n := counter.Load(); n != 100 {
+			t.Fatalf("expected 100, got %d", n)
+		}
+
+		counter.Store(7)
+		if n := counter.Load(); n != 7 {
+			t.Fatalf("expected 7 after Store, got %d", n)
+		}
+	})
+
+	t.Run("Accumulate", func(t *testing.T) {
+		statuses := NewShared(map[string]string{})
+		track := func(name string, task Task[int]) Task[int] {
+			return Accumulate(task, statuses, func(m map[string]string, r Result[int]) map[string]string {
+				if r.Err != nil {
+					m[name] = "failed"
+				} else {
+					m[name] = "ok"
+				}
+				return m
+			})
+		}
+
+		results, err := All(context.Background(),
+			track("a", func(ctx context.Context) (int, error) { return 1, nil }),
+			track("b", func(ctx context.Context) (int, error) { return 0, errors.New("down") }),
+		)
+		if err != nil || results[0].Value != 1 || results[1].Err == nil {
+			t.Fatalf("expected results to pass through unchanged, got %v, %v", results, err)
+		}
+
+		statuses.Update(func(m map[string]string) map[string]string {
+			if m["a"] != "ok" || m["b"] != "failed" {
+				t.Errorf("expected a=ok b=failed, got %v", m)
+			}
+			return m
+		})
+	})
+}
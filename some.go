@@ -0,0 +1,119 @@
+package await
+
+import (
+	"context"
+	"fmt"
+)
+
+// Some executes all tasks concurrently and returns once n of them have
+// succeeded, cancelling the rest: the quorum or consensus-read pattern.
+// Values are returned in completion order. As soon as enough tasks have failed
+// that n successes are no longer possible, the remaining tasks are cancelled
+// and an *AggregateError holding the failures is returned.
+// n must be between 1 and len(tasks); otherwise the error wraps ErrInvalidQuorum.
+func Some[T any](ctx context.Context, n int, tasks ...Task[T]) ([]T, error) {
+	return SomeWithOptions(ctx, Options{}, n, tasks...)
+}
+
+// SomeWithOptions is like Some but applies the given execution options.
+// With Sequential, tasks run in order until n have succeeded.
+func SomeWithOptions[T any](ctx context.Context, opts Options, n int, tasks ...Task[T]) ([]T, error) {
+	if len(tasks) == 0 {
+		return nil, ErrNoTasks
+	}
+	if n < 1 || n > len(tasks) {
+		return nil, fmt.Errorf("%w: need %d successes from %d tasks", ErrInvalidQuorum, n, len(tasks))
+	}
+	tasks = admit(opts, tasks)
+
+	q := &quorum[T]{
+		need:     n,
+		spare:    len(tasks) - n,
+		tracking: trackingEnabled(opts),
+		values:   make([]T, 0, n),
+	}
+
+	if opts.Sequential {
+		for i, task := range tasks {
+			info := q.info(i)
+			val, err := runSingle(ctx, opts, info, task)
+			if q.add(i, info, val, err) {
+				break
+			}
+		}
+	} else {
+		ctx, cancel := context.WithCancelCause(ctx)
+		defer cancel(nil)
+
+		type outcome struct {
+			idx  int
+			info *taskInfo
+			val  T
+			err  error
+		}
+		// Buffered so tasks finishing after the quorum settles never block.
+		outcomes := make(chan outcome, len(tasks))
+		for i, t := range tasks {
+			go func(idx int, task Task[T]) {
+				info := q.info(idx)
+				val, err := runSingle(ctx, opts, info, task)
+				outcomes <- outcome{idx, info, val, err}
+			}(i, t)
+		}
+		for range tasks {
+			o := <-outcomes
+			if q.add(o.idx, o.info, o.val, o.err) {
+				break
+			}
+		}
+		if q.met() {
+			cancel(ErrSiblingSucceeded) // Cancel remaining
+		}
+	}
+
+	if q.met() {
+		return q.values, nil
+	}
+	aggErr := newAggregateError(opts, q.errs, q.indices, q.failed)
+	logAggregateFailure(ctx, len(tasks), aggErr, q.failed)
+	return nil, aggErr
+}
+
+// quorum tallies the outcomes of a Some call on the calling goroutine.
+type quorum[T any] struct {
+	need     int // Successes still required
+	spare    int // Failures that can still be tolerated
+	tracking bool
+	values   []T
+	errs     []error
+	indices  []int
+	failed   []*taskInfo
+}
+
+func (q *quorum[T]) info(idx int) *taskInfo {
+	if !q.tracking {
+		return nil
+	}
+	return &taskInfo{index: idx}
+}
+
+// add records one outcome and reports whether the call is settled, either
+// because the quorum is met or because it can no longer be met.
+func (q *quorum[T]) add(idx int, info *taskInfo, val T, err error) bool {
+	if err == nil {
+		q.values = append(q.values, val)
+		q.need--
+		return q.need == 0
+	}
+	q.errs = append(q.errs, err)
+	q.indices = append(q.indices, idx)
+	if info != nil {
+		q.failed = append(q.failed, info)
+	}
+	q.spare--
+	return q.spare < 0
+}
+
+func (q *quorum[T]) met() bool {
+	return q.need == 0
+}
@@ -0,0 +1,86 @@
+package await
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestSome(t *testing.T) {
+	ctx := context.Background()
+
+	value := func(v int, d time.Duration) Task[int] {
+		return func(ctx context.Context) (int, error) {
+			select {
+			case <-time.After(d):
+				return v, nil
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			}
+		}
+	}
+	fail := func(ctx context.Context) (int, error) { return 0, errors.New("replica down") }
+
+	t.Run("quorum met", func(t *testing.T) {
+		cancelled := make(chan bool, 1)
+		straggler := func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			cancelled <- CancelledBySibling(ctx)
+			return 0, ctx.Err()
+		}
+
+		vals, err := Some(ctx, 2, value(1, 0), fail, value(2, 5*time.Millisecond), straggler)
+		if err != nil {
+			t.Fatalf("expected quorum, got %v", err)
+		}
+		sort.Ints(vals)
+		if len(vals) != 2 || vals[0] != 1 || vals[1] != 2 {
+			t.Fatalf("expected [1 2], got %v", vals)
+		}
+		if !<-cancelled {
+			t.Fatal("expected straggler to be cancelled once the quorum was met")
+		}
+	})
+
+	t.Run("quorum impossible", func(t *testing.T) {
+		start := time.Now()
+		_, err := Some(ctx, 2, fail, fail, value(1, time.Second))
+		var aggErr *AggregateError
+		if !errors.As(err, &aggErr) || aggErr.Len() != 2 {
+			t.Fatalf("expected AggregateError with 2 failures, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+			t.Fatalf("expected early return once the quorum was unreachable, took %v", elapsed)
+		}
+	})
+
+	t.Run("sequential", func(t *testing.T) {
+		ran := 0
+		count := func(v int) Task[int] {
+			return func(ctx context.Context) (int, error) {
+				ran++
+				return v, nil
+			}
+		}
+		vals, err := SomeWithOptions(ctx, Options{Sequential: true}, 2, count(1), count(2), count(3))
+		if err != nil || len(vals) != 2 || vals[0] != 1 || vals[1] != 2 {
+			t.Fatalf("expected [1 2], got %v, %v", vals, err)
+		}
+		if ran != 2 {
+			t.Fatalf("expected 2 tasks to run, got %d", ran)
+		}
+	})
+
+	t.Run("invalid quorum", func(t *testing.T) {
+		for _, n := range []int{0, 3} {
+			if _, err := Some(ctx, n, fail, fail); !errors.Is(err, ErrInvalidQuorum) {
+				t.Errorf("n=%d: expected ErrInvalidQuorum, got %v", n, err)
+			}
+		}
+		if _, err := Some[int](ctx, 1); !errors.Is(err, ErrNoTasks) {
+			t.Errorf("expected ErrNoTasks, got %v", err)
+		}
+	})
+}
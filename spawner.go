@@ -0,0 +1,50 @@
+package await
+
+import "context"
+
+// Spawner starts subtasks tied to the lifetime of the task or scope that owns
+// it. *Scope is a Spawner.
+type Spawner interface {
+	// Go starts fn in a new goroutine. An error returned by fn cancels the
+	// owner's context and is reported as the owner's error.
+	Go(fn func(ctx context.Context) error) error
+}
+
+type spawnerKey struct{}
+
+// SpawnerFromContext returns the Spawner of the running task: a per-task
+// scope for tasks run with Options.Subtasks, or the enclosing Scope for tasks
+// started inside WithScope. Either way the owning call does not return until
+// the subtasks have finished. Outside both, the returned Spawner's Go fails
+// with ErrNoSpawner without starting anything.
+func SpawnerFromContext(ctx context.Context) Spawner {
+	if s, ok := ctx.Value(spawnerKey{}).(Spawner); ok {
+		return s
+	}
+	return noSpawner{}
+}
+
+type noSpawner struct{}
+
+func (noSpawner) Go(func(ctx context.Context) error) error {
+	return ErrNoSpawner
+}
+
+// withSubtasks runs task in its own Scope, so the subtasks it spawns finish
+// before it is reported done. A failing subtask cancels the task and its
+// other subtasks, and its error becomes the task's error.
+func withSubtasks[T any](task Task[T]) Task[T] {
+	return func(ctx context.Context) (T, error) {
+		var val T
+		err := WithScope(ctx, func(s *Scope) error {
+			var err error
+			val, err = task(s.ctx)
+			return err
+		})
+		if err != nil {
+			var zero T
+			return zero, err
+		}
+		return val, nil
+	}
+}
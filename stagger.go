@@ -0,0 +1,63 @@
+package await
+
+import "time"
+
+// launch starts the tasks of an Any or Race call in the given order, or in
+// argument order when order is nil. Without StaggerDelay every task starts at
+// once. With it, each task starts StaggerDelay after the previous one, or
+// immediately when a task of an Any call fails, and tasks not yet started
+// when the call settles are skipped.
+func (st *firstState[T]) launch(tasks []Task[T], order []int) {
+	if order == nil {
+		order = make([]int, len(tasks))
+		for i := range order {
+			order[i] = i
+		}
+	}
+
+	if st.opts.StaggerDelay <= 0 {
+		goroutines.Add(int64(len(order)))
+		for _, i := range order {
+			go st.run(i, tasks[i])
+		}
+		return
+	}
+
+	if !st.firstCompletion {
+		st.failure = make(chan struct{}, 1)
+	}
+	goroutines.Add(2)
+	go st.run(order[0], tasks[order[0]])
+	go func() {
+		defer goroutines.Add(-1)
+		for k := 1; k < len(order); k++ {
+			timer := time.NewTimer(st.opts.StaggerDelay)
+			select {
+			case <-st.done:
+				timer.Stop()
+				for ; k < len(order); k++ {
+					st.skip(order[k])
+				}
+				return
+			case <-st.failure:
+				timer.Stop()
+			case <-timer.C:
+			}
+			goroutines.Add(1)
+			go st.run(order[k], tasks[order[k]])
+		}
+	}()
+}
+
+// skip accounts for a task that was never started because the call settled first.
+func (st *firstState[T]) skip(idx int) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.remaining--
+	if st.running != nil {
+		st.running[idx] = false
+	}
+	if st.remaining == 0 && st.finished != nil {
+		close(st.finished)
+	}
+}
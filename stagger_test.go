@@ -0,0 +1,83 @@
+package await
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestStaggerDelay(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("fast first task avoids backups", func(t *testing.T) {
+		var started atomic.Int32
+		task := func(v int, d time.Duration) Task[int] {
+			return func(ctx context.Context) (int, error) {
+				started.Add(1)
+				time.Sleep(d)
+				return v, nil
+			}
+		}
+
+		val, err := AnyWithOptions(ctx, Options{StaggerDelay: 50 * time.Millisecond},
+			task(1, 5*time.Millisecond), task(2, 0), task(3, 0))
+		if err != nil || val != 1 {
+			t.Fatalf("expected 1, got %d, %v", val, err)
+		}
+		if n := started.Load(); n != 1 {
+			t.Fatalf("expected only the first task to start, got %d", n)
+		}
+	})
+
+	t.Run("slow first task launches backup", func(t *testing.T) {
+		val, err := AnyWithOptions(ctx, Options{StaggerDelay: 10 * time.Millisecond},
+			func(ctx context.Context) (int, error) {
+				<-ctx.Done()
+				return 0, ctx.Err()
+			},
+			func(ctx context.Context) (int, error) { return 2, nil },
+		)
+		if err != nil || val != 2 {
+			t.Fatalf("expected backup to win with 2, got %d, %v", val, err)
+		}
+	})
+
+	t.Run("failure starts next task immediately", func(t *testing.T) {
+		start := time.Now()
+		val, err := AnyWithOptions(ctx, Options{StaggerDelay: time.Second},
+			func(ctx context.Context) (int, error) { return 0, errors.New("down") },
+			func(ctx context.Context) (int, error) { return 2, nil },
+		)
+		if err != nil || val != 2 {
+			t.Fatalf("expected 2, got %d, %v", val, err)
+		}
+		if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+			t.Fatalf("expected failover without waiting for the stagger delay, took %v", elapsed)
+		}
+	})
+
+	t.Run("all fail", func(t *testing.T) {
+		fail := func(ctx context.Context) (int, error) { return 0, errors.New("down") }
+		_, err := AnyWithOptions(ctx, Options{StaggerDelay: time.Second}, fail, fail, fail)
+		var aggErr *AggregateError
+		if !errors.As(err, &aggErr) || aggErr.Len() != 3 {
+			t.Fatalf("expected 3 aggregated errors, got %v", err)
+		}
+	})
+
+	t.Run("Race", func(t *testing.T) {
+		var started atomic.Int32
+		task := func(ctx context.Context) (int, error) {
+			started.Add(1)
+			return 1, nil
+		}
+		if _, err := RaceWithOptions(ctx, Options{StaggerDelay: 50 * time.Millisecond}, task, task); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if n := started.Load(); n != 1 {
+			t.Fatalf("expected one task to start, got %d", n)
+		}
+	})
+}
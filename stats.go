@@ -0,0 +1,69 @@
+package await
+
+import (
+	"expvar"
+	"sync/atomic"
+)
+
+// StatsSnapshot counts the work in progress at the moment Stats was called.
+// Only work started while stats collection is enabled is counted.
+type StatsSnapshot struct {
+	InFlight   int64 `json:"in_flight"`   // Tasks running in All, Any, Race and Pool workers
+	PoolQueued int64 `json:"pool_queued"` // Tasks waiting for a worker, summed over all pools
+	RetryLoops int64 `json:"retry_loops"` // retry.Do and retry.DoParallel calls in progress
+}
+
+var (
+	statsEnabled atomic.Bool
+	inFlight     atomic.Int64
+	poolQueued   atomic.Int64
+	retryLoops   atomic.Int64
+)
+
+// EnableStats turns collection of the counts returned by Stats on or off.
+// Collection costs a few atomic operations per task, so it is off by default.
+// Work already in progress when collection is switched is still counted
+// correctly when it finishes.
+func EnableStats(enabled bool) {
+	statsEnabled.Store(enabled)
+}
+
+// Stats returns the current counts of in-flight tasks, queued pool tasks and
+// running retry loops.
+func Stats() StatsSnapshot {
+	return StatsSnapshot{
+		InFlight:   inFlight.Load(),
+		PoolQueued: poolQueued.Load(),
+		RetryLoops: retryLoops.Load(),
+	}
+}
+
+// StatsVar returns an expvar.Var that reports Stats as JSON, for publishing
+// on the /debug/vars endpoint:
+//
+//	await.EnableStats(true)
+//	expvar.Publish("await", await.StatsVar())
+func StatsVar() expvar.Var {
+	return expvar.Func(func() any { return Stats() })
+}
+
+// TrackRetryLoop counts a retry loop as in progress until the returned
+// function is called. It is exported for packages built on await, such as
+// retry; it does nothing while stats collection is disabled.
+func TrackRetryLoop() (done func()) {
+	if !statsEnabled.Load() {
+		return func() {}
+	}
+	retryLoops.Add(1)
+	return endRetryLoop
+}
+
+func endRetryLoop() {
+	retryLoops.Add(-1)
+}
+
+// instrumented reports whether tasks must run through runTask even without
+// options that need it, so that subscribers and stats see them.
+func instrumented() bool {
+	return Subscribed() || statsEnabled.Load()
+}
@@ -0,0 +1,90 @@
+package await
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	ctx := context.Background()
+	EnableStats(true)
+	t.Cleanup(func() { EnableStats(false) })
+
+	t.Run("counts running tasks", func(t *testing.T) {
+		release := make(chan struct{})
+		started := make(chan struct{}, 2)
+		blocking := func(ctx context.Context) (int, error) {
+			started <- struct{}{}
+			<-release
+			return 1, nil
+		}
+
+		done := make(chan struct{})
+		go func() {
+			_, _ = All(ctx, blocking, blocking)
+			close(done)
+		}()
+		<-started
+		<-started
+		if s := Stats(); s.InFlight != 2 {
+			t.Fatalf("expected 2 in-flight tasks, got %+v", s)
+		}
+		close(release)
+		<-done
+		if s := Stats(); s.InFlight != 0 {
+			t.Fatalf("expected no in-flight tasks after All returned, got %+v", s)
+		}
+	})
+
+	t.Run("counts queued pool tasks", func(t *testing.T) {
+		release := make(chan struct{})
+		started := make(chan struct{})
+		pool := NewPool[int](ctx, 1)
+		first := pool.Submit(func(ctx context.Context) (int, error) {
+			close(started)
+			<-release
+			return 1, nil
+		})
+		<-started
+		pool.Submit(func(ctx context.Context) (int, error) { return 2, nil })
+		pool.Submit(func(ctx context.Context) (int, error) { return 3, nil })
+
+		if s := Stats(); s.PoolQueued != 2 || s.InFlight != 1 {
+			t.Fatalf("expected 2 queued and 1 running, got %+v", s)
+		}
+		close(release)
+		_, _ = first.Await(ctx)
+		pool.Close()
+		if s := Stats(); s != (StatsSnapshot{}) {
+			t.Fatalf("expected zero stats after Close, got %+v", s)
+		}
+	})
+
+	t.Run("retry loops", func(t *testing.T) {
+		done := TrackRetryLoop()
+		if s := Stats(); s.RetryLoops != 1 {
+			t.Fatalf("expected 1 retry loop, got %+v", s)
+		}
+		done()
+		if s := Stats(); s.RetryLoops != 0 {
+			t.Fatalf("expected 0 retry loops, got %+v", s)
+		}
+	})
+
+	t.Run("expvar", func(t *testing.T) {
+		var s StatsSnapshot
+		if err := json.Unmarshal([]byte(StatsVar().String()), &s); err != nil {
+			t.Fatalf("expected JSON from StatsVar, got %v", err)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		EnableStats(false)
+		defer EnableStats(true)
+		TrackRetryLoop() // not counted, so never needs its done call
+		if s := Stats(); s.RetryLoops != 0 {
+			t.Fatalf("expected nothing counted while disabled, got %+v", s)
+		}
+	})
+}
@@ -0,0 +1,208 @@
+// Package supervisor runs long-lived tasks and restarts them when they fail,
+// in the style of an Erlang supervisor. Restart delays come from a
+// retry.Strategy, a restart budget per time window stops crash loops, and
+// every state transition is reported to an Observer. Children run under an
+// await.Lifecycle, so stopping the supervisor is a graceful shutdown.
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/remiges-tech/await"
+	"github.com/remiges-tech/await/retry"
+)
+
+var (
+	// ErrRestartLimit is returned by a child that failed more than MaxRestarts
+	// times within Window.
+	ErrRestartLimit = errors.New("restart limit exceeded")
+
+	// ErrDuplicateChild is returned when adding a child whose name is already in use.
+	ErrDuplicateChild = errors.New("child already exists")
+)
+
+// State describes where a child is in its lifecycle.
+type State string
+
+const (
+	StateRunning    State = "running"    // Child function is executing
+	StateRestarting State = "restarting" // Child failed and is waiting out its restart delay
+	StateCompleted  State = "completed"  // Child returned nil and will not be restarted
+	StateFailed     State = "failed"     // Child gave up: non-retryable error or restart limit exceeded
+	StateStopped    State = "stopped"    // Supervisor was stopped
+)
+
+// Event describes a single child state transition.
+type Event struct {
+	Child    string    // Child name
+	From     State     // Previous state, or "" when the child is first started
+	To       State     // New state
+	Err      error     // Error that caused the transition, if any
+	Restarts int       // Restarts performed so far
+	Time     time.Time // When the transition happened
+}
+
+// Observer receives child state transitions. Calls for one child are made
+// in order from that child's goroutine; different children report concurrently.
+type Observer interface {
+	StateChanged(Event)
+}
+
+// ObserverFunc adapts a function to the Observer interface.
+type ObserverFunc func(Event)
+
+// StateChanged calls f(e).
+func (f ObserverFunc) StateChanged(e Event) {
+	f(e)
+}
+
+// Child describes a supervised task and its restart policy.
+type Child struct {
+	Name        string                          // Unique name used in events and errors
+	Run         func(ctx context.Context) error // Long-lived task; should return when ctx is done
+	Strategy    retry.Strategy                  // Restart delay and retry decision (defaults to retry.DefaultOptions' strategy)
+	MaxRestarts int                             // Restarts allowed within Window before giving up (0 = unlimited)
+	Window      time.Duration                   // Sliding window for MaxRestarts; a run lasting longer restarts the Strategy at attempt 1 (0 = supervisor lifetime)
+}
+
+// Supervisor runs children and restarts them on failure.
+type Supervisor struct {
+	lc       *await.Lifecycle
+	observer Observer
+
+	mu     sync.Mutex
+	states map[string]State
+}
+
+// New creates a Supervisor whose children run under a context derived from ctx.
+// observer may be nil.
+func New(ctx context.Context, observer Observer) *Supervisor {
+	return &Supervisor{
+		lc:       await.NewLifecycle(ctx),
+		observer: observer,
+		states:   make(map[string]State),
+	}
+}
+
+// Add starts a child. Returns ErrDuplicateChild if the name is in use, or
+// await.ErrLifecycleStopped if the supervisor has been stopped.
+func (s *Supervisor) Add(child Child) error {
+	s.mu.Lock()
+	if _, ok := s.states[child.Name]; ok {
+		s.mu.Unlock()
+		return fmt.Errorf("%w: %s", ErrDuplicateChild, child.Name)
+	}
+	s.states[child.Name] = ""
+	s.mu.Unlock()
+
+	if child.Strategy == nil {
+		child.Strategy = retry.DefaultOptions().Strategy
+	}
+	err := s.lc.Go(child.Name, func(ctx context.Context) error {
+		return s.supervise(ctx, child)
+	})
+	if err != nil {
+		s.mu.Lock()
+		delete(s.states, child.Name)
+		s.mu.Unlock()
+	}
+	return err
+}
+
+// State returns the current state of the named child and whether it exists.
+func (s *Supervisor) State(name string) (State, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, ok := s.states[name]
+	return state, ok
+}
+
+// Stop cancels every child and waits for them to return until ctx is done.
+// Returns an *await.ShutdownError listing children that gave up or did not
+// stop in time, or nil on a clean stop.
+func (s *Supervisor) Stop(ctx context.Context) error {
+	return s.lc.Shutdown(ctx)
+}
+
+// supervise runs child until it completes, gives up, or ctx is done.
+func (s *Supervisor) supervise(ctx context.Context, child Child) error {
+	var recent []time.Time // restarts within Window
+	restarts := 0
+	for attempt := 1; ; attempt++ {
+		s.transition(child.Name, StateRunning, nil, restarts)
+		started := time.Now()
+		err := child.Run(ctx)
+
+		if ctx.Err() != nil {
+			s.transition(child.Name, StateStopped, err, restarts)
+			return nil
+		}
+		if err == nil {
+			s.transition(child.Name, StateCompleted, nil, restarts)
+			return nil
+		}
+		if child.Window > 0 && time.Since(started) > child.Window {
+			// The child ran healthily for a whole window, so this failure
+			// starts a new backoff sequence instead of continuing the last.
+			attempt = 1
+		}
+		if !child.Strategy.ShouldRetry(attempt, err) {
+			s.transition(child.Name, StateFailed, err, restarts)
+			return err
+		}
+
+		now := time.Now()
+		recent = pruneRestarts(append(recent, now), now, child.Window)
+		if child.MaxRestarts > 0 && len(recent) > child.MaxRestarts {
+			err = fmt.Errorf("%w (%d): %w", ErrRestartLimit, child.MaxRestarts, err)
+			s.transition(child.Name, StateFailed, err, restarts)
+			return err
+		}
+
+		restarts++
+		s.transition(child.Name, StateRestarting, err, restarts)
+		timer := time.NewTimer(child.Strategy.NextDelay(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			s.transition(child.Name, StateStopped, nil, restarts)
+			return nil
+		case <-timer.C:
+		}
+	}
+}
+
+// pruneRestarts drops restart times that fall outside window.
+func pruneRestarts(restarts []time.Time, now time.Time, window time.Duration) []time.Time {
+	if window <= 0 {
+		return restarts
+	}
+	cutoff := now.Add(-window)
+	i := 0
+	for i < len(restarts) && restarts[i].Before(cutoff) {
+		i++
+	}
+	return restarts[i:]
+}
+
+func (s *Supervisor) transition(name string, to State, err error, restarts int) {
+	s.mu.Lock()
+	from := s.states[name]
+	s.states[name] = to
+	s.mu.Unlock()
+
+	if s.observer != nil {
+		s.observer.StateChanged(Event{
+			Child:    name,
+			From:     from,
+			To:       to,
+			Err:      err,
+			Restarts: restarts,
+			Time:     time.Now(),
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/remiges-tech/await"
+	"github.com/remiges-tech/await/retry"
+)
+
+// recorder collects events for assertions.
+type recorder struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (r *recorder) StateChanged(e Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+}
+
+func (r *recorder) states(child string) []State {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var states []State
+	for _, e := range r.events {
+		if e.Child == child {
+			states = append(states, e.To)
+		}
+	}
+	return states
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestSupervisor(t *testing.T) {
+	t.Run("restarts failed child until it completes", func(t *testing.T) {
+		rec := &recorder{}
+		s := New(context.Background(), rec)
+
+		var runs atomic.Int32
+		err := s.Add(Child{
+			Name:     "worker",
+			Strategy: &retry.NoDelay{},
+			Run: func(ctx context.Context) error {
+				if runs.Add(1) < 3 {
+					return errors.New("crashed")
+				}
+				return nil
+			},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		waitFor(t, func() bool {
+			state, _ := s.State("worker")
+			return state == StateCompleted
+		})
+		if err := s.Stop(context.Background()); err != nil {
+			t.Fatalf("expected clean stop, got %v", err)
+		}
+
+		want := []State{StateRunning, StateRestarting, StateRunning, StateRestarting, StateRunning, StateCompleted}
+		got := rec.states("worker")
+		if len(got) != len(want) {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("expected %v, got %v", want, got)
+			}
+		}
+	})
+
+	t.Run("gives up after restart limit", func(t *testing.T) {
+		s := New(context.Background(), nil)
+		s.Add(Child{
+			Name:        "flaky",
+			Strategy:    &retry.NoDelay{},
+			MaxRestarts: 2,
+			Window:      time.Minute,
+			Run:         func(ctx context.Context) error { return errors.New("crashed") },
+		})
+
+		waitFor(t, func() bool {
+			state, _ := s.State("flaky")
+			return state == StateFailed
+		})
+
+		err := s.Stop(context.Background())
+		var se *await.ShutdownError
+		if !errors.As(err, &se) || !errors.Is(err, ErrRestartLimit) {
+			t.Fatalf("expected ShutdownError wrapping ErrRestartLimit, got %v", err)
+		}
+	})
+
+	t.Run("healthy run resets the backoff", func(t *testing.T) {
+		s := New(context.Background(), nil)
+		strategy := &attemptRecorder{}
+		var runs atomic.Int32
+		s.Add(Child{
+			Name:     "steady",
+			Strategy: strategy,
+			Window:   20 * time.Millisecond,
+			Run: func(ctx context.Context) error {
+				switch runs.Add(1) {
+				case 3:
+					time.Sleep(40 * time.Millisecond) // outlasts Window
+				case 4:
+					return nil
+				}
+				return errors.New("crashed")
+			},
+		})
+
+		waitFor(t, func() bool {
+			state, _ := s.State("steady")
+			return state == StateCompleted
+		})
+		s.Stop(context.Background())
+
+		if got := strategy.recorded(); len(got) != 3 || got[0] != 1 || got[1] != 2 || got[2] != 1 {
+			t.Fatalf("expected attempts [1 2 1], got %v", got)
+		}
+	})
+
+	t.Run("permanent error is not restarted", func(t *testing.T) {
+		rec := &recorder{}
+		s := New(context.Background(), rec)
+		s.Add(Child{
+			Name:     "config",
+			Strategy: &retry.NoDelay{},
+			Run:      func(ctx context.Context) error { return retry.Permanent(errors.New("bad config")) },
+		})
+
+		waitFor(t, func() bool {
+			state, _ := s.State("config")
+			return state == StateFailed
+		})
+		s.Stop(context.Background())
+
+		if got := rec.states("config"); len(got) != 2 {
+			t.Fatalf("expected [running failed], got %v", got)
+		}
+	})
+
+	t.Run("stop cancels running children", func(t *testing.T) {
+		s := New(context.Background(), nil)
+		s.Add(Child{
+			Name: "server",
+			Run: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+		})
+		waitFor(t, func() bool {
+			state, _ := s.State("server")
+			return state == StateRunning
+		})
+
+		if err := s.Stop(context.Background()); err != nil {
+			t.Fatalf("expected clean stop, got %v", err)
+		}
+		if state, _ := s.State("server"); state != StateStopped {
+			t.Fatalf("expected stopped, got %s", state)
+		}
+		if err := s.Add(Child{Name: "late", Run: func(ctx context.Context) error { return nil }}); !errors.Is(err, await.ErrLifecycleStopped) {
+			t.Fatalf("expected ErrLifecycleStopped, got %v", err)
+		}
+	})
+
+	t.Run("duplicate child", func(t *testing.T) {
+		s := New(context.Background(), nil)
+		defer s.Stop(context.Background())
+		run := func(ctx context.Context) error {
+			<-ctx.Done()
+			return nil
+		}
+		s.Add(Child{Name: "a", Run: run})
+		if err := s.Add(Child{Name: "a", Run: run}); !errors.Is(err, ErrDuplicateChild) {
+			t.Fatalf("expected ErrDuplicateChild, got %v", err)
+		}
+	})
+}
+
+func TestPruneRestarts(t *testing.T) {
+	now := time.Now()
+	restarts := []time.Time{now.Add(-3 * time.Second), now.Add(-time.Second), now}
+	if got := pruneRestarts(restarts, now, 2*time.Second); len(got) != 2 {
+		t.Fatalf("expected 2 restarts in window, got %d", len(got))
+	}
+	if got := pruneRestarts(restarts, now, 0); len(got) != 3 {
+		t.Fatalf("expected all restarts without window, got %d", len(got))
+	}
+}
+
+// attemptRecorder is a Strategy without delays that records the attempt
+// numbers it is asked about.
+type attemptRecorder struct {
+	mu       sync.Mutex
+	attempts []int
+}
+
+func (a *attemptRecorder) ShouldRetry(attempt int, err error) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.attempts = append(a.attempts, attempt)
+	return true
+}
+
+func (a *attemptRecorder) NextDelay(attempt int) time.Duration {
+	return 0
+}
+
+func (a *attemptRecorder) recorded() []int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]int(nil), a.attempts...)
+}
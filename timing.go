@@ -0,0 +1,39 @@
+package await
+
+import (
+	"context"
+	"time"
+)
+
+// Timing records when a task ran. With Options.Timing, All and Reduce fill it
+// in for each Result and AnyWithReport for the winning task, so latency can be
+// analysed without wrapping every task in timing code.
+type Timing struct {
+	Started  time.Time     // When the task started; zero if it never ran
+	Duration time.Duration // How long the task ran, including any retries
+	Attempt  int           // Last attempt made by a task wrapped with retry; 0 if not reported
+}
+
+// RecordAttempt records that the running task has started its attempt-th
+// attempt, for Timing.Attempt. The retry package calls it for every attempt;
+// other retrying wrappers can do the same. It does nothing when ctx does not
+// belong to a task whose timing is recorded.
+func RecordAttempt(ctx context.Context, attempt int) {
+	info := taskInfoFrom(ctx)
+	if info == nil {
+		return
+	}
+	for info.parent != nil {
+		info = info.parent
+	}
+	info.attempt.Store(int32(attempt))
+}
+
+// timing returns the recorded timing of the task, or the zero Timing if it
+// is not tracked.
+func (i *taskInfo) timing() Timing {
+	if i == nil {
+		return Timing{}
+	}
+	return Timing{Started: i.started, Duration: i.duration, Attempt: int(i.attempt.Load())}
+}
@@ -0,0 +1,67 @@
+package await
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTiming(t *testing.T) {
+	ctx := context.Background()
+	sleep := func(d time.Duration) Task[int] {
+		return func(ctx context.Context) (int, error) {
+			time.Sleep(d)
+			return 1, nil
+		}
+	}
+
+	t.Run("All records start and duration", func(t *testing.T) {
+		before := time.Now()
+		results, err := AllWithOptions(ctx, Options{Timing: true}, sleep(20*time.Millisecond), sleep(0))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for i, r := range results {
+			if r.Started.Before(before) {
+				t.Errorf("task %d: expected start time to be set, got %v", i, r.Started)
+			}
+		}
+		if results[0].Duration < 20*time.Millisecond {
+			t.Errorf("expected task 0 to take at least 20ms, got %v", results[0].Duration)
+		}
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		results, _ := All(ctx, sleep(0), sleep(0))
+		if results[0].Timing != (Timing{}) {
+			t.Fatalf("expected zero Timing, got %+v", results[0].Timing)
+		}
+	})
+
+	t.Run("attempts reported by retrying tasks", func(t *testing.T) {
+		retrying := func(ctx context.Context) (int, error) {
+			for attempt := 1; ; attempt++ {
+				RecordAttempt(ctx, attempt)
+				if attempt == 3 {
+					return 3, nil
+				}
+			}
+		}
+		results, _ := AllWithOptions(ctx, Options{Timing: true, Sequential: true}, retrying, sleep(0))
+		if results[0].Attempt != 3 || results[1].Attempt != 0 {
+			t.Fatalf("expected attempts 3 and 0, got %d and %d", results[0].Attempt, results[1].Attempt)
+		}
+	})
+
+	t.Run("AnyWithReport times the winner", func(t *testing.T) {
+		fail := func(ctx context.Context) (int, error) { return 0, errors.New("down") }
+		_, report, err := AnyWithReport(ctx, Options{Timing: true}, fail, sleep(10*time.Millisecond))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if report.Timing.Started.IsZero() || report.Timing.Duration < 10*time.Millisecond {
+			t.Fatalf("expected winner timing, got %+v", report.Timing)
+		}
+	})
+}
@@ -0,0 +1,46 @@
+package await
+
+import "context"
+
+// Pair holds the two values of a function that returns two values and an
+// error, so the function can run as a single Task.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Unpack returns the pair's values.
+func (p Pair[A, B]) Unpack() (A, B) {
+	return p.First, p.Second
+}
+
+// Triple holds the three values of a function that returns three values and
+// an error, so the function can run as a single Task.
+type Triple[A, B, C any] struct {
+	First  A
+	Second B
+	Third  C
+}
+
+// Unpack returns the triple's values.
+func (t Triple[A, B, C]) Unpack() (A, B, C) {
+	return t.First, t.Second, t.Third
+}
+
+// Pack2 adapts a function returning two values and an error into a Task whose
+// value is a Pair, for use with All, Any, Race and the other executors.
+func Pack2[A, B any](fn func(ctx context.Context) (A, B, error)) Task[Pair[A, B]] {
+	return func(ctx context.Context) (Pair[A, B], error) {
+		a, b, err := fn(ctx)
+		return Pair[A, B]{First: a, Second: b}, err
+	}
+}
+
+// Pack3 adapts a function returning three values and an error into a Task
+// whose value is a Triple.
+func Pack3[A, B, C any](fn func(ctx context.Context) (A, B, C, error)) Task[Triple[A, B, C]] {
+	return func(ctx context.Context) (Triple[A, B, C], error) {
+		a, b, c, err := fn(ctx)
+		return Triple[A, B, C]{First: a, Second: b, Third: c}, err
+	}
+}
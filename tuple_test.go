@@ -0,0 +1,42 @@
+package await
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestPack(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Pack2", func(t *testing.T) {
+		divide := func(ctx context.Context) (int, int, error) { return 3, 1, nil }
+		results, err := All(ctx, Pack2(divide))
+		if err != nil || results[0].Err != nil {
+			t.Fatalf("unexpected error: %v, %v", err, results[0].Err)
+		}
+		q, r := results[0].Value.Unpack()
+		if q != 3 || r != 1 {
+			t.Fatalf("expected (3, 1), got (%d, %d)", q, r)
+		}
+	})
+
+	t.Run("Pack3", func(t *testing.T) {
+		user := func(ctx context.Context) (string, int, bool, error) { return "asha", 30, true, nil }
+		v, err := Any(ctx, Pack3(user))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if name, age, active := v.Unpack(); name != "asha" || age != 30 || !active {
+			t.Fatalf("unexpected values %+v", v)
+		}
+	})
+
+	t.Run("error passes through", func(t *testing.T) {
+		errDown := errors.New("down")
+		_, err := Pack2(func(ctx context.Context) (int, string, error) { return 0, "", errDown })(ctx)
+		if !errors.Is(err, errDown) {
+			t.Fatalf("expected errDown, got %v", err)
+		}
+	})
+}
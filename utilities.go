@@ -1 +1,46 @@
 package await
+
+import "context"
+
+// FromFunc adapts a function that takes no context into a Task. The function
+// cannot observe cancellation, so it keeps running after ctx is done.
+func FromFunc[T any](fn func() (T, error)) Task[T] {
+	return func(ctx context.Context) (T, error) {
+		return fn()
+	}
+}
+
+// FromValue returns a Task that succeeds immediately with v, for cached or
+// precomputed values that take part in All, Any or Race alongside real work.
+func FromValue[T any](v T) Task[T] {
+	return func(ctx context.Context) (T, error) {
+		return v, nil
+	}
+}
+
+// FromErr returns a Task that fails immediately with err.
+func FromErr[T any](err error) Task[T] {
+	return func(ctx context.Context) (T, error) {
+		var zero T
+		return zero, err
+	}
+}
+
+// FromChannelValue returns a Task that receives a single value from ch. It
+// fails with ErrChannelClosed if ch is closed, or with ctx's error if ctx is
+// done first. Each run of the task receives a new value.
+func FromChannelValue[T any](ch <-chan T) Task[T] {
+	return func(ctx context.Context) (T, error) {
+		select {
+		case v, ok := <-ch:
+			if !ok {
+				var zero T
+				return zero, ErrChannelClosed
+			}
+			return v, nil
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package await
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestAdapters(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("FromFunc", func(t *testing.T) {
+		task := FromFunc(func() (int, error) { return 42, nil })
+		if v, err := task(ctx); err != nil || v != 42 {
+			t.Fatalf("expected {42, nil}, got {%d, %v}", v, err)
+		}
+	})
+
+	t.Run("FromValue and FromErr", func(t *testing.T) {
+		errDown := errors.New("down")
+		v, err := Any(ctx, FromErr[string](errDown), FromValue("cached"))
+		if err != nil || v != "cached" {
+			t.Fatalf("expected {cached, nil}, got {%q, %v}", v, err)
+		}
+		if _, err := FromErr[string](errDown)(ctx); !errors.Is(err, errDown) {
+			t.Fatalf("expected errDown, got %v", err)
+		}
+	})
+
+	t.Run("FromChannelValue", func(t *testing.T) {
+		ch := make(chan int, 1)
+		task := FromChannelValue(ch)
+
+		ch <- 7
+		if v, err := task(ctx); err != nil || v != 7 {
+			t.Fatalf("expected {7, nil}, got {%d, %v}", v, err)
+		}
+
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		if _, err := task(cancelled); !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+
+		close(ch)
+		if _, err := task(ctx); !errors.Is(err, ErrChannelClosed) {
+			t.Fatalf("expected ErrChannelClosed, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,29 @@
+package await
+
+import "fmt"
+
+// ValidateAs adapts a typed validation function for Options.Validate. Values
+// of another type are rejected with an error.
+func ValidateAs[T any](validate func(T) error) func(value any) error {
+	return func(value any) error {
+		v, ok := value.(T)
+		if !ok {
+			var want T
+			return fmt.Errorf("validator for %T got %T", want, value)
+		}
+		return validate(v)
+	}
+}
+
+// validateResult applies validate to the value of a successful task, turning
+// a rejected value into an error wrapping ErrInvalidResult.
+func validateResult[T any](validate func(any) error, val T, err error) (T, error) {
+	if err != nil || validate == nil {
+		return val, err
+	}
+	if verr := validate(val); verr != nil {
+		var zero T
+		return zero, fmt.Errorf("%w: %w", ErrInvalidResult, verr)
+	}
+	return val, nil
+}
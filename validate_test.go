@@ -0,0 +1,51 @@
+package await
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	ctx := context.Background()
+
+	type quote struct{ price float64 }
+	errZeroPrice := errors.New("zero price")
+	opts := Options{Validate: ValidateAs(func(q quote) error {
+		if q.price <= 0 {
+			return errZeroPrice
+		}
+		return nil
+	})}
+	garbage := func(ctx context.Context) (quote, error) { return quote{}, nil }
+	good := func(ctx context.Context) (quote, error) { return quote{price: 101}, nil }
+
+	t.Run("All records rejected values as failures", func(t *testing.T) {
+		results, err := AllWithOptions(ctx, opts, garbage, good)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !errors.Is(results[0].Err, ErrInvalidResult) || !errors.Is(results[0].Err, errZeroPrice) {
+			t.Fatalf("expected task 0 invalid, got %v", results[0].Err)
+		}
+		if results[1].Err != nil || results[1].Value.price != 101 {
+			t.Fatalf("expected task 1 valid, got %+v", results[1])
+		}
+	})
+
+	t.Run("Any skips rejected values", func(t *testing.T) {
+		q, err := AnyWithOptions(ctx, opts, garbage, good)
+		if err != nil || q.price != 101 {
+			t.Fatalf("expected valid quote, got %+v, %v", q, err)
+		}
+		if _, err := AnyWithOptions(ctx, opts, garbage); !errors.Is(err, ErrInvalidResult) {
+			t.Fatalf("expected ErrInvalidResult, got %v", err)
+		}
+	})
+
+	t.Run("ValidateAs rejects other types", func(t *testing.T) {
+		if err := ValidateAs(func(q quote) error { return nil })("text"); err == nil {
+			t.Fatal("expected type mismatch error")
+		}
+	})
+}
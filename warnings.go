@@ -0,0 +1,67 @@
+package await
+
+import (
+	"context"
+	"sync"
+)
+
+// ResultWithWarnings is a Result together with the non-fatal warnings its
+// task reported with Warn, such as partial data from a provider that should
+// not count as a failure.
+type ResultWithWarnings[T any] struct {
+	Result[T]
+	Warnings []error // Warnings in the order they were reported; nil if none
+}
+
+type warningsKey struct{}
+
+// warningSink collects the warnings of one task. Tasks may report from
+// goroutines of their own, so appends are locked.
+type warningSink struct {
+	mu       sync.Mutex
+	warnings []error
+}
+
+// Warn reports a non-fatal warning for the running task. Warnings are
+// collected by AllWithWarnings; elsewhere Warn does nothing, so tasks can
+// report unconditionally. Nil warnings are ignored. Safe for concurrent use.
+func Warn(ctx context.Context, warning error) {
+	sink, _ := ctx.Value(warningsKey{}).(*warningSink)
+	if sink == nil || warning == nil {
+		return
+	}
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+	sink.warnings = append(sink.warnings, warning)
+}
+
+// AllWithWarnings is like AllWithOptions but also returns the warnings each
+// task reported with Warn alongside its result. Warnings reported by nested
+// calls that do not collect warnings themselves, such as an All inside a
+// task, are attributed to the enclosing task. Warnings reported after a task
+// has returned are dropped.
+func AllWithWarnings[T any](ctx context.Context, opts Options, tasks ...Task[T]) ([]ResultWithWarnings[T], error) {
+	sinks := make([]warningSink, len(tasks))
+	wrapped := make([]Task[T], len(tasks))
+	for i, task := range tasks {
+		task, sink := task, &sinks[i]
+		wrapped[i] = func(ctx context.Context) (T, error) {
+			return task(context.WithValue(ctx, warningsKey{}, sink))
+		}
+	}
+
+	results, err := AllWithOptions(ctx, opts, wrapped...)
+	if results == nil {
+		return nil, err
+	}
+
+	out := make([]ResultWithWarnings[T], len(results))
+	for i, r := range results {
+		out[i].Result = r
+		sinks[i].mu.Lock()
+		out[i].Warnings = sinks[i].warnings
+		sinks[i].warnings = nil
+		sinks[i].mu.Unlock()
+	}
+	return out, err
+}
@@ -0,0 +1,52 @@
+package await
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestAllWithWarnings(t *testing.T) {
+	ctx := context.Background()
+	errStale := errors.New("address is stale")
+	errPartial := errors.New("missing date of birth")
+	errDown := errors.New("down")
+
+	results, err := AllWithWarnings(ctx, Options{},
+		func(ctx context.Context) (string, error) {
+			Warn(ctx, errStale)
+			Warn(ctx, nil)
+			// Warnings from a nested call belong to this task.
+			_, _ = All(ctx, func(ctx context.Context) (int, error) {
+				Warn(ctx, errPartial)
+				return 0, nil
+			}, func(ctx context.Context) (int, error) { return 0, nil })
+			return "cvl", nil
+		},
+		func(ctx context.Context) (string, error) { return "ndml", nil },
+		func(ctx context.Context) (string, error) {
+			Warn(ctx, errPartial)
+			return "", errDown
+		},
+	)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if r := results[0]; r.Value != "cvl" || len(r.Warnings) != 2 || r.Warnings[0] != errStale || r.Warnings[1] != errPartial {
+		t.Errorf("expected value with 2 warnings, got %+v", r)
+	}
+	if r := results[1]; !r.IsOK() || r.Warnings != nil {
+		t.Errorf("expected success without warnings, got %+v", r)
+	}
+	if r := results[2]; !errors.Is(r.Err, errDown) || len(r.Warnings) != 1 {
+		t.Errorf("expected failure that still carries its warning, got %+v", r)
+	}
+
+	// Outside AllWithWarnings, Warn is a no-op.
+	Warn(ctx, errStale)
+
+	if _, err := AllWithWarnings[int](ctx, Options{}); !errors.Is(err, ErrNoTasks) {
+		t.Errorf("expected ErrNoTasks, got %v", err)
+	}
+}
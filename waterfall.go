@@ -0,0 +1,55 @@
+package await
+
+import (
+	"context"
+	"fmt"
+)
+
+// Step is one stage of a sequential chain: it receives the previous stage's
+// output and produces the input for the next.
+type Step[I, O any] func(ctx context.Context, in I) (O, error)
+
+// Then composes two steps into one that runs first, then feeds its output to
+// second. It stops at the first error, or if ctx is done between the steps.
+// Chain Then calls to build a type-checked waterfall of any length.
+func Then[A, B, C any](first Step[A, B], second Step[B, C]) Step[A, C] {
+	return func(ctx context.Context, in A) (C, error) {
+		var zero C
+		mid, err := first(ctx, in)
+		if err != nil {
+			return zero, err
+		}
+		if err := ctx.Err(); err != nil {
+			return zero, err
+		}
+		return second(ctx, mid)
+	}
+}
+
+// Bind fixes the input of step, producing a Task that can be passed to All,
+// Any, Race or a Pool.
+func Bind[I, O any](step Step[I, O], in I) Task[O] {
+	return func(ctx context.Context) (O, error) {
+		return step(ctx, in)
+	}
+}
+
+// Waterfall runs steps sequentially on the caller's goroutine, passing each
+// step's output to the next, starting from initial, and returns the last
+// step's output. It stops at the first failing step, returning its error
+// prefixed with the step index, or with the context error if ctx is done
+// before a step starts. Use Then for type-checked chains.
+func Waterfall(ctx context.Context, initial any, steps ...func(ctx context.Context, in any) (any, error)) (any, error) {
+	val := initial
+	for i, step := range steps {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		out, err := step(ctx, val)
+		if err != nil {
+			return nil, fmt.Errorf("step %d: %w", i, err)
+		}
+		val = out
+	}
+	return val, nil
+}
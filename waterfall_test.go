@@ -0,0 +1,68 @@
+package await
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestWaterfall(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("passes outputs along", func(t *testing.T) {
+		val, err := Waterfall(ctx, "20",
+			func(ctx context.Context, in any) (any, error) { return strconv.Atoi(in.(string)) },
+			func(ctx context.Context, in any) (any, error) { return in.(int) + 1, nil },
+		)
+		if err != nil || val != 21 {
+			t.Fatalf("expected 21, got %v, %v", val, err)
+		}
+	})
+
+	t.Run("stops at first error", func(t *testing.T) {
+		errBoom := errors.New("boom")
+		ran := false
+		_, err := Waterfall(ctx, 1,
+			func(ctx context.Context, in any) (any, error) { return nil, errBoom },
+			func(ctx context.Context, in any) (any, error) {
+				ran = true
+				return in, nil
+			},
+		)
+		if !errors.Is(err, errBoom) || err.Error() != "step 0: boom" {
+			t.Fatalf("expected step 0 error, got %v", err)
+		}
+		if ran {
+			t.Fatal("expected later steps to be skipped")
+		}
+	})
+
+	t.Run("no steps", func(t *testing.T) {
+		if val, err := Waterfall(ctx, 7); err != nil || val != 7 {
+			t.Fatalf("expected initial value, got %v, %v", val, err)
+		}
+	})
+}
+
+func TestThen(t *testing.T) {
+	parse := Step[string, int](func(ctx context.Context, s string) (int, error) { return strconv.Atoi(s) })
+	double := Step[int, int](func(ctx context.Context, n int) (int, error) { return n * 2, nil })
+	format := Step[int, string](func(ctx context.Context, n int) (string, error) { return "#" + strconv.Itoa(n), nil })
+
+	chain := Then(Then(parse, double), format)
+
+	got, err := chain(context.Background(), "21")
+	if err != nil || got != "#42" {
+		t.Fatalf("expected #42, got %q, %v", got, err)
+	}
+
+	if _, err := chain(context.Background(), "x"); err == nil {
+		t.Fatal("expected parse error")
+	}
+
+	results, _ := All(context.Background(), Bind(chain, "1"), Bind(chain, "2"))
+	if results[0].Value != "#2" || results[1].Value != "#4" {
+		t.Fatalf("expected [#2 #4], got %v", Values(results))
+	}
+}